@@ -2,8 +2,18 @@ package main
 
 import (
 	"github.com/normalniydada/case_infotecs/internal/infrastructure/app"
+	"log"
+	"os"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(os.Args[2:]); err != nil {
+			log.Printf("[ERROR] %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	app.Start()
 }
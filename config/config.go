@@ -10,13 +10,26 @@ import (
 	"github.com/spf13/viper"
 	"log"
 	"os"
+	"time"
 )
 
 // Config представляет основную структуру конфигурации приложения.
 // Содержит все необходимые настройки для работы сервера и базы данных.
 type Config struct {
-	Server   ServerConfig   // Настройки HTTP сервера
-	Database DatabaseConfig // Настройки подключения к базе данных
+	Server    ServerConfig         // Настройки HTTP сервера
+	Database  DatabaseConfig       // Настройки подключения к базе данных
+	Admin     AdminConfig          // Настройки привилегированного доступа
+	FX        FXConfig             // Настройки курсов обмена валют
+	Fees      map[string]FeeConfig // Тарифы комиссий за переводы по кодам валют
+	Holds     HoldConfig           // Настройки холдов (резервирования средств)
+	Scheduler SchedulerConfig      // Настройки планировщика запланированных переводов
+	Outbox    OutboxConfig         // Настройки публикации событий из outbox
+	Webhooks  WebhookConfig        // Настройки доставки вебхуков подписчикам
+	Stream    StreamConfig         // Настройки потока транзакций в реальном времени
+	RBAC      RBACConfig           // Роли и разрешения API
+	Limits    LimitsConfig         // Лимиты расходов кошельков
+	Risk      RiskConfig           // Правила проверки переводов
+	RateLimit RateLimitConfig      // Ограничение частоты запросов к API
 }
 
 // DatabaseConfig содержит параметры для подключения к базе данных.
@@ -31,12 +44,142 @@ type DatabaseConfig struct {
 	MaxIdleConns    int    // Макс. количество неактивных соединений в пуле
 	MaxOpenConns    int    // Макс. количество открытых соединений
 	ConnMaxLifetime int    // Макс. время жизни соединения в секундах
+	AutoMigrate     bool   // Применять миграции при старте приложения
 }
 
 // ServerConfig содержит параметры HTTP сервера.
 type ServerConfig struct {
 	Host string // Хост для запуска сервера
 	Port int    // Порт для запуска сервера
+
+	// TrustedProxies - диапазоны адресов (CIDR) обратных прокси, которым доверяется заголовок
+	// X-Forwarded-For. Пустой список - адрес клиента определяется по соединению.
+	TrustedProxies []string
+}
+
+// AdminConfig содержит параметры привилегированного доступа к API.
+type AdminConfig struct {
+	Token string // Токен администратора (загружается из .env)
+}
+
+// FXConfig содержит параметры источника курсов обмена валют.
+type FXConfig struct {
+	RatesFile string // Путь к YAML-файлу курсов (пустая строка - переводы между валютами отключены)
+}
+
+// FeeConfig содержит тариф комиссии за переводы в одной валюте.
+// Суммы задаются строками, чтобы избежать потери точности при разборе float.
+type FeeConfig struct {
+	Collector string          `mapstructure:"collector"` // Адрес кошелька-сборщика комиссий
+	Flat      string          `mapstructure:"flat"`      // Фиксированная часть комиссии
+	Percent   string          `mapstructure:"percent"`   // Процент от суммы перевода
+	Min       string          `mapstructure:"min"`       // Минимальная комиссия
+	Max       string          `mapstructure:"max"`       // Максимальная комиссия
+	Tiers     []FeeTierConfig `mapstructure:"tiers"`     // Ступени тарифа по сумме перевода
+}
+
+// FeeTierConfig содержит ступень тарифа комиссии.
+type FeeTierConfig struct {
+	UpTo    string `mapstructure:"up_to"`   // Верхняя граница суммы (пусто - без границы)
+	Flat    string `mapstructure:"flat"`    // Фиксированная часть комиссии
+	Percent string `mapstructure:"percent"` // Процент от суммы перевода
+}
+
+// HoldConfig содержит параметры холдов.
+type HoldConfig struct {
+	DefaultTTL    time.Duration // Срок холда, если он не указан в запросе
+	MaxTTL        time.Duration // Максимальный срок холда
+	SweepInterval time.Duration // Период снятия резерва с истекших холдов
+}
+
+// SchedulerConfig содержит параметры планировщика запланированных переводов.
+type SchedulerConfig struct {
+	PollInterval time.Duration // Период поиска переводов, срок которых наступил
+	BatchSize    int           // Максимальное число переводов, захватываемых за один раз
+	Lease        time.Duration // Срок аренды захваченного перевода
+	MaxAttempts  int           // Максимальное число попыток одного выполнения
+	RetryBackoff time.Duration // Задержка перед первой повторной попыткой (далее удваивается)
+	MinInterval  time.Duration // Минимальный период регулярного перевода
+}
+
+// OutboxConfig содержит параметры ретранслятора outbox и приемников событий.
+type OutboxConfig struct {
+	PollInterval   time.Duration // Период публикации новых событий
+	BatchSize      int           // Максимальное число событий за один проход
+	RetryBackoff   time.Duration // Задержка перед первой повторной публикацией (далее удваивается)
+	MaxBackoff     time.Duration // Максимальная задержка перед повторной публикацией
+	File           string        // Путь к файлу событий JSON Lines (пустая строка - не использовать)
+	WebhookURL     string        // URL вебхука (пустая строка - не использовать)
+	WebhookTimeout time.Duration // Таймаут запроса к вебхуку
+	ChannelBuffer  int           // Буфер канала событий внутри процесса (0 - не использовать)
+}
+
+// WebhookConfig содержит параметры доставки вебхуков подписчикам.
+type WebhookConfig struct {
+	PollInterval time.Duration // Период поиска доставок, срок попытки которых наступил
+	BatchSize    int           // Максимальное число доставок, захватываемых за один раз
+	Lease        time.Duration // Срок аренды захваченной доставки (больше BatchSize * Timeout)
+	MaxAttempts  int           // Максимальное число попыток доставки
+	RetryBackoff time.Duration // Задержка перед первой повторной попыткой (далее удваивается)
+	MaxBackoff   time.Duration // Максимальная задержка перед повторной попыткой
+	Timeout      time.Duration // Таймаут запроса к получателю
+
+	// AllowPrivateTargets разрешает URL подписок на loopback, частные и link-local адреса
+	// (по умолчанию выключено; только для локальной разработки).
+	AllowPrivateTargets bool
+}
+
+// StreamConfig содержит параметры потока транзакций (SSE, WebSocket).
+type StreamConfig struct {
+	PollInterval time.Duration // Период чтения новых переводов
+	BatchSize    int           // Максимальное число событий за один запрос к БД
+	Buffer       int           // Число событий, которые клиент может не успеть прочитать
+	Heartbeat    time.Duration // Период служебных сообщений SSE
+}
+
+// RBACConfig содержит политику доступа к API: роли и их разрешения.
+type RBACConfig struct {
+	AnonymousRole  string              // Роль запроса без API-ключа (пустая строка - запрос отклоняется с 401)
+	DefaultRole    string              // Роль учетной записи, которой роль не назначена
+	AdminTokenRole string              // Роль клиента с токеном администратора
+	Roles          map[string][]string // Разрешения по ролям ("*" - все разрешения)
+}
+
+// LimitsConfig содержит лимиты расходов кошельков по уровням.
+type LimitsConfig struct {
+	DefaultTier string                                    // Уровень кошельков, которым уровень не назначен
+	Tiers       map[string]map[string]SpendingLimitConfig // Лимиты по уровням и кодам валют
+}
+
+// SpendingLimitConfig содержит лимиты расходов уровня в одной валюте.
+// Суммы задаются строками; пустая строка - без лимита.
+type SpendingLimitConfig struct {
+	MaxSingle string `mapstructure:"max_single"` // Максимальная сумма одного перевода
+	Daily     string `mapstructure:"daily"`      // Лимит расхода за сутки
+	Weekly    string `mapstructure:"weekly"`     // Лимит расхода за неделю
+	Monthly   string `mapstructure:"monthly"`    // Лимит расхода за месяц
+}
+
+// RiskConfig содержит параметры правил проверки переводов.
+type RiskConfig struct {
+	RulesFile string // Путь к YAML-файлу правил (пустая строка - переводы не проверяются)
+}
+
+// RateLimitConfig содержит параметры ограничения частоты запросов к API (корзина токенов).
+type RateLimitConfig struct {
+	Store         string        // Хранилище корзин: memory (один экземпляр) или postgres (несколько экземпляров)
+	SweepInterval time.Duration // Период удаления заполненных корзин
+	Address       RateLimitRule // Ограничение по адресу клиента (до аутентификации)
+	Client        RateLimitRule // Ограничение по API-ключу
+	Wallet        RateLimitRule // Ограничение переводов по кошельку отправителя и учетной записи вызывающего
+}
+
+// RateLimitRule содержит ограничение частоты: корзина на Burst запросов,
+// пополняемая на Requests запросов за Period.
+type RateLimitRule struct {
+	Requests int           // Число запросов за период (0 - без ограничения)
+	Period   time.Duration // Период пополнения корзины
+	Burst    int           // Емкость корзины (0 - равна Requests)
 }
 
 // NewConfig создает и инициализирует новый объект Config.
@@ -58,6 +201,39 @@ func NewConfig() *Config {
 	v.SetConfigType("yaml")
 	v.AddConfigPath("./config")
 
+	v.SetDefault("database.auto_migrate", true)
+	v.SetDefault("holds.default_ttl", "15m")
+	v.SetDefault("holds.max_ttl", "168h")
+	v.SetDefault("holds.sweep_interval", "30s")
+	v.SetDefault("scheduler.poll_interval", "10s")
+	v.SetDefault("scheduler.batch_size", 50)
+	v.SetDefault("scheduler.lease", "1m")
+	v.SetDefault("scheduler.max_attempts", 3)
+	v.SetDefault("scheduler.retry_backoff", "1m")
+	v.SetDefault("scheduler.min_interval", "1m")
+	v.SetDefault("outbox.poll_interval", "1s")
+	v.SetDefault("outbox.batch_size", 100)
+	v.SetDefault("outbox.retry_backoff", "1s")
+	v.SetDefault("outbox.max_backoff", "5m")
+	v.SetDefault("outbox.webhook_timeout", "5s")
+	v.SetDefault("webhooks.poll_interval", "5s")
+	v.SetDefault("webhooks.batch_size", 10)
+	v.SetDefault("webhooks.lease", "2m")
+	v.SetDefault("webhooks.max_attempts", 8)
+	v.SetDefault("webhooks.retry_backoff", "30s")
+	v.SetDefault("webhooks.max_backoff", "1h")
+	v.SetDefault("webhooks.timeout", "10s")
+	v.SetDefault("webhooks.allow_private_targets", false)
+	v.SetDefault("stream.poll_interval", "500ms")
+	v.SetDefault("stream.batch_size", 100)
+	v.SetDefault("stream.buffer", 256)
+	v.SetDefault("stream.heartbeat", "15s")
+	v.SetDefault("rbac.default_role", "operator")
+	v.SetDefault("rbac.admin_token_role", "admin")
+	v.SetDefault("limits.default_tier", "standard")
+	v.SetDefault("rate_limit.store", "memory")
+	v.SetDefault("rate_limit.sweep_interval", "1m")
+
 	if err := v.ReadInConfig(); err != nil {
 		log.Fatalf("[ERROR] Error reading configuration file: %v", err)
 	}
@@ -66,6 +242,8 @@ func NewConfig() *Config {
 		Server: ServerConfig{
 			Host: v.GetString("server.host"),
 			Port: v.GetInt("server.port"),
+
+			TrustedProxies: v.GetStringSlice("server.trusted_proxies"),
 		},
 		Database: DatabaseConfig{
 			Host:            v.GetString("database.host"),
@@ -77,7 +255,96 @@ func NewConfig() *Config {
 			MaxIdleConns:    v.GetInt("database.max_idle_conns"),
 			MaxOpenConns:    v.GetInt("database.max_open_conns"),
 			ConnMaxLifetime: v.GetInt("database.conn_max_lifetime"),
+			AutoMigrate:     v.GetBool("database.auto_migrate"),
+		},
+		Admin: AdminConfig{
+			Token: os.Getenv("ADMIN_TOKEN"),
+		},
+		FX: FXConfig{
+			RatesFile: v.GetString("fx.rates_file"),
+		},
+		Holds: HoldConfig{
+			DefaultTTL:    v.GetDuration("holds.default_ttl"),
+			MaxTTL:        v.GetDuration("holds.max_ttl"),
+			SweepInterval: v.GetDuration("holds.sweep_interval"),
+		},
+		Scheduler: SchedulerConfig{
+			PollInterval: v.GetDuration("scheduler.poll_interval"),
+			BatchSize:    v.GetInt("scheduler.batch_size"),
+			Lease:        v.GetDuration("scheduler.lease"),
+			MaxAttempts:  v.GetInt("scheduler.max_attempts"),
+			RetryBackoff: v.GetDuration("scheduler.retry_backoff"),
+			MinInterval:  v.GetDuration("scheduler.min_interval"),
+		},
+		Outbox: OutboxConfig{
+			PollInterval:   v.GetDuration("outbox.poll_interval"),
+			BatchSize:      v.GetInt("outbox.batch_size"),
+			RetryBackoff:   v.GetDuration("outbox.retry_backoff"),
+			MaxBackoff:     v.GetDuration("outbox.max_backoff"),
+			File:           v.GetString("outbox.file"),
+			WebhookURL:     v.GetString("outbox.webhook_url"),
+			WebhookTimeout: v.GetDuration("outbox.webhook_timeout"),
+			ChannelBuffer:  v.GetInt("outbox.channel_buffer"),
 		},
+		Webhooks: WebhookConfig{
+			PollInterval: v.GetDuration("webhooks.poll_interval"),
+			BatchSize:    v.GetInt("webhooks.batch_size"),
+			Lease:        v.GetDuration("webhooks.lease"),
+			MaxAttempts:  v.GetInt("webhooks.max_attempts"),
+			RetryBackoff: v.GetDuration("webhooks.retry_backoff"),
+			MaxBackoff:   v.GetDuration("webhooks.max_backoff"),
+			Timeout:      v.GetDuration("webhooks.timeout"),
+
+			AllowPrivateTargets: v.GetBool("webhooks.allow_private_targets"),
+		},
+		Stream: StreamConfig{
+			PollInterval: v.GetDuration("stream.poll_interval"),
+			BatchSize:    v.GetInt("stream.batch_size"),
+			Buffer:       v.GetInt("stream.buffer"),
+			Heartbeat:    v.GetDuration("stream.heartbeat"),
+		},
+		RBAC: RBACConfig{
+			AnonymousRole:  v.GetString("rbac.anonymous_role"),
+			DefaultRole:    v.GetString("rbac.default_role"),
+			AdminTokenRole: v.GetString("rbac.admin_token_role"),
+		},
+		Limits: LimitsConfig{
+			DefaultTier: v.GetString("limits.default_tier"),
+		},
+		Risk: RiskConfig{
+			RulesFile: v.GetString("risk.rules_file"),
+		},
+		RateLimit: RateLimitConfig{
+			Store:         v.GetString("rate_limit.store"),
+			SweepInterval: v.GetDuration("rate_limit.sweep_interval"),
+			Address: RateLimitRule{
+				Requests: v.GetInt("rate_limit.address.requests"),
+				Period:   v.GetDuration("rate_limit.address.period"),
+				Burst:    v.GetInt("rate_limit.address.burst"),
+			},
+			Client: RateLimitRule{
+				Requests: v.GetInt("rate_limit.client.requests"),
+				Period:   v.GetDuration("rate_limit.client.period"),
+				Burst:    v.GetInt("rate_limit.client.burst"),
+			},
+			Wallet: RateLimitRule{
+				Requests: v.GetInt("rate_limit.wallet.requests"),
+				Period:   v.GetDuration("rate_limit.wallet.period"),
+				Burst:    v.GetInt("rate_limit.wallet.burst"),
+			},
+		},
+	}
+
+	if err := v.UnmarshalKey("fees", &cfg.Fees); err != nil {
+		log.Fatalf("[ERROR] Error reading fee schedules: %v", err)
+	}
+
+	if err := v.UnmarshalKey("rbac.roles", &cfg.RBAC.Roles); err != nil {
+		log.Fatalf("[ERROR] Error reading roles: %v", err)
+	}
+
+	if err := v.UnmarshalKey("limits.tiers", &cfg.Limits.Tiers); err != nil {
+		log.Fatalf("[ERROR] Error reading spending limits: %v", err)
 	}
 
 	return cfg
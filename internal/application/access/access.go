@@ -0,0 +1,49 @@
+// Package access предоставляет проверку прав субъекта операции (models.Principal) на кошельки.
+// Субъект помещается в контекст запроса промежуточным обработчиком аутентификации.
+package access
+
+import (
+	"context"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// Principal возвращает субъект операции из контекста.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - *models.Principal: субъект операции
+//   - error: ErrUnauthenticated, если запрос анонимный
+func Principal(ctx context.Context) (*models.Principal, error) {
+	principal, ok := models.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, er.ErrUnauthenticated
+	}
+	return principal, nil
+}
+
+// Authorize проверяет, что субъект операции может распоряжаться хотя бы одним из кошельков.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - wallets: кошельки, владение любым из которых дает доступ
+//
+// Возвращает:
+//   - error: ErrUnauthenticated для анонимного запроса,
+//     ErrWalletForbidden, если ни один кошелек не принадлежит субъекту
+func Authorize(ctx context.Context, wallets ...*models.Wallet) error {
+	principal, err := Principal(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, wallet := range wallets {
+		if principal.Owns(wallet) {
+			return nil
+		}
+	}
+
+	return er.ErrWalletForbidden
+}
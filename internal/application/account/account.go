@@ -0,0 +1,338 @@
+// Package account предоставляет сервисный слой для учетных записей и их API-ключей.
+// Реализует выпуск и отзыв ключей и аутентификацию запросов по ключу.
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	// keyPrefix - начало всех API-ключей; по нему ключ легко найти в логах и конфигурации.
+	keyPrefix = "sk_"
+	// displayPrefixLength - длина начала ключа, сохраняемого для отображения в списке ключей.
+	displayPrefixLength = len(keyPrefix) + 8
+	// maxNameLength - максимальная длина имени учетной записи и ключа.
+	maxNameLength = 100
+	// touchInterval - минимальный период записи времени последнего использования ключа.
+	touchInterval = time.Minute
+)
+
+// accountService реализует интерфейс AccountService.
+type accountService struct {
+	repo   repository.AccountRepository
+	policy repository.AccessPolicy
+}
+
+// NewAccountService создает новый экземпляр сервиса учетных записей.
+//
+// Параметры:
+//   - repo: репозиторий учетных записей и API-ключей
+//   - policy: политика доступа, по которой проверяются роли учетных записей
+//
+// Возвращает:
+//   - service.AccountService: реализацию интерфейса сервиса
+func NewAccountService(repo repository.AccountRepository, policy repository.AccessPolicy) service.AccountService {
+	return &accountService{repo: repo, policy: policy}
+}
+
+// CreateAccount создает учетную запись вместе с ее первым API-ключом.
+// Разрешение на операцию (accounts:manage) проверяется на уровне маршрута.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - req: имя и роль учетной записи
+//
+// Возвращает:
+//   - *dto.AccountResponse: созданная учетная запись и ключ (ключ показывается только здесь)
+//   - error: ErrInvalidAccount при пустом или слишком длинном имени или неизвестной роли
+//     или ошибка репозитория
+func (s *accountService) CreateAccount(ctx context.Context, req dto.AccountRequest) (*dto.AccountResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxNameLength {
+		return nil, fmt.Errorf("%w: name must be 1-%d characters", er.ErrInvalidAccount, maxNameLength)
+	}
+
+	role, err := s.role(req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, key, err := newAPIKey("")
+	if err != nil {
+		return nil, err
+	}
+
+	account := &models.Account{PublicID: uuid.NewString(), Name: name, Role: role}
+	if err = s.repo.CreateAccount(ctx, account, key); err != nil {
+		return nil, err
+	}
+
+	resp := s.accountResponse(account)
+	keyResp := dto.NewAPIKeyResponse(key, account)
+	keyResp.Key = plaintext
+	resp.APIKey = &keyResp
+	return &resp, nil
+}
+
+// UpdateAccount назначает учетной записи роль. Роль действует со следующего запроса
+// с любым ключом учетной записи. Разрешение на операцию (accounts:manage) проверяется
+// на уровне маршрута.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: публичный идентификатор учетной записи (UUID)
+//   - req: новая роль (пустая строка - роль по умолчанию)
+//
+// Возвращает:
+//   - *dto.AccountResponse: учетная запись с новой ролью
+//   - error: ErrInvalidAPIKeyID, ErrInvalidAccount (неизвестная роль), ErrAccountNotFound
+//     или ошибка репозитория
+func (s *accountService) UpdateAccount(ctx context.Context, id string,
+	req dto.UpdateAccountRequest) (*dto.AccountResponse, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidAPIKeyID
+	}
+
+	role, err := s.role(req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.repo.Account(ctx, publicID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.UpdateAccountRole(ctx, account, role); err != nil {
+		return nil, err
+	}
+
+	resp := s.accountResponse(account)
+	return &resp, nil
+}
+
+// CreateAPIKey создает API-ключ учетной записи вызывающего. Привилегированный клиент
+// создает ключ для учетной записи, указанной в запросе.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - req: имя ключа и (для привилегированного клиента) учетная запись
+//
+// Возвращает:
+//   - *dto.APIKeyResponse: созданный ключ (сам ключ показывается только здесь)
+//   - error: ошибка, если ключ не создан
+//
+// Возможные ошибки:
+//   - ErrUnauthenticated: запрос без API-ключа
+//   - ErrInvalidAccount: слишком длинное имя или учетная запись не указана привилегированным клиентом
+//   - ErrInvalidAPIKeyID, ErrAccountNotFound, ErrAccountForbidden: невалидная, несуществующая или чужая учетная запись
+func (s *accountService) CreateAPIKey(ctx context.Context, req dto.APIKeyRequest) (*dto.APIKeyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if len(name) > maxNameLength {
+		return nil, fmt.Errorf("%w: name must be at most %d characters", er.ErrInvalidAccount, maxNameLength)
+	}
+
+	account, err := s.account(ctx, req.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, key, err := newAPIKey(name)
+	if err != nil {
+		return nil, err
+	}
+
+	key.AccountID = account.ID
+	if err = s.repo.CreateAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewAPIKeyResponse(key, account)
+	resp.Key = plaintext
+	return &resp, nil
+}
+
+// APIKeys возвращает API-ключи учетной записи вызывающего (без самих ключей),
+// в том числе отозванные. Привилегированный клиент указывает учетную запись.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - accountID: публичный идентификатор учетной записи (пустая строка - учетная запись вызывающего)
+//
+// Возвращает:
+//   - []dto.APIKeyResponse: ключи в порядке создания
+//   - error: те же ошибки доступа к учетной записи, что и у CreateAPIKey, или ошибка репозитория
+func (s *accountService) APIKeys(ctx context.Context, accountID string) ([]dto.APIKeyResponse, error) {
+	account, err := s.account(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.repo.APIKeys(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api keys: %w", err)
+	}
+
+	resp := make([]dto.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		resp = append(resp, dto.NewAPIKeyResponse(&keys[i], account))
+	}
+
+	return resp, nil
+}
+
+// RevokeAPIKey отзывает API-ключ. Учетная запись может отозвать только свой ключ
+// (в том числе ключ, которым выполнен запрос), привилегированный клиент - любой.
+// Ключ другой учетной записи считается не найденным.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: публичный идентификатор ключа (UUID)
+//
+// Возвращает:
+//   - error: ErrUnauthenticated, ErrInvalidAPIKeyID, ErrAPIKeyNotFound или ошибка репозитория
+func (s *accountService) RevokeAPIKey(ctx context.Context, id string) error {
+	principal, err := access.Principal(ctx)
+	if err != nil {
+		return err
+	}
+
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return er.ErrInvalidAPIKeyID
+	}
+
+	key, err := s.repo.APIKey(ctx, publicID.String())
+	if err != nil {
+		return err
+	}
+
+	if !principal.Privileged && key.AccountID != principal.AccountID {
+		return er.ErrAPIKeyNotFound
+	}
+
+	return s.repo.RevokeAPIKey(ctx, key, time.Now())
+}
+
+// Authenticate проверяет API-ключ и возвращает субъект операции - учетную запись ключа.
+// Время последнего использования ключа записывается не чаще, чем раз в touchInterval.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - key: API-ключ из запроса
+//
+// Возвращает:
+//   - *models.Principal: субъект операции
+//   - error: ErrInvalidAPIKey для неизвестного или отозванного ключа или ошибка репозитория
+func (s *accountService) Authenticate(ctx context.Context, key string) (*models.Principal, error) {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil, er.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repo.APIKeyByHash(ctx, hashAPIKey(key))
+	if errors.Is(err, er.ErrAPIKeyNotFound) {
+		return nil, er.ErrInvalidAPIKey
+	} else if err != nil {
+		return nil, err
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil, er.ErrInvalidAPIKey
+	}
+
+	if err = s.repo.TouchAPIKey(ctx, apiKey, time.Now(), touchInterval); err != nil {
+		log.Printf("[WARN] Error updating api key %s last use: %v", apiKey.PublicID, err)
+	}
+
+	return &models.Principal{
+		AccountID:       apiKey.AccountID,
+		AccountPublicID: apiKey.Account.PublicID,
+		KeyID:           apiKey.PublicID,
+		Role:            apiKey.Account.Role,
+	}, nil
+}
+
+// role проверяет роль из запроса. Пустая строка означает роль по умолчанию.
+func (s *accountService) role(role string) (string, error) {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if role != "" && !s.policy.HasRole(role) {
+		return "", fmt.Errorf("%w: unknown role %q", er.ErrInvalidAccount, role)
+	}
+	return role, nil
+}
+
+// accountResponse преобразует учетную запись в DTO ответа с ее действующей ролью.
+func (s *accountService) accountResponse(account *models.Account) dto.AccountResponse {
+	return dto.NewAccountResponse(account, s.policy.Role(&models.Principal{Role: account.Role}))
+}
+
+// account возвращает учетную запись, с которой работает вызывающий: указанную в запросе
+// (привилегированный клиент или сама учетная запись) или учетную запись вызывающего.
+func (s *accountService) account(ctx context.Context, id string) (*models.Account, error) {
+	principal, err := access.Principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == "" {
+		if principal.AccountID == 0 {
+			return nil, fmt.Errorf("%w: account_id is required", er.ErrInvalidAccount)
+		}
+		id = principal.AccountPublicID
+	}
+
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidAPIKeyID
+	}
+
+	if !principal.Privileged && publicID.String() != principal.AccountPublicID {
+		return nil, er.ErrAccountForbidden
+	}
+
+	return s.repo.Account(ctx, publicID.String())
+}
+
+// newAPIKey генерирует API-ключ и его модель (без учетной записи).
+//
+// Возвращает:
+//   - string: ключ, который нужно передать клиенту
+//   - *models.APIKey: модель ключа с его хешем и началом
+//   - error: ошибка генератора случайных чисел
+func newAPIKey(name string) (string, *models.APIKey, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", nil, fmt.Errorf("error generating api key: %w", err)
+	}
+
+	plaintext := keyPrefix + hex.EncodeToString(b)
+	return plaintext, &models.APIKey{
+		PublicID: uuid.NewString(),
+		Name:     name,
+		Prefix:   plaintext[:displayPrefixLength],
+		Hash:     hashAPIKey(plaintext),
+	}, nil
+}
+
+// hashAPIKey вычисляет SHA-256 ключа в hex-формате. Ключ содержит 256 случайных бит,
+// поэтому медленная функция хеширования паролей не нужна.
+func hashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
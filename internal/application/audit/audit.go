@@ -0,0 +1,80 @@
+// Package audit предоставляет сервисный слой для журнала аудита:
+// записи попыток операций API и их чтения.
+package audit
+
+import (
+	"context"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+const (
+	// defaultCount - число записей журнала, если count не указан.
+	defaultCount = 50
+	// maxCount - максимальное число записей журнала за один запрос.
+	maxCount = 500
+)
+
+// auditService реализует интерфейс AuditService.
+type auditService struct {
+	repo repository.AuditRepository
+}
+
+// NewAuditService создает новый экземпляр сервиса журнала аудита.
+//
+// Параметры:
+//   - repo: репозиторий журнала аудита
+//
+// Возвращает:
+//   - service.AuditService: реализацию интерфейса сервиса
+func NewAuditService(repo repository.AuditRepository) service.AuditService {
+	return &auditService{repo: repo}
+}
+
+// Record добавляет запись в журнал аудита.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - event: запись журнала
+//
+// Возвращает:
+//   - error: ошибка репозитория
+func (s *auditService) Record(ctx context.Context, event *models.AuditEvent) error {
+	return s.repo.CreateAuditEvent(ctx, event)
+}
+
+// Events возвращает записи журнала аудита от новых к старым. Следующая страница
+// запрашивается с beforeID, равным идентификатору последней полученной записи.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - beforeID: вернуть записи с идентификатором меньше указанного (0 - с самой новой)
+//   - count: число записей (0 - значение по умолчанию)
+//
+// Возвращает:
+//   - []dto.AuditEventResponse: записи журнала
+//   - error: ErrInvalidCount при count вне диапазона или ошибка репозитория
+func (s *auditService) Events(ctx context.Context, beforeID uint, count int) ([]dto.AuditEventResponse, error) {
+	if count == 0 {
+		count = defaultCount
+	}
+	if count < 0 || count > maxCount {
+		return nil, fmt.Errorf("%w: count must be 1-%d", er.ErrInvalidCount, maxCount)
+	}
+
+	events, err := s.repo.AuditEvents(ctx, beforeID, count)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.AuditEventResponse, 0, len(events))
+	for i := range events {
+		resp = append(resp, dto.NewAuditEventResponse(&events[i]))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,71 @@
+// Package ledger предоставляет сервисный слой для журнала двойной записи.
+// Реализует проверку согласованности кэшированных балансов кошельков с проводками.
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"time"
+)
+
+// ledgerService реализует интерфейс LedgerService.
+// Содержит репозиторий для чтения журнала проводок.
+type ledgerService struct {
+	ledgerRepo repository.LedgerRepository
+}
+
+// NewLedgerService создает новый экземпляр сервиса журнала двойной записи.
+//
+// Параметры:
+//   - ledgerRepo: репозиторий для доступа к журналу проводок
+//
+// Возвращает:
+//   - service.LedgerService: реализацию интерфейса сервиса журнала
+func NewLedgerService(ledgerRepo repository.LedgerRepository) service.LedgerService {
+	return &ledgerService{ledgerRepo: ledgerRepo}
+}
+
+// Verify проверяет согласованность журнала двойной записи.
+//
+// Проверки:
+//   - баланс каждого кошелька равен сумме его проводок
+//   - проводки каждой транзакции сбалансированы
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - *dto.LedgerReport: отчет о проверке
+//   - error: ошибка, если проверку не удалось выполнить
+func (s *ledgerService) Verify(ctx context.Context) (*dto.LedgerReport, error) {
+	mismatches, err := s.ledgerRepo.BalanceMismatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error checking wallet balances: %w", err)
+	}
+
+	unbalanced, err := s.ledgerRepo.UnbalancedTransactions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error checking transaction entries: %w", err)
+	}
+
+	report := &dto.LedgerReport{
+		Consistent:             len(mismatches) == 0 && len(unbalanced) == 0,
+		CheckedAt:              time.Now(),
+		BalanceMismatches:      make([]dto.BalanceMismatch, 0, len(mismatches)),
+		UnbalancedTransactions: make([]string, 0, len(unbalanced)),
+	}
+
+	for _, mismatch := range mismatches {
+		report.BalanceMismatches = append(report.BalanceMismatches, dto.BalanceMismatch{
+			Address:       mismatch.Address,
+			Balance:       mismatch.Balance,
+			LedgerBalance: mismatch.LedgerBalance,
+		})
+	}
+	report.UnbalancedTransactions = append(report.UnbalancedTransactions, unbalanced...)
+
+	return report, nil
+}
@@ -0,0 +1,125 @@
+// Package risk предоставляет сервисный слой проверки переводов правилами
+// (решения allow, deny и review) до их выполнения.
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/shopspring/decimal"
+	"log"
+	"time"
+)
+
+// riskEngine реализует интерфейс RiskEngine.
+// Правила берутся из источника при каждой проверке, поэтому измененные правила
+// действуют со следующего перевода.
+type riskEngine struct {
+	rules           repository.RiskRuleSource
+	walletRepo      repository.WalletRepository
+	transactionRepo repository.TransactionRepository
+}
+
+// NewRiskEngine создает новый экземпляр проверки переводов правилами.
+//
+// Параметры:
+//   - rules: источник правил
+//   - walletRepo: репозиторий кошельков (возраст кошельков отправителя и получателя)
+//   - transactionRepo: репозиторий транзакций (частота и объем переводов отправителя)
+//
+// Возвращает:
+//   - service.RiskEngine: реализацию интерфейса проверки
+func NewRiskEngine(rules repository.RiskRuleSource, walletRepo repository.WalletRepository,
+	transactionRepo repository.TransactionRepository) service.RiskEngine {
+	return &riskEngine{rules: rules, walletRepo: walletRepo, transactionRepo: transactionRepo}
+}
+
+// Evaluate проверяет перевод правилами по порядку. Решение принимает первое правило,
+// все условия которого выполняются; если такого нет, перевод разрешен.
+// Возвраты не проверяются. Переводы отправителя за окно считаются по зафиксированным
+// транзакциям, поэтому предыдущие переводы того же пакета в них не входят.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transaction: подготовленная транзакция (Amount - сумма в валюте отправителя)
+//
+// Возвращает:
+//   - *models.RiskDecision: решение и сработавшее правило
+//   - error: ErrWalletSenderNotFound, ErrWalletReceiverNotFound или ошибка репозитория
+func (e *riskEngine) Evaluate(ctx context.Context, transaction *models.Transaction) (*models.RiskDecision, error) {
+	rules := e.rules.Rules()
+	if len(rules) == 0 || transaction.IsRefund() {
+		return &models.RiskDecision{Outcome: models.RiskOutcomeAllow}, nil
+	}
+
+	facts, err := e.facts(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		matched, err := rules[i].Match(facts)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating risk rule %s: %w", rules[i].Name, err)
+		}
+		if !matched {
+			continue
+		}
+
+		if rules[i].Outcome != models.RiskOutcomeAllow {
+			log.Printf("[INFO] Transfer %s -> %s: risk rule %s decided %s", transaction.From, transaction.To,
+				rules[i].Name, rules[i].Outcome)
+		}
+		return &models.RiskDecision{Outcome: rules[i].Outcome, Rule: rules[i].Name, Reason: rules[i].Reason}, nil
+	}
+
+	return &models.RiskDecision{Outcome: models.RiskOutcomeAllow}, nil
+}
+
+// facts собирает данные перевода для условий правил. Переводы отправителя
+// запрашиваются только условиями, которым они нужны, и не более одного раза на окно.
+func (e *riskEngine) facts(ctx context.Context, transaction *models.Transaction) (*models.RiskFacts, error) {
+	sender, err := e.walletRepo.Wallet(ctx, transaction.From)
+	if errors.Is(err, er.ErrWalletNotFound) {
+		return nil, er.ErrWalletSenderNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	receiver, err := e.walletRepo.Wallet(ctx, transaction.To)
+	if errors.Is(err, er.ErrWalletNotFound) {
+		return nil, er.ErrWalletReceiverNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	type outgoing struct {
+		count  int64
+		amount decimal.Decimal
+	}
+	cache := make(map[time.Time]outgoing)
+
+	return &models.RiskFacts{
+		Transaction: transaction,
+		Sender:      sender,
+		Receiver:    receiver,
+		Now:         time.Now(),
+		Outgoing: func(since time.Time) (int64, decimal.Decimal, error) {
+			if cached, ok := cache[since]; ok {
+				return cached.count, cached.amount, nil
+			}
+
+			count, amount, err := e.transactionRepo.OutgoingSince(ctx, transaction.From, since)
+			if err != nil {
+				return 0, decimal.Zero, err
+			}
+
+			cache[since] = outgoing{count: count, amount: amount}
+			return count, amount, nil
+		},
+	}, nil
+}
@@ -0,0 +1,484 @@
+// Package schedule предоставляет сервисный слой для запланированных переводов.
+// Реализует управление расписаниями и их выполнение планировщиком.
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"log"
+	"net/http"
+	"time"
+)
+
+// runsLimit - число последних попыток выполнения в ответе на запрос перевода.
+const runsLimit = 20
+
+// Policy задает параметры выполнения запланированных переводов.
+type Policy struct {
+	BatchSize    int           // Максимальное число переводов, захватываемых за один раз
+	Lease        time.Duration // Срок аренды захваченного перевода
+	MaxAttempts  int           // Максимальное число попыток одного выполнения
+	RetryBackoff time.Duration // Задержка перед первой повторной попыткой (далее удваивается)
+	MinInterval  time.Duration // Минимальный период регулярного перевода
+}
+
+// scheduledTransferService реализует интерфейс ScheduledTransferService.
+// Переводы выполняются через WalletService.TransferMoney, поэтому к ним применяются
+// те же проверки, курсы обмена и комиссии, что и к переводам через API.
+// Владелец кошелька отправителя проверяется при создании и изменении перевода;
+// планировщик выполняет переводы от имени models.SystemPrincipal.
+type scheduledTransferService struct {
+	repo          repository.ScheduledTransferRepository
+	walletRepo    repository.WalletRepository
+	walletService service.WalletService
+	policy        Policy
+}
+
+// NewScheduledTransferService создает новый экземпляр сервиса запланированных переводов.
+//
+// Параметры:
+//   - repo: репозиторий запланированных переводов
+//   - walletRepo: репозиторий кошельков (проверка владельца кошелька отправителя)
+//   - walletService: сервис кошельков, выполняющий переводы
+//   - policy: параметры выполнения и повторных попыток
+//
+// Возвращает:
+//   - service.ScheduledTransferService: реализацию интерфейса сервиса
+func NewScheduledTransferService(repo repository.ScheduledTransferRepository, walletRepo repository.WalletRepository,
+	walletService service.WalletService, policy Policy) service.ScheduledTransferService {
+	return &scheduledTransferService{repo: repo, walletRepo: walletRepo, walletService: walletService, policy: policy}
+}
+
+// CreateScheduledTransfer создает разовый или регулярный запланированный перевод.
+// Параметры перевода проверяются расчетом (как в QuoteTransfer), поэтому перевод
+// между несуществующими кошельками или с непредставимой суммой не будет запланирован.
+// Запланировать перевод может только владелец кошелька отправителя.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - req: параметры перевода и расписания
+//
+// Возвращает:
+//   - *dto.ScheduledTransferResponse: созданный перевод
+//   - error: ошибка, если перевод не создан
+//
+// Возможные ошибки:
+//   - ErrInvalidSchedule: период меньше минимального или окончание раньше начала
+//   - ErrUnauthenticated, ErrWalletForbidden: запрос без API-ключа или от чужой учетной записи
+//   - ErrSignatureRequired: кошелек отправителя имеет открытый ключ (переводы с него только подписанные)
+//   - ошибки валидации перевода (см. WalletService.QuoteTransfer)
+func (s *scheduledTransferService) CreateScheduledTransfer(ctx context.Context,
+	req dto.ScheduledTransferRequest) (*dto.ScheduledTransferResponse, error) {
+	if !req.Amount.IsPositive() {
+		return nil, er.ErrInvalidAmount
+	}
+
+	sender, err := s.authorize(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	// Запланированный перевод выполняется без подписи, поэтому с кошелька с ключом он недоступен
+	if sender.PublicKey != nil {
+		return nil, er.ErrSignatureRequired
+	}
+
+	startAt := time.Now()
+	if req.StartAt != nil {
+		startAt = *req.StartAt
+	}
+
+	if err := s.validateSchedule(req.IntervalSeconds, startAt, req.EndAt); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.walletService.QuoteTransfer(ctx, dto.TransactionRequest{
+		From: req.From, To: req.To, Amount: req.Amount}); err != nil {
+		return nil, err
+	}
+
+	// PostgreSQL хранит время с точностью до микросекунд
+	startAt = startAt.Truncate(time.Microsecond)
+	transfer := &models.ScheduledTransfer{
+		PublicID:        uuid.NewString(),
+		From:            req.From,
+		To:              req.To,
+		Amount:          req.Amount,
+		IntervalSeconds: req.IntervalSeconds,
+		EndAt:           req.EndAt,
+		Status:          models.ScheduledTransferActive,
+		OccurrenceAt:    startAt,
+		NextRunAt:       startAt,
+	}
+
+	if err := s.repo.CreateScheduledTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewScheduledTransferResponse(transfer, nil)
+	return &resp, nil
+}
+
+// ScheduledTransfer возвращает запланированный перевод и последние попытки его выполнения.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: публичный идентификатор перевода (UUID)
+//
+// Возвращает:
+//   - *dto.ScheduledTransferResponse: перевод с попытками выполнения
+//   - error: ErrInvalidScheduledTransferID, ErrScheduledTransferNotFound или ошибка репозитория
+func (s *scheduledTransferService) ScheduledTransfer(ctx context.Context,
+	id string) (*dto.ScheduledTransferResponse, error) {
+	transfer, err := s.scheduledTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.repo.Runs(ctx, transfer.ID, runsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scheduled transfer runs: %w", err)
+	}
+
+	resp := dto.NewScheduledTransferResponse(transfer, runs)
+	return &resp, nil
+}
+
+// ScheduledTransfers возвращает запланированные переводы кошелька-отправителя.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька-отправителя
+//
+// Возвращает:
+//   - []dto.ScheduledTransferResponse: запланированные переводы (пустой список, если их нет)
+//   - error: ошибка репозитория
+func (s *scheduledTransferService) ScheduledTransfers(ctx context.Context,
+	address string) ([]dto.ScheduledTransferResponse, error) {
+	transfers, err := s.repo.ScheduledTransfers(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scheduled transfers: %w", err)
+	}
+
+	resp := make([]dto.ScheduledTransferResponse, 0, len(transfers))
+	for i := range transfers {
+		resp = append(resp, dto.NewScheduledTransferResponse(&transfers[i], nil))
+	}
+
+	return resp, nil
+}
+
+// UpdateScheduledTransfer изменяет сумму, период, окончание или статус (active/paused)
+// запланированного перевода. Изменения применяются со следующей попытки выполнения.
+// При возобновлении приостановленного перевода пропущенные выполнения не наверстываются:
+// следующее выполнение назначается на ближайшее плановое время.
+// Изменить перевод может только владелец кошелька отправителя.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор перевода (UUID)
+//   - req: изменяемые поля
+//
+// Возвращает:
+//   - *dto.ScheduledTransferResponse: измененный перевод
+//   - error: ошибка, если перевод не изменен
+//
+// Возможные ошибки:
+//   - ErrInvalidScheduledTransferID, ErrScheduledTransferNotFound
+//   - ErrUnauthenticated, ErrWalletForbidden: запрос без API-ключа или от чужой учетной записи
+//   - ErrScheduledTransferFinished: перевод завершен, остановлен или отменен
+//   - ErrInvalidAmount, ErrInvalidSchedule и ошибки валидации перевода
+func (s *scheduledTransferService) UpdateScheduledTransfer(ctx context.Context, id string,
+	req dto.UpdateScheduledTransferRequest) (*dto.ScheduledTransferResponse, error) {
+	transfer, err := s.scheduledTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.authorize(ctx, transfer.From); err != nil {
+		return nil, err
+	}
+
+	if req.Amount != nil {
+		if !req.Amount.IsPositive() {
+			return nil, er.ErrInvalidAmount
+		}
+
+		if _, err = s.walletService.QuoteTransfer(ctx, dto.TransactionRequest{
+			From: transfer.From, To: transfer.To, Amount: *req.Amount}); err != nil {
+			return nil, err
+		}
+		transfer.Amount = *req.Amount
+	}
+
+	if req.IntervalSeconds != nil {
+		transfer.IntervalSeconds = *req.IntervalSeconds
+	}
+
+	if req.EndAt != nil {
+		transfer.EndAt = req.EndAt
+	}
+
+	if err = s.validateSchedule(transfer.IntervalSeconds, transfer.OccurrenceAt, transfer.EndAt); err != nil {
+		return nil, err
+	}
+
+	if req.Status != nil {
+		status := models.ScheduledTransferStatus(*req.Status)
+		if status != models.ScheduledTransferActive && status != models.ScheduledTransferPaused {
+			return nil, er.ErrInvalidSchedule
+		}
+
+		if status == models.ScheduledTransferActive && transfer.Status == models.ScheduledTransferPaused {
+			transfer.OccurrenceAt = nextOccurrence(transfer, time.Now())
+			transfer.NextRunAt = transfer.OccurrenceAt
+			transfer.Attempts = 0
+		}
+		transfer.Status = status
+	}
+
+	if err = s.repo.UpdateScheduledTransfer(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewScheduledTransferResponse(transfer, nil)
+	return &resp, nil
+}
+
+// CancelScheduledTransfer отменяет запланированный перевод. Уже выполненные переводы
+// не отменяются; попытка, выполняющаяся в момент отмены, завершается.
+// Отменить перевод может только владелец кошелька отправителя.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор перевода (UUID)
+//
+// Возвращает:
+//   - error: ErrInvalidScheduledTransferID, ErrScheduledTransferNotFound, ErrUnauthenticated,
+//     ErrWalletForbidden, ErrScheduledTransferFinished или ошибка репозитория
+func (s *scheduledTransferService) CancelScheduledTransfer(ctx context.Context, id string) error {
+	transfer, err := s.scheduledTransfer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.authorize(ctx, transfer.From); err != nil {
+		return err
+	}
+
+	transfer.Status = models.ScheduledTransferCancelled
+	return s.repo.UpdateScheduledTransfer(ctx, transfer)
+}
+
+// RunDue выполняет запланированные переводы, срок которых наступил.
+// Переводы захватываются пачками по policy.BatchSize, пока захватывать нечего.
+// Каждая попытка записывается в журнал выполнения. Перевод выполняется с ключом
+// идемпотентности текущего выполнения, поэтому повтор после сбоя экземпляра
+// (когда перевод прошел, а результат не записан) не списывает средства повторно.
+// Владелец кошелька отправителя проверен при создании перевода, поэтому переводы
+// выполняются от имени models.SystemPrincipal.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - int: количество выполненных попыток
+//   - error: ошибка репозитория
+func (s *scheduledTransferService) RunDue(ctx context.Context) (int, error) {
+	ctx = models.WithPrincipal(ctx, models.SystemPrincipal)
+
+	var total int
+	for {
+		transfers, err := s.repo.ClaimDue(ctx, time.Now(), s.policy.Lease, s.policy.BatchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for i := range transfers {
+			if err = s.run(ctx, &transfers[i]); err != nil {
+				return total, err
+			}
+			total++
+		}
+
+		if len(transfers) < s.policy.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+// run выполняет одну попытку захваченного перевода и записывает ее результат.
+// Перевод, отложенный правилом проверки (ответ 202), завершает выполнение так же, как успешный:
+// дальнейшая судьба перевода решается в очереди отложенных переводов.
+// Постоянные ошибки (кошелек не найден, валюта или сумма невалидны) останавливают перевод;
+// остальные повторяются с экспоненциальной задержкой до policy.MaxAttempts попыток,
+// после чего выполнение пропускается (регулярный перевод) или перевод останавливается (разовый).
+// Внутренний метод, используется в RunDue.
+func (s *scheduledTransferService) run(ctx context.Context, transfer *models.ScheduledTransfer) error {
+	run := &models.ScheduledTransferRun{OccurrenceAt: transfer.OccurrenceAt, Attempt: transfer.Attempts + 1}
+
+	resp, err := s.walletService.TransferMoney(ctx, dto.TransactionRequest{
+		From:   transfer.From,
+		To:     transfer.To,
+		Amount: transfer.Amount,
+	}, idempotencyKey(transfer))
+	if ctx.Err() != nil {
+		// Приложение останавливается: перевод выполнит другой экземпляр после окончания аренды
+		return ctx.Err()
+	}
+
+	now := time.Now()
+	if err == nil && resp.StatusCode == http.StatusAccepted {
+		var pending dto.PendingTransferResponse
+		if err = json.Unmarshal(resp.Body, &pending); err != nil {
+			return fmt.Errorf("error decoding pending transfer: %w", err)
+		}
+
+		run.Status = models.ScheduledTransferRunParked
+		run.PendingTransferID = &pending.ID
+		finishOccurrence(transfer, now, models.ScheduledTransferCompleted)
+	} else if err == nil {
+		var receipt dto.TransactionResponse
+		if err = json.Unmarshal(resp.Body, &receipt); err != nil {
+			return fmt.Errorf("error decoding transfer receipt: %w", err)
+		}
+
+		run.Status = models.ScheduledTransferRunSucceeded
+		run.TransactionID = &receipt.ID
+		finishOccurrence(transfer, now, models.ScheduledTransferCompleted)
+	} else {
+		message := err.Error()
+		run.Status = models.ScheduledTransferRunFailed
+		run.Error = &message
+
+		switch {
+		case isPermanentError(err):
+			transfer.Status = models.ScheduledTransferFailed
+		case run.Attempt >= s.policy.MaxAttempts:
+			finishOccurrence(transfer, now, models.ScheduledTransferFailed)
+		default:
+			transfer.Attempts = run.Attempt
+			transfer.NextRunAt = now.Add(s.policy.RetryBackoff << (run.Attempt - 1))
+
+			// Повторная попытка не должна наступить позже следующего планового выполнения
+			if transfer.IntervalSeconds > 0 && !transfer.NextRunAt.Before(transfer.OccurrenceAt.Add(transfer.Interval())) {
+				finishOccurrence(transfer, now, models.ScheduledTransferFailed)
+			}
+		}
+	}
+
+	err = s.repo.CompleteRun(ctx, transfer, run)
+	if errors.Is(err, er.ErrScheduledTransferClaimLost) {
+		log.Printf("[WARN] Scheduled transfer %s was claimed again before run completion", transfer.PublicID)
+		return nil
+	}
+	return err
+}
+
+// validateSchedule проверяет период и окончание расписания.
+func (s *scheduledTransferService) validateSchedule(intervalSeconds int64, startAt time.Time, endAt *time.Time) error {
+	if intervalSeconds < 0 {
+		return er.ErrInvalidSchedule
+	}
+
+	if intervalSeconds > 0 && time.Duration(intervalSeconds)*time.Second < s.policy.MinInterval {
+		return fmt.Errorf("%w: interval must be at least %s", er.ErrInvalidSchedule, s.policy.MinInterval)
+	}
+
+	if endAt != nil && endAt.Before(startAt) {
+		return fmt.Errorf("%w: end_at is before the first run", er.ErrInvalidSchedule)
+	}
+
+	return nil
+}
+
+// authorize проверяет, что вызывающий владеет кошельком отправителя (в том числе закрытым),
+// и возвращает этот кошелек.
+func (s *scheduledTransferService) authorize(ctx context.Context, address string) (*models.Wallet, error) {
+	if _, err := access.Principal(ctx); err != nil {
+		return nil, err
+	}
+
+	sender, err := s.walletRepo.WalletIncludingClosed(ctx, address)
+	if errors.Is(err, er.ErrWalletNotFound) {
+		return nil, er.ErrWalletSenderNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = access.Authorize(ctx, sender); err != nil {
+		return nil, err
+	}
+	return sender, nil
+}
+
+// scheduledTransfer разбирает идентификатор и возвращает запланированный перевод.
+func (s *scheduledTransferService) scheduledTransfer(ctx context.Context, id string) (*models.ScheduledTransfer, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidScheduledTransferID
+	}
+
+	return s.repo.ScheduledTransfer(ctx, publicID.String())
+}
+
+// finishOccurrence завершает текущее выполнение и назначает следующее.
+// Разовый перевод и регулярный перевод, у которого следующее выполнение позже EndAt,
+// получают статус final (completed после успеха, failed после исчерпания попыток
+// разового перевода; исчерпанное расписание регулярного перевода всегда completed).
+func finishOccurrence(transfer *models.ScheduledTransfer, now time.Time, final models.ScheduledTransferStatus) {
+	transfer.Attempts = 0
+
+	if transfer.IntervalSeconds == 0 {
+		transfer.Status = final
+		return
+	}
+
+	next := nextOccurrence(transfer, now)
+	if transfer.EndAt != nil && next.After(*transfer.EndAt) {
+		transfer.Status = models.ScheduledTransferCompleted
+		return
+	}
+
+	transfer.OccurrenceAt = next
+	transfer.NextRunAt = next
+}
+
+// nextOccurrence возвращает ближайшее плановое время выполнения: текущее выполнение,
+// если его время еще не наступило, иначе первое плановое время после now.
+// Пропущенные выполнения (например, пока приложение было остановлено) не наверстываются.
+// Для разового перевода возвращает время текущего выполнения.
+func nextOccurrence(transfer *models.ScheduledTransfer, now time.Time) time.Time {
+	interval := transfer.Interval()
+	if interval == 0 || transfer.OccurrenceAt.After(now) {
+		return transfer.OccurrenceAt
+	}
+
+	return transfer.OccurrenceAt.Add(interval * (now.Sub(transfer.OccurrenceAt)/interval + 1))
+}
+
+// idempotencyKey возвращает ключ идемпотентности текущего выполнения перевода.
+func idempotencyKey(transfer *models.ScheduledTransfer) string {
+	return fmt.Sprintf("scheduled:%s:%d", transfer.PublicID, transfer.OccurrenceAt.UnixMicro())
+}
+
+// isPermanentError сообщает, что ошибка перевода не исчезнет при повторной попытке.
+func isPermanentError(err error) bool {
+	return errors.Is(err, er.ErrWalletSenderNotFound) ||
+		errors.Is(err, er.ErrWalletReceiverNotFound) ||
+		errors.Is(err, er.ErrSameWalletTransfer) ||
+		errors.Is(err, er.ErrInvalidAmount) ||
+		errors.Is(err, er.ErrAmountAmbiguous) ||
+		errors.Is(err, er.ErrCurrencyMismatch) ||
+		errors.Is(err, er.ErrInvalidAmountPrecision) ||
+		errors.Is(err, er.ErrIdempotencyKeyReused)
+}
@@ -0,0 +1,284 @@
+// Package stream предоставляет поток зафиксированных переводов для клиентов
+// реального времени (SSE, WebSocket).
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"strconv"
+	"sync"
+)
+
+// eventTransfer - тип события потока о зафиксированном переводе.
+const eventTransfer = "transfer"
+
+// Policy задает параметры потока.
+type Policy struct {
+	BatchSize int // Максимальное число событий, читаемых из БД за один запрос
+	Buffer    int // Число событий, которые подписчик может не успеть прочитать
+}
+
+// subscriber - подписчик на новые события. Канал live закрывается, когда подписчик
+// отключается от рассылки (не успевает читать события или поток закрыт).
+type subscriber struct {
+	live chan models.OutboxEvent
+}
+
+// transactionStream реализует интерфейс TransactionStream.
+//
+// Источник потока - события outbox в порядке публикации (PublishSeq). Poll периодически
+// читает новые события и рассылает их подписчикам этого экземпляра приложения; подписчик
+// сначала дочитывает из БД события после Last-Event-ID, затем получает рассылку.
+// Подписчик, не успевающий читать рассылку, отключается от нее и снова дочитывает
+// пропущенное из БД, поэтому события не теряются.
+type transactionStream struct {
+	repo   repository.OutboxRepository
+	policy Policy
+
+	last int64 // Номер последнего разосланного события (-1 - еще не определен); только для Poll
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// NewTransactionStream создает поток зафиксированных переводов.
+//
+// Параметры:
+//   - repo: репозиторий outbox
+//   - policy: параметры чтения и буферизации
+//
+// Возвращает:
+//   - service.TransactionStream: реализацию интерфейса потока
+func NewTransactionStream(repo repository.OutboxRepository, policy Policy) service.TransactionStream {
+	return &transactionStream{
+		repo:        repo,
+		policy:      policy,
+		last:        -1,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe подписывается на поток переводов. Канал событий закрывается при отмене ctx
+// или закрытии потока.
+//
+// Параметры:
+//   - ctx: контекст подписки (обычно контекст HTTP-запроса)
+//   - wallet: адрес кошелька для фильтрации (отправитель или получатель; пустая строка - все переводы)
+//   - lastEventID: номер последнего полученного события; поток продолжается со следующего.
+//     Пустая строка - только новые переводы
+//
+// Возвращает:
+//   - <-chan dto.StreamEvent: события потока
+//   - error: ErrInvalidLastEventID, ErrStreamClosed или ошибка репозитория
+func (s *transactionStream) Subscribe(ctx context.Context, wallet, lastEventID string) (<-chan dto.StreamEvent, error) {
+	var last int64
+	if lastEventID != "" {
+		var err error
+		last, err = strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || last < 0 {
+			return nil, er.ErrInvalidLastEventID
+		}
+	} else {
+		var err error
+		if last, err = s.repo.LastPublishSeq(ctx); err != nil {
+			return nil, fmt.Errorf("error getting stream position: %w", err)
+		}
+	}
+
+	sub, err := s.subscribe()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan dto.StreamEvent)
+	go s.serve(ctx, sub, wallet, last, out)
+
+	return out, nil
+}
+
+// Poll читает новые опубликованные события и рассылает их подписчикам.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - error: ошибка репозитория
+func (s *transactionStream) Poll(ctx context.Context) error {
+	if s.last < 0 {
+		last, err := s.repo.LastPublishSeq(ctx)
+		if err != nil {
+			return err
+		}
+		s.last = last
+	}
+
+	for {
+		events, err := s.repo.Published(ctx, s.last, models.EventTransferCompleted, "", s.policy.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range events {
+			s.broadcast(events[i])
+			s.last = *events[i].PublishSeq
+		}
+
+		if len(events) < s.policy.BatchSize {
+			return nil
+		}
+	}
+}
+
+// Close закрывает поток: каналы всех подписчиков закрываются, новые подписки отклоняются.
+func (s *transactionStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.live)
+	}
+}
+
+// serve передает подписчику события после last: сначала из БД, затем из рассылки.
+// Если подписчик отключен от рассылки из-за медленного чтения, он подписывается
+// заново и дочитывает пропущенное из БД.
+// Внутренний метод, выполняется в отдельной goroutine для каждой подписки.
+func (s *transactionStream) serve(ctx context.Context, sub *subscriber, wallet string, last int64,
+	out chan<- dto.StreamEvent) {
+	defer close(out)
+
+	for {
+		var ok bool
+		if last, ok = s.catchUp(ctx, wallet, last, out); !ok {
+			s.unsubscribe(sub)
+			return
+		}
+
+	live:
+		for {
+			select {
+			case event, open := <-sub.live:
+				if !open {
+					break live
+				}
+
+				seq := *event.PublishSeq
+				if seq <= last {
+					// Событие уже передано при чтении из БД
+					continue
+				}
+
+				if matches(&event, wallet) && !send(ctx, out, &event) {
+					s.unsubscribe(sub)
+					return
+				}
+				last = seq
+			case <-ctx.Done():
+				s.unsubscribe(sub)
+				return
+			}
+		}
+
+		// Рассылка закрыта: поток закрыт или подписчик не успевал читать события
+		var err error
+		if sub, err = s.subscribe(); err != nil {
+			return
+		}
+	}
+}
+
+// catchUp передает подписчику события после last, уже записанные в БД.
+// Возвращает номер последнего переданного события и false, если передача прервана.
+func (s *transactionStream) catchUp(ctx context.Context, wallet string, last int64,
+	out chan<- dto.StreamEvent) (int64, bool) {
+	for {
+		events, err := s.repo.Published(ctx, last, models.EventTransferCompleted, wallet, s.policy.BatchSize)
+		if err != nil {
+			return last, false
+		}
+
+		for i := range events {
+			if matches(&events[i], wallet) && !send(ctx, out, &events[i]) {
+				return last, false
+			}
+			last = *events[i].PublishSeq
+		}
+
+		if len(events) < s.policy.BatchSize {
+			return last, true
+		}
+	}
+}
+
+// subscribe подключает нового подписчика к рассылке.
+func (s *transactionStream) subscribe() (*subscriber, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, er.ErrStreamClosed
+	}
+
+	sub := &subscriber{live: make(chan models.OutboxEvent, s.policy.Buffer)}
+	s.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// unsubscribe отключает подписчика от рассылки.
+func (s *transactionStream) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.live)
+	}
+}
+
+// broadcast рассылает событие подписчикам. Подписчик с заполненным буфером
+// отключается от рассылки, чтобы не задерживать остальных.
+func (s *transactionStream) broadcast(event models.OutboxEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		select {
+		case sub.live <- event:
+		default:
+			delete(s.subscribers, sub)
+			close(sub.live)
+		}
+	}
+}
+
+// matches проверяет, что перевод касается кошелька wallet (пустая строка - любой перевод).
+func matches(event *models.OutboxEvent, wallet string) bool {
+	if wallet == "" {
+		return true
+	}
+
+	var transfer models.TransferCompleted
+	if err := json.Unmarshal(event.Payload, &transfer); err != nil {
+		return false
+	}
+	return transfer.From == wallet || transfer.To == wallet
+}
+
+// send передает событие подписчику. Возвращает false, если подписка отменена.
+func send(ctx context.Context, out chan<- dto.StreamEvent, event *models.OutboxEvent) bool {
+	select {
+	case out <- dto.StreamEvent{ID: *event.PublishSeq, Type: eventTransfer, Data: event.Payload}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
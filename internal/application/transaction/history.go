@@ -0,0 +1,137 @@
+// Package transaction предоставляет сервисный слой для работы с транзакциями.
+// Реализует бизнес-логику обработки транзакций между кошельками.
+package transaction
+
+import (
+	"encoding/base64"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/shopspring/decimal"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHistoryLimit = 20  // Размер страницы истории по умолчанию
+	maxHistoryLimit     = 100 // Максимальный размер страницы истории
+)
+
+// parseHistoryFilter преобразует параметры query-строки в фильтр репозитория.
+//
+// Параметры:
+//   - address: адрес кошелька
+//   - req: параметры выборки из query-строки
+//
+// Возвращает:
+//   - repository.TransactionFilter: фильтр выборки
+//   - error: ErrInvalidHistoryFilter или ErrInvalidCursor при невалидных параметрах
+func parseHistoryFilter(address string, req dto.TransactionHistoryRequest) (repository.TransactionFilter, error) {
+	filter := repository.TransactionFilter{
+		Address:   address,
+		Direction: repository.DirectionBoth,
+		Limit:     defaultHistoryLimit,
+	}
+
+	switch direction := repository.TransactionDirection(req.Direction); direction {
+	case "":
+	case repository.DirectionIncoming, repository.DirectionOutgoing, repository.DirectionBoth:
+		filter.Direction = direction
+	default:
+		return filter, fmt.Errorf("%w: direction must be incoming, outgoing or both", er.ErrInvalidHistoryFilter)
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseTime(req.CreatedFrom, "created_from"); err != nil {
+		return filter, err
+	}
+	if filter.CreatedTo, err = parseTime(req.CreatedTo, "created_to"); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount, err = parseAmount(req.MinAmount, "min_amount"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = parseAmount(req.MaxAmount, "max_amount"); err != nil {
+		return filter, err
+	}
+
+	if req.Limit != "" {
+		limit, err := strconv.Atoi(req.Limit)
+		if err != nil || limit <= 0 || limit > maxHistoryLimit {
+			return filter, fmt.Errorf("%w: limit must be between 1 and %d", er.ErrInvalidHistoryFilter, maxHistoryLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &cursor
+	}
+
+	return filter, nil
+}
+
+// parseTime разбирает необязательный параметр даты в формате RFC3339.
+func parseTime(value, name string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC3339 timestamp", er.ErrInvalidHistoryFilter, name)
+	}
+
+	return &t, nil
+}
+
+// parseAmount разбирает необязательный параметр суммы.
+func parseAmount(value, name string) (*decimal.Decimal, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be a decimal number", er.ErrInvalidHistoryFilter, name)
+	}
+
+	return &amount, nil
+}
+
+// encodeCursor кодирует позицию выборки в непрозрачную для клиента строку.
+// Формат до кодирования: "<created_at в наносекундах Unix>:<id>".
+func encodeCursor(cursor repository.TransactionCursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.CreatedAt.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor восстанавливает позицию выборки из строки, полученной от encodeCursor.
+func decodeCursor(value string) (repository.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return repository.TransactionCursor{}, er.ErrInvalidCursor
+	}
+
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found {
+		return repository.TransactionCursor{}, er.ErrInvalidCursor
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return repository.TransactionCursor{}, er.ErrInvalidCursor
+	}
+
+	internalID, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return repository.TransactionCursor{}, er.ErrInvalidCursor
+	}
+
+	return repository.TransactionCursor{CreatedAt: time.Unix(0, unixNano), ID: uint(internalID)}, nil
+}
@@ -0,0 +1,174 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// ratePrecision - число знаков после запятой у курса, сохраняемого в транзакции возврата.
+const ratePrecision = 16
+
+// Refund возвращает отправителю исходной транзакции всю сумму или ее часть.
+// Возврат создается новой транзакцией в обратном направлении (от получателя к отправителю),
+// связанной с исходной через RefundOf. Комиссия исходной транзакции не возвращается,
+// за возврат комиссия не взимается.
+//
+// Сумма возврата задается в валюте отправителя исходной транзакции. Если исходная
+// транзакция была с конвертацией, с получателя списывается пропорциональная часть
+// зачисленной ему суммы по курсу исходной транзакции (с округлением вниз), а последний
+// возврат списывает весь остаток, поэтому в сумме с получателя не списывается больше,
+// чем ему было зачислено.
+//
+// Выполнить возврат может только владелец кошелька получателя исходной транзакции.
+// Возврат не подписывается, поэтому с кошелька, имеющего открытый ключ, он недоступен.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор исходной транзакции (UUID)
+//   - req: сумма возврата (ноль - весь невозвращенный остаток)
+//
+// Возвращает:
+//   - *dto.TransactionResponse: квитанция о транзакции возврата
+//   - error: ошибка, если возврат не выполнен
+//
+// Возможные ошибки:
+//   - ErrInvalidTransactionID: если идентификатор не является UUID
+//   - ErrTransactionIDNotFound: если транзакция не найдена
+//   - ErrUnauthenticated, ErrWalletForbidden: запрос без API-ключа или от чужой учетной записи
+//   - ErrSignatureRequired: кошелек получателя имеет открытый ключ (списания с него только подписанные)
+//   - ErrRefundOfRefund: если транзакция сама является возвратом
+//   - ErrInvalidAmount, ErrInvalidAmountPrecision: при невалидной сумме
+//   - ErrRefundExceedsOriginal: если возврат превысит невозвращенный остаток
+//   - ErrRefundNotEnoughMoney: если у получателя недостаточно средств
+//   - Другие ошибки репозитория: при проблемах доступа к данным
+func (s *transactionService) Refund(ctx context.Context, id string, req dto.RefundRequest) (*dto.TransactionResponse, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidTransactionID
+	}
+
+	if _, err = access.Principal(ctx); err != nil {
+		return nil, err
+	}
+
+	original, err := s.transactionRepo.Transaction(ctx, publicID.String())
+	if err != nil {
+		return nil, fmt.Errorf("error getting transaction: %w", err)
+	}
+
+	receiver, err := s.walletRepo.WalletIncludingClosed(ctx, original.To)
+	if err != nil {
+		return nil, fmt.Errorf("error getting receiver wallet: %w", err)
+	}
+
+	if err = access.Authorize(ctx, receiver); err != nil {
+		return nil, err
+	}
+
+	// Возврат списывает средства с получателя без подписи, поэтому с кошелька с ключом возврат недоступен
+	if receiver.PublicKey != nil {
+		return nil, er.ErrSignatureRequired
+	}
+
+	if original.IsRefund() {
+		return nil, er.ErrRefundOfRefund
+	}
+
+	refundable := original.Refundable()
+	amount := req.Amount
+	if amount.IsZero() {
+		amount = refundable
+	}
+
+	if amount.IsNegative() {
+		return nil, er.ErrInvalidAmount
+	}
+
+	if !refundable.IsPositive() || amount.GreaterThan(refundable) {
+		return nil, er.ErrRefundExceedsOriginal
+	}
+
+	debit, err := s.refundDebit(ctx, original, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := &models.Transaction{
+		PublicID:       uuid.NewString(),
+		From:           original.To,
+		To:             original.From,
+		Amount:         debit,
+		Currency:       original.TargetCurrency,
+		TargetAmount:   amount,
+		TargetCurrency: original.Currency,
+		Rate:           amount.DivRound(debit, ratePrecision),
+		Spread:         decimal.Zero,
+		RefundOf:       &original.PublicID,
+	}
+	// PostgreSQL хранит время с точностью до микросекунд
+	refund.CreatedAt = time.Now().Truncate(time.Microsecond)
+
+	if err = s.transactionRepo.Refund(ctx, refund); err != nil {
+		return nil, fmt.Errorf("error refunding transaction: %w", err)
+	}
+
+	resp := dto.NewTransactionResponse(refund)
+	return &resp, nil
+}
+
+// refundDebit рассчитывает сумму, списываемую с получателя исходной транзакции
+// при возврате amount ее отправителю.
+func (s *transactionService) refundDebit(ctx context.Context, original *models.Transaction,
+	amount decimal.Decimal) (decimal.Decimal, error) {
+	source, err := s.currencyRepo.Currency(ctx, original.Currency)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("error getting sender currency: %w", err)
+	}
+
+	if !source.ValidAmount(amount) {
+		return decimal.Zero, fmt.Errorf("%w: %s allows %d decimals in multiples of %s",
+			er.ErrInvalidAmountPrecision, source.Code, source.Decimals, source.MinUnit)
+	}
+
+	if !original.IsExchange() {
+		return amount, nil
+	}
+
+	// Последний возврат списывает весь остаток зачисленной суммы. Если конкурентный
+	// возврат успеет раньше, репозиторий отклонит этот как превышающий остаток.
+	if amount.Equal(original.Refundable()) {
+		refunds, err := s.transactionRepo.Refunds(ctx, original.PublicID)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("error getting refunds: %w", err)
+		}
+
+		debit := original.TargetAmount
+		for i := range refunds {
+			debit = debit.Sub(refunds[i].Amount)
+		}
+		if !debit.IsPositive() {
+			return decimal.Zero, er.ErrInvalidAmount
+		}
+		return debit, nil
+	}
+
+	target, err := s.currencyRepo.Currency(ctx, original.TargetCurrency)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("error getting receiver currency: %w", err)
+	}
+
+	debit := target.RoundDown(amount.Mul(original.TargetAmount).DivRound(original.Amount, ratePrecision))
+	if !debit.IsPositive() {
+		// Сумма возврата слишком мала и после конвертации округляется до нуля
+		return decimal.Zero, er.ErrInvalidAmount
+	}
+
+	return debit, nil
+}
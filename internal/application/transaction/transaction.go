@@ -5,6 +5,7 @@ package transaction
 import (
 	"context"
 	"fmt"
+	"github.com/google/uuid"
 	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
 	"github.com/normalniydada/case_infotecs/internal/domain/repository"
 	"github.com/normalniydada/case_infotecs/internal/domain/service"
@@ -12,20 +13,26 @@ import (
 )
 
 // transactionService реализует интерфейс TransactionService.
-// Содержит репозиторий для работы с данными транзакций.
+// Содержит репозитории транзакций, валют (для округления сумм возврата)
+// и кошельков (для проверки владельца кошелька, с которого выполняется возврат).
 type transactionService struct {
 	transactionRepo repository.TransactionRepository
+	currencyRepo    repository.CurrencyRepository
+	walletRepo      repository.WalletRepository
 }
 
 // NewTransactionService создает новый экземпляр сервиса для работы с транзакциями.
 //
 // Параметры:
 //   - transactionRepo: репозиторий для доступа к данным транзакций
+//   - currencyRepo: репозиторий валют
+//   - walletRepo: репозиторий кошельков
 //
 // Возвращает:
 //   - service.TransactionService: реализацию интерфейса сервиса транзакций
-func NewTransactionService(transactionRepo repository.TransactionRepository) service.TransactionService {
-	return &transactionService{transactionRepo: transactionRepo}
+func NewTransactionService(transactionRepo repository.TransactionRepository,
+	currencyRepo repository.CurrencyRepository, walletRepo repository.WalletRepository) service.TransactionService {
+	return &transactionService{transactionRepo: transactionRepo, currencyRepo: currencyRepo, walletRepo: walletRepo}
 }
 
 // LastNTransactions возвращает последние N транзакций из системы.
@@ -54,14 +61,84 @@ func (s *transactionService) LastNTransactions(ctx context.Context, n int) ([]dt
 	}
 
 	respTransactions := make([]dto.TransactionResponse, 0, len(transactions))
-	for _, transaction := range transactions {
-		respTransactions = append(respTransactions, dto.TransactionResponse{
-			From:      transaction.From,
-			To:        transaction.To,
-			Amount:    transaction.Amount,
-			CreatedAt: transaction.CreatedAt,
-		})
+	for i := range transactions {
+		respTransactions = append(respTransactions, dto.NewTransactionResponse(&transactions[i]))
 	}
 
 	return respTransactions, nil
 }
+
+// Transaction возвращает транзакцию по ее публичному идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: публичный идентификатор транзакции (UUID)
+//
+// Возвращает:
+//   - *dto.TransactionResponse: транзакция в формате DTO
+//   - error: ошибка, если транзакцию не удалось получить
+//
+// Возможные ошибки:
+//   - ErrInvalidTransactionID: если идентификатор не является UUID
+//   - ErrTransactionIDNotFound: если транзакция не найдена
+//   - Другие ошибки репозитория: при проблемах доступа к данным
+func (s *transactionService) Transaction(ctx context.Context, id string) (*dto.TransactionResponse, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidTransactionID
+	}
+
+	transaction, err := s.transactionRepo.Transaction(ctx, publicID.String())
+	if err != nil {
+		return nil, fmt.Errorf("error getting transaction: %w", err)
+	}
+
+	resp := dto.NewTransactionResponse(transaction)
+	return &resp, nil
+}
+
+// WalletTransactions возвращает страницу истории транзакций кошелька с учетом фильтров.
+// Пустая история не считается ошибкой: возвращается пустая страница.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//   - req: параметры выборки из query-строки
+//
+// Возвращает:
+//   - *dto.TransactionPage: страница транзакций и курсор следующей страницы
+//   - error: ошибка, если выборку не удалось выполнить
+//
+// Возможные ошибки:
+//   - ErrInvalidHistoryFilter: при невалидных параметрах выборки
+//   - ErrInvalidCursor: при невалидном курсоре
+//   - Другие ошибки репозитория: при проблемах доступа к данным
+func (s *transactionService) WalletTransactions(ctx context.Context, address string,
+	req dto.TransactionHistoryRequest) (*dto.TransactionPage, error) {
+	filter, err := parseHistoryFilter(address, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Запрашиваем на одну запись больше, чтобы узнать, есть ли следующая страница
+	limit := filter.Limit
+	filter.Limit++
+
+	transactions, err := s.transactionRepo.WalletTransactions(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error getting wallet transactions: %w", err)
+	}
+
+	page := &dto.TransactionPage{Transactions: make([]dto.TransactionResponse, 0, limit)}
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+		last := transactions[limit-1]
+		page.NextCursor = encodeCursor(repository.TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	for i := range transactions {
+		page.Transactions = append(page.Transactions, dto.NewTransactionResponse(&transactions[i]))
+	}
+
+	return page, nil
+}
@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"net/http"
+	"strings"
+)
+
+// maxBatchLegs - максимальное число переводов в одном пакете.
+const maxBatchLegs = 100
+
+// TransferBatch атомарно выполняет пакет переводов: либо все переводы фиксируются
+// в одной транзакции БД, либо ни один. Каждый перевод рассчитывается так же,
+// как в TransferMoney (курс обмена и комиссия), и получает общий идентификатор пакета.
+// Переводы выполняются в порядке следования в запросе.
+// Вызывающий должен владеть кошельками отправителей всех переводов. Переводы с кошельков,
+// имеющих открытый ключ, подписываются по отдельности; переводы одного кошелька в пакете
+// должны иметь возрастающие номера (nonce).
+// Каждый перевод проверяется правилами и порогом согласования кошелька отправителя; пакет
+// не откладывается, поэтому решение review для любого перевода отклоняет пакет так же, как решение deny.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - req: переводы пакета
+//   - idempotencyKey: значение заголовка Idempotency-Key (пустая строка - без идемпотентности)
+//
+// Возвращает:
+//   - *dto.StoredResponse: ответ с идентификатором пакета и квитанциями о переводах
+//   - error: ошибка, если пакет не выполнен
+//
+// Возможные ошибки:
+//   - ErrInvalidBatchSize: если пакет пуст или превышает maxBatchLegs
+//   - *er.BatchLegError: ошибка перевода с его номером (те же ошибки, что и у TransferMoney,
+//     а также ErrTransferReviewRequired)
+//   - ErrIdempotencyKeyReused: если ключ уже использован с другими параметрами
+func (s *walletService) TransferBatch(ctx context.Context, req dto.BatchTransferRequest,
+	idempotencyKey string) (*dto.StoredResponse, error) {
+	if len(req.Legs) == 0 || len(req.Legs) > maxBatchLegs {
+		return nil, er.ErrInvalidBatchSize
+	}
+
+	for i := range req.Legs {
+		if err := validateTransferRequest(req.Legs[i]); err != nil {
+			return nil, &er.BatchLegError{Leg: i, Err: err}
+		}
+	}
+
+	for i := range req.Legs {
+		sender, err := s.authorizeSender(ctx, req.Legs[i].From)
+		if err != nil {
+			return nil, &er.BatchLegError{Leg: i, Err: err}
+		}
+
+		if err = verifySignature(sender, req.Legs[i]); err != nil {
+			return nil, &er.BatchLegError{Leg: i, Err: err}
+		}
+	}
+
+	var requestHash string
+	if idempotencyKey != "" {
+		requestHash = hashBatchRequest(req)
+
+		// Повтор запроса: отдаем сохраненный результат
+		stored, err := s.replay(ctx, idempotencyKey, requestHash)
+		if !errors.Is(err, er.ErrIdempotencyKeyNotFound) {
+			return stored, err
+		}
+	}
+
+	batchID := uuid.NewString()
+	batch := &dto.BatchTransferResponse{BatchID: batchID, Legs: make([]dto.TransactionResponse, 0, len(req.Legs))}
+	transactions := make([]*models.Transaction, 0, len(req.Legs))
+
+	for i := range req.Legs {
+		transaction, err := s.prepareTransfer(ctx, req.Legs[i])
+		if err != nil {
+			return nil, &er.BatchLegError{Leg: i, Err: err}
+		}
+
+		// Пакет нельзя отложить частично, поэтому перевод, требующий проверки, отклоняет весь пакет
+		if err = s.requireAllowed(ctx, transaction); err != nil {
+			return nil, &er.BatchLegError{Leg: i, Err: err}
+		}
+
+		transaction.BatchID = &batchID
+		transactions = append(transactions, transaction)
+		batch.Legs = append(batch.Legs, dto.NewTransactionResponse(transaction))
+	}
+
+	response, err := newStoredResponse(http.StatusOK, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	var record *models.IdempotencyKey
+	if idempotencyKey != "" {
+		record = &models.IdempotencyKey{
+			AccountID:    idempotencyAccount(ctx),
+			Key:          idempotencyKey,
+			RequestHash:  requestHash,
+			StatusCode:   response.StatusCode,
+			ResponseBody: response.Body,
+		}
+	}
+
+	err = s.walletRepo.TransferBatch(ctx, transactions, record)
+	if errors.Is(err, er.ErrIdempotencyKeyExists) {
+		// Конкурентный запрос с тем же ключом зафиксировался первым
+		return s.replay(ctx, idempotencyKey, requestHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// hashBatchRequest вычисляет отпечаток параметров пакетного перевода из отпечатков его переводов.
+// Префикс отличает отпечаток пакета из одного перевода от отпечатка одиночного перевода.
+//
+// Возвращает:
+//   - string: SHA-256 хеш параметров в hex-формате
+func hashBatchRequest(req dto.BatchTransferRequest) string {
+	legs := make([]string, 0, len(req.Legs))
+	for i := range req.Legs {
+		legs = append(legs, hashTransferRequest(req.Legs[i]))
+	}
+
+	hash := sha256.Sum256([]byte("batch\n" + strings.Join(legs, "\n")))
+	return hex.EncodeToString(hash[:])
+}
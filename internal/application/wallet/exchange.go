@@ -0,0 +1,106 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/shopspring/decimal"
+)
+
+// ratePrecision - количество знаков после запятой у примененного курса
+// (совпадает с точностью колонки transactions.rate, чтобы квитанция не расходилась с БД).
+const ratePrecision = 16
+
+// price рассчитывает суммы перевода и заполняет поля транзакции, связанные с обменом.
+// Задается ровно одна из сумм: amount (списание в валюте отправителя)
+// или targetAmount (зачисление в валюте получателя).
+//
+// Вторая сумма округляется в пользу системы: зачисление - вниз, списание - вверх
+// до минимальной единицы валюты. Для кошельков в одной валюте курс равен 1, спред - 0.
+// Внутренний метод, используется при подготовке перевода.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transaction: заполняемая транзакция
+//   - sourceCode: валюта кошелька отправителя
+//   - targetCode: валюта кошелька получателя
+//   - amount: сумма списания (ноль, если задана сумма зачисления)
+//   - targetAmount: сумма зачисления (ноль, если задана сумма списания)
+//
+// Возвращает:
+//   - *models.Currency: валюта отправителя
+//   - error: ErrInvalidAmountPrecision, ErrInvalidAmount, ErrFXRateNotFound или ошибка репозитория
+func (s *walletService) price(ctx context.Context, transaction *models.Transaction, sourceCode, targetCode string,
+	amount, targetAmount decimal.Decimal) (*models.Currency, error) {
+	source, err := s.currencyRepo.Currency(ctx, sourceCode)
+	if err != nil {
+		return nil, fmt.Errorf("error getting sender currency: %w", err)
+	}
+
+	target := source
+	rate := &models.FXRate{Base: sourceCode, Quote: targetCode, Rate: decimal.NewFromInt(1), Spread: decimal.Zero}
+
+	if sourceCode != targetCode {
+		if target, err = s.currencyRepo.Currency(ctx, targetCode); err != nil {
+			return nil, fmt.Errorf("error getting receiver currency: %w", err)
+		}
+
+		if rate, err = s.fxRateProvider.Rate(ctx, sourceCode, targetCode); err != nil {
+			return nil, err
+		}
+	}
+
+	applied := rate.Applied().Round(ratePrecision)
+
+	if !amount.IsZero() {
+		if !source.ValidAmount(amount) {
+			return nil, precisionError(source)
+		}
+		targetAmount = target.RoundDown(amount.Mul(applied))
+	} else {
+		if !target.ValidAmount(targetAmount) {
+			return nil, precisionError(target)
+		}
+		amount = source.RoundUp(targetAmount.DivRound(applied, ratePrecision))
+	}
+
+	if !amount.IsPositive() || !targetAmount.IsPositive() {
+		return nil, fmt.Errorf("%w: converted amount rounds to zero", er.ErrInvalidAmount)
+	}
+
+	transaction.Amount = amount
+	transaction.Currency = source.Code
+	transaction.TargetAmount = targetAmount
+	transaction.TargetCurrency = target.Code
+	transaction.Rate = applied
+	transaction.Spread = rate.Spread
+
+	return source, nil
+}
+
+// applyFee рассчитывает комиссию по тарифу валюты отправителя и заполняет поля транзакции.
+// Комиссия округляется вверх до минимальной единицы валюты. Переводы без тарифа
+// и переводы с кошелька-сборщика комиссий не облагаются.
+// Внутренний метод, используется при подготовке перевода.
+func (s *walletService) applyFee(transaction *models.Transaction, currency *models.Currency) {
+	schedule := s.feeSchedules.Schedule(currency.Code)
+	if schedule == nil || schedule.Collector == transaction.From {
+		return
+	}
+
+	fee := currency.RoundUp(schedule.Fee(transaction.Amount))
+	if !fee.IsPositive() {
+		return
+	}
+
+	collector := schedule.Collector
+	transaction.Fee = fee
+	transaction.FeeWallet = &collector
+}
+
+// precisionError оборачивает ErrInvalidAmountPrecision описанием точности валюты.
+func precisionError(currency *models.Currency) error {
+	return fmt.Errorf("%w: %s allows %d decimals in multiples of %s",
+		er.ErrInvalidAmountPrecision, currency.Code, currency.Decimals, currency.MinUnit)
+}
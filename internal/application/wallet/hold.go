@@ -0,0 +1,281 @@
+package wallet
+
+import (
+	"context"
+	"github.com/google/uuid"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"time"
+)
+
+// expireBatchSize - количество истекших холдов, обрабатываемых одной транзакцией БД.
+const expireBatchSize = 100
+
+// holdService реализует интерфейс HoldService.
+// Списание холда рассчитывается так же, как обычный перевод (курс, комиссия),
+// поэтому сервис использует расчет перевода сервиса кошельков.
+type holdService struct {
+	transfers  *walletService
+	holdRepo   repository.HoldRepository
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+}
+
+// NewHoldService создает новый экземпляр сервиса холдов.
+//
+// Параметры:
+//   - holdRepo: репозиторий холдов
+//   - walletRepo: репозиторий кошельков
+//   - currencyRepo: репозиторий справочника валют
+//   - fxRateProvider: источник курсов обмена
+//   - feeSchedules: источник тарифов комиссии
+//   - risk: проверка переводов правилами
+//   - pendingRepo: репозиторий отложенных переводов (политика согласования кошелька отправителя)
+//   - defaultTTL: срок холда, если он не указан в запросе
+//   - maxTTL: максимальный срок холда
+//
+// Возвращает:
+//   - service.HoldService: реализацию интерфейса сервиса холдов
+func NewHoldService(holdRepo repository.HoldRepository, walletRepo repository.WalletRepository,
+	currencyRepo repository.CurrencyRepository, fxRateProvider repository.FXRateProvider,
+	feeSchedules repository.FeeScheduleProvider, risk service.RiskEngine,
+	pendingRepo repository.PendingTransferRepository, defaultTTL, maxTTL time.Duration) service.HoldService {
+	return &holdService{
+		transfers: &walletService{
+			walletRepo:     walletRepo,
+			currencyRepo:   currencyRepo,
+			fxRateProvider: fxRateProvider,
+			feeSchedules:   feeSchedules,
+			risk:           risk,
+			pendingRepo:    pendingRepo,
+		},
+		holdRepo:   holdRepo,
+		defaultTTL: defaultTTL,
+		maxTTL:     maxTTL,
+	}
+}
+
+// CreateHold резервирует средства на кошельке отправителя для последующего перевода получателю.
+// Резервируется сумма холда вместе с комиссией, рассчитанной по текущему тарифу,
+// поэтому доступный баланс уменьшается, а баланс по журналу не меняется.
+// Создать холд может только владелец кошелька отправителя.
+// Перевод холда проверяется правилами и порогом согласования при создании; холд
+// не откладывается, поэтому решение review отклоняет его так же, как решение deny.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - req: отправитель, получатель, сумма и срок холда в секундах
+//
+// Возвращает:
+//   - *dto.HoldResponse: созданный холд
+//   - error: ошибка, если холд не удалось создать
+//
+// Возможные ошибки:
+//   - ErrInvalidHoldTTL: при отрицательном или превышающем максимум сроке
+//   - ErrUnauthenticated, ErrWalletForbidden и ошибки валидации перевода (см. WalletService.TransferMoney)
+//   - ErrSignatureRequired: кошелек отправителя имеет открытый ключ (переводы с него только подписанные)
+//   - ErrTransferDenied, ErrTransferReviewRequired: перевод холда отклонен правилом проверки
+//   - ErrNotEnoughMoney: если недостаточно доступных средств
+func (s *holdService) CreateHold(ctx context.Context, req dto.HoldRequest) (*dto.HoldResponse, error) {
+	ttl := s.defaultTTL
+	if req.TTLSeconds != 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+
+	if ttl <= 0 || ttl > s.maxTTL {
+		return nil, er.ErrInvalidHoldTTL
+	}
+
+	transferReq := dto.TransactionRequest{From: req.From, To: req.To, Amount: req.Amount}
+	if err := validateTransferRequest(transferReq); err != nil {
+		return nil, err
+	}
+
+	sender, err := s.transfers.authorizeSender(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	// Списание холда не подписывается, поэтому с кошелька с ключом холд недоступен
+	if sender.PublicKey != nil {
+		return nil, er.ErrSignatureRequired
+	}
+
+	// Проверяем получателя, курс и точность суммы так же, как при переводе
+	transaction, err := s.transfers.prepareTransfer(ctx, transferReq)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.transfers.requireAllowed(ctx, transaction); err != nil {
+		return nil, err
+	}
+
+	hold := &models.Hold{
+		PublicID:  uuid.NewString(),
+		From:      transaction.From,
+		To:        transaction.To,
+		Amount:    transaction.Amount,
+		Reserved:  transaction.Debited(),
+		Currency:  transaction.Currency,
+		Status:    models.HoldStatusActive,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+
+	if err = s.holdRepo.CreateHold(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	return dto.NewHoldResponse(hold), nil
+}
+
+// Hold возвращает холд по идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: публичный идентификатор холда (UUID)
+//
+// Возвращает:
+//   - *dto.HoldResponse: данные холда
+//   - error: ErrInvalidHoldID, ErrHoldNotFound или ошибка репозитория
+func (s *holdService) Hold(ctx context.Context, id string) (*dto.HoldResponse, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidHoldID
+	}
+
+	hold, err := s.holdRepo.Hold(ctx, publicID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewHoldResponse(hold), nil
+}
+
+// CaptureHold списывает холд: переводит получателю указанную сумму (по умолчанию всю сумму холда)
+// и снимает резерв. Остаток при частичном списании возвращается в доступный баланс.
+// Курс и комиссия рассчитываются на момент списания.
+// Списать холд может владелец кошелька отправителя или получателя.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор холда (UUID)
+//   - req: сумма списания (необязательно)
+//
+// Возвращает:
+//   - *dto.HoldResponse: списанный холд с квитанцией о переводе
+//   - error: ошибка, если холд не удалось списать
+//
+// Возможные ошибки:
+//   - ErrInvalidHoldID: если идентификатор не является UUID
+//   - ErrHoldNotFound: если холд не найден
+//   - ErrUnauthenticated, ErrWalletForbidden: запрос без API-ключа или от чужой учетной записи
+//   - ErrHoldNotActive: если холд уже списан, отменен или истек
+//   - ErrInvalidAmount, ErrCaptureExceedsHold: при невалидной сумме списания
+//   - ошибки перевода (см. WalletService.TransferMoney)
+func (s *holdService) CaptureHold(ctx context.Context, id string,
+	req dto.CaptureHoldRequest) (*dto.HoldResponse, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidHoldID
+	}
+
+	hold, err := s.holdRepo.Hold(ctx, publicID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.transfers.authorizeAny(ctx, hold.From, hold.To); err != nil {
+		return nil, err
+	}
+
+	amount := req.Amount
+	if amount.IsZero() {
+		amount = hold.Amount
+	}
+
+	if amount.IsNegative() {
+		return nil, er.ErrInvalidAmount
+	}
+
+	if amount.GreaterThan(hold.Amount) {
+		return nil, er.ErrCaptureExceedsHold
+	}
+
+	transaction, err := s.transfers.prepareTransfer(ctx, dto.TransactionRequest{
+		From:   hold.From,
+		To:     hold.To,
+		Amount: amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	captured, err := s.holdRepo.CaptureHold(ctx, hold.PublicID, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewHoldResponse(captured)
+	receipt := dto.NewTransactionResponse(transaction)
+	resp.Transaction = &receipt
+
+	return resp, nil
+}
+
+// VoidHold отменяет холд и возвращает зарезервированные средства в доступный баланс.
+// Отменить холд может владелец кошелька отправителя или получателя.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор холда (UUID)
+//
+// Возвращает:
+//   - *dto.HoldResponse: отмененный холд
+//   - error: ErrInvalidHoldID, ErrHoldNotFound, ErrUnauthenticated, ErrWalletForbidden,
+//     ErrHoldNotActive или ошибка репозитория
+func (s *holdService) VoidHold(ctx context.Context, id string) (*dto.HoldResponse, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidHoldID
+	}
+
+	hold, err := s.holdRepo.Hold(ctx, publicID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.transfers.authorizeAny(ctx, hold.From, hold.To); err != nil {
+		return nil, err
+	}
+
+	hold, err = s.holdRepo.VoidHold(ctx, hold.PublicID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewHoldResponse(hold), nil
+}
+
+// ExpireHolds снимает резерв со всех холдов с истекшим сроком.
+// Вызывается периодически фоновой задачей приложения.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - int: количество истекших холдов
+//   - error: ошибка репозитория
+func (s *holdService) ExpireHolds(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		n, err := s.holdRepo.ExpireHolds(ctx, time.Now(), expireBatchSize)
+		total += n
+		if err != nil || n < expireBatchSize {
+			return total, err
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/shopspring/decimal"
+	"strings"
+)
+
+// WalletLimits возвращает лимиты расходов кошелька и остаток по каждому лимиту в текущем периоде.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - *dto.WalletLimitsResponse: уровень, лимиты, расход и остатки
+//   - error: ErrWalletNotFound или ошибка репозитория
+func (s *walletService) WalletLimits(ctx context.Context, address string) (*dto.WalletLimitsResponse, error) {
+	status, err := s.walletRepo.WalletLimitStatus(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewWalletLimitsResponse(status), nil
+}
+
+// SetWalletLimits назначает кошельку уровень лимитов и индивидуальные лимиты.
+// Разрешение на операцию (limits:manage) проверяется на уровне маршрута.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//   - req: уровень и лимиты, заменяющие лимиты уровня
+//
+// Возвращает:
+//   - *dto.WalletLimitsResponse: лимиты кошелька после изменения
+//   - error: ошибка, если лимиты не изменены
+//
+// Возможные ошибки:
+//   - ErrInvalidLimit: неизвестный уровень или отрицательный лимит
+//   - ErrWalletNotFound: кошелек не найден или закрыт
+func (s *walletService) SetWalletLimits(ctx context.Context, address string,
+	req dto.WalletLimitsRequest) (*dto.WalletLimitsResponse, error) {
+	tier := strings.ToLower(strings.TrimSpace(req.Tier))
+	if tier != "" && !s.limits.HasTier(tier) {
+		return nil, fmt.Errorf("%w: unknown tier %q", er.ErrInvalidLimit, req.Tier)
+	}
+
+	for name, limit := range map[string]*decimal.Decimal{
+		"max_single": req.MaxSingle,
+		"daily":      req.Daily,
+		"weekly":     req.Weekly,
+		"monthly":    req.Monthly,
+	} {
+		if limit != nil && limit.IsNegative() {
+			return nil, fmt.Errorf("%w: %s must not be negative", er.ErrInvalidLimit, name)
+		}
+	}
+
+	status, err := s.walletRepo.SetWalletLimit(ctx, address, &models.WalletLimit{
+		Tier:      tier,
+		MaxSingle: req.MaxSingle,
+		Daily:     req.Daily,
+		Weekly:    req.Weekly,
+		Monthly:   req.Monthly,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewWalletLimitsResponse(status), nil
+}
@@ -0,0 +1,389 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"strings"
+)
+
+const (
+	// defaultPendingCount - число отложенных переводов, если count не указан.
+	defaultPendingCount = 50
+	// maxPendingCount - максимальное число отложенных переводов за один запрос.
+	maxPendingCount = 500
+)
+
+// pendingTransferService реализует интерфейс PendingTransferService.
+// Одобренный перевод рассчитывается так же, как обычный перевод (курс, комиссия),
+// поэтому сервис использует расчет перевода сервиса кошельков.
+type pendingTransferService struct {
+	transfers *walletService
+	repo      repository.PendingTransferRepository
+}
+
+// NewPendingTransferService создает новый экземпляр сервиса отложенных переводов.
+//
+// Параметры:
+//   - repo: репозиторий отложенных переводов и политик согласования
+//   - walletRepo: репозиторий кошельков (проверка владельца)
+//   - accountRepo: репозиторий учетных записей (проверка согласующих)
+//   - currencyRepo: репозиторий справочника валют
+//   - fxRateProvider: источник курсов обмена
+//   - feeSchedules: источник тарифов комиссии
+//
+// Возвращает:
+//   - service.PendingTransferService: реализацию интерфейса сервиса
+func NewPendingTransferService(repo repository.PendingTransferRepository, walletRepo repository.WalletRepository,
+	accountRepo repository.AccountRepository, currencyRepo repository.CurrencyRepository,
+	fxRateProvider repository.FXRateProvider, feeSchedules repository.FeeScheduleProvider) service.PendingTransferService {
+	return &pendingTransferService{
+		transfers: &walletService{
+			walletRepo:     walletRepo,
+			accountRepo:    accountRepo,
+			currencyRepo:   currencyRepo,
+			fxRateProvider: fxRateProvider,
+			feeSchedules:   feeSchedules,
+			pendingRepo:    repo,
+		},
+		repo: repo,
+	}
+}
+
+// PendingTransfers возвращает отложенные переводы в указанном статусе от старых к новым.
+// Доступ к очереди определяется разрешением маршрута (transfers:approve).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - status: статус переводов (pending, executed или rejected; пустая строка - pending)
+//   - count: число переводов (0 - значение по умолчанию)
+//
+// Возвращает:
+//   - []dto.PendingTransferResponse: отложенные переводы
+//   - error: ErrInvalidPendingTransferStatus, ErrInvalidCount или ошибка репозитория
+func (s *pendingTransferService) PendingTransfers(ctx context.Context, status string,
+	count int) ([]dto.PendingTransferResponse, error) {
+	filter, err := parsePendingTransferStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		count = defaultPendingCount
+	}
+	if count < 0 || count > maxPendingCount {
+		return nil, fmt.Errorf("%w: count must be 1-%d", er.ErrInvalidCount, maxPendingCount)
+	}
+
+	transfers, err := s.repo.PendingTransfers(ctx, filter, count)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.PendingTransferResponse, 0, len(transfers))
+	for i := range transfers {
+		resp = append(resp, dto.NewPendingTransferResponse(&transfers[i]))
+	}
+
+	return resp, nil
+}
+
+// PendingTransfer возвращает отложенный перевод по идентификатору.
+// Перевод доступен владельцу кошелька отправителя или получателя (в том числе закрытого)
+// и согласующим, назначенным политикой согласования кошелька; остальные согласующие
+// видят перевод в очереди (GET /pending-transfers).
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор отложенного перевода (UUID)
+//
+// Возвращает:
+//   - *dto.PendingTransferResponse: данные перевода
+//   - error: ErrInvalidPendingTransferID, ErrPendingTransferNotFound, ErrUnauthenticated,
+//     ErrWalletForbidden или ошибка репозитория
+func (s *pendingTransferService) PendingTransfer(ctx context.Context,
+	id string) (*dto.PendingTransferResponse, error) {
+	transfer, err := s.pendingTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Согласующие, назначенные политикой кошелька, видят перевод без владения кошельками
+	principal, _ := access.Principal(ctx)
+	if len(transfer.Approvers) == 0 || !transfer.CanDecide(principal.AccountPublicID) {
+		if err = s.transfers.authorizeAny(ctx, transfer.From, transfer.To); err != nil {
+			return nil, err
+		}
+	}
+
+	resp := dto.NewPendingTransferResponse(transfer)
+	return &resp, nil
+}
+
+// ApprovePendingTransfer одобряет отложенный перевод от имени учетной записи вызывающего.
+// Когда число одобрений достигает требуемого политикой согласования, перевод выполняется
+// атомарно вместе с последним одобрением тем же путем, что и POST /send: курс и комиссия
+// рассчитываются на момент выполнения, лимиты расходов и статусы кошельков проверяются,
+// резерв перевода снимается. Если перевод не удался, одобрение не сохраняется.
+// Номер подписанного перевода израсходован при откладывании, поэтому транзакция
+// выполненного перевода номера и подписи не содержит (они остаются в отложенном переводе).
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор отложенного перевода (UUID)
+//   - req: комментарий согласующего
+//
+// Возвращает:
+//   - *dto.PendingTransferResponse: перевод после одобрения (с квитанцией, если перевод выполнен)
+//   - error: ошибка, если одобрение не удалось
+//
+// Возможные ошибки:
+//   - ErrInvalidPendingTransferID, ErrPendingTransferNotFound: невалидный или неизвестный идентификатор
+//   - ErrUnauthenticated: запрос без API-ключа
+//   - ErrNotApprover: вызывающий без учетной записи или не входит в число согласующих
+//   - ErrSelfApproval: вызывающий - инициатор перевода
+//   - ErrPendingTransferNotPending: перевод уже выполнен или отклонен
+//   - ErrAlreadyDecided: учетная запись уже приняла решение по переводу
+//   - ошибки перевода (см. WalletService.TransferMoney)
+func (s *pendingTransferService) ApprovePendingTransfer(ctx context.Context, id string,
+	req dto.PendingTransferDecisionRequest) (*dto.PendingTransferResponse, error) {
+	transfer, err := s.pendingTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, err := s.decision(ctx, transfer, true, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.AccountID != nil && *transfer.AccountID == decision.AccountID {
+		return nil, er.ErrSelfApproval
+	}
+
+	// Перевод рассчитывается заново: курс и тариф могли измениться с момента запроса
+	transaction, err := s.transfers.prepareTransfer(ctx, dto.TransactionRequest{
+		From:         transfer.From,
+		To:           transfer.To,
+		Amount:       transfer.Amount,
+		TargetAmount: transfer.TargetAmount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err = s.repo.ApprovePendingTransfer(ctx, transfer.PublicID, decision, transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewPendingTransferResponse(transfer)
+	if transfer.Status == models.PendingTransferExecuted {
+		receipt := dto.NewTransactionResponse(transaction)
+		resp.Transaction = &receipt
+	}
+
+	return &resp, nil
+}
+
+// RejectPendingTransfer отклоняет отложенный перевод от имени учетной записи вызывающего
+// и возвращает зарезервированные средства в доступный баланс отправителя.
+// Перевод отклоняется первым отказом. Инициатор перевода, входящий в число согласующих,
+// может отклонить (отозвать) свой перевод.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор отложенного перевода (UUID)
+//   - req: комментарий согласующего (причина отказа)
+//
+// Возвращает:
+//   - *dto.PendingTransferResponse: отклоненный перевод
+//   - error: ErrInvalidPendingTransferID, ErrPendingTransferNotFound, ErrUnauthenticated,
+//     ErrNotApprover, ErrPendingTransferNotPending, ErrAlreadyDecided или ошибка репозитория
+func (s *pendingTransferService) RejectPendingTransfer(ctx context.Context, id string,
+	req dto.PendingTransferDecisionRequest) (*dto.PendingTransferResponse, error) {
+	transfer, err := s.pendingTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, err := s.decision(ctx, transfer, false, req)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err = s.repo.RejectPendingTransfer(ctx, transfer.PublicID, decision)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewPendingTransferResponse(transfer)
+	return &resp, nil
+}
+
+// ApprovalPolicy возвращает политику согласования переводов с кошелька.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - *dto.ApprovalPolicyResponse: порог, число одобрений и согласующие
+//   - error: ErrWalletNotFound или ошибка репозитория
+func (s *pendingTransferService) ApprovalPolicy(ctx context.Context,
+	address string) (*dto.ApprovalPolicyResponse, error) {
+	policy, err := s.repo.ApprovalPolicy(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewApprovalPolicyResponse(address, policy), nil
+}
+
+// SetApprovalPolicy назначает кошельку политику согласования переводов (N из M).
+// Разрешение на операцию (approvals:manage) проверяется на уровне маршрута.
+// Политика действует со следующего перевода; уже отложенные переводы согласуются
+// по политике на момент запроса.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//   - req: порог, число одобрений и согласующие учетные записи
+//
+// Возвращает:
+//   - *dto.ApprovalPolicyResponse: политика кошелька после изменения
+//   - error: ошибка, если политика не изменена
+//
+// Возможные ошибки:
+//   - ErrInvalidApprovalPolicy: отрицательный порог, невалидный идентификатор согласующего
+//     или число одобрений меньше 1 или больше числа согласующих
+//   - ErrAccountNotFound: согласующая учетная запись не найдена
+//   - ErrWalletNotFound: кошелек не найден или закрыт
+func (s *pendingTransferService) SetApprovalPolicy(ctx context.Context, address string,
+	req dto.ApprovalPolicyRequest) (*dto.ApprovalPolicyResponse, error) {
+	if req.Threshold != nil && req.Threshold.IsNegative() {
+		return nil, fmt.Errorf("%w: threshold must not be negative", er.ErrInvalidApprovalPolicy)
+	}
+
+	required := req.RequiredApprovals
+	if required == 0 {
+		required = 1
+	}
+	if required < 0 {
+		return nil, fmt.Errorf("%w: required_approvals must be positive", er.ErrInvalidApprovalPolicy)
+	}
+
+	approvers := make([]string, 0, len(req.Approvers))
+	seen := make(map[string]bool, len(req.Approvers))
+	for _, approver := range req.Approvers {
+		publicID, err := uuid.Parse(strings.TrimSpace(approver))
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid approver %q", er.ErrInvalidApprovalPolicy, approver)
+		}
+
+		if seen[publicID.String()] {
+			continue
+		}
+		seen[publicID.String()] = true
+
+		if _, err = s.transfers.accountRepo.Account(ctx, publicID.String()); err != nil {
+			return nil, err
+		}
+		approvers = append(approvers, publicID.String())
+	}
+
+	if len(approvers) > 0 && required > len(approvers) {
+		return nil, fmt.Errorf("%w: required_approvals must not exceed the number of approvers",
+			er.ErrInvalidApprovalPolicy)
+	}
+
+	policy := &models.ApprovalPolicy{
+		Threshold:         req.Threshold,
+		RequiredApprovals: required,
+		Approvers:         approvers,
+	}
+
+	if err := s.repo.SetApprovalPolicy(ctx, address, policy); err != nil {
+		return nil, err
+	}
+
+	return dto.NewApprovalPolicyResponse(address, policy), nil
+}
+
+// pendingTransfer проверяет идентификатор и возвращает отложенный перевод.
+// Внутренний метод, используется в PendingTransfer, ApprovePendingTransfer и RejectPendingTransfer.
+func (s *pendingTransferService) pendingTransfer(ctx context.Context, id string) (*models.PendingTransfer, error) {
+	if _, err := access.Principal(ctx); err != nil {
+		return nil, err
+	}
+
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidPendingTransferID
+	}
+
+	return s.repo.PendingTransfer(ctx, publicID.String())
+}
+
+// approver проверяет, что вызывающий - учетная запись, которая может принимать решение по переводу.
+// Разрешение transfers:approve проверяется на уровне маршрута.
+// Внутренний метод, используется в decision.
+func (s *pendingTransferService) approver(ctx context.Context, transfer *models.PendingTransfer) error {
+	principal, err := access.Principal(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Решение учитывается по учетной записи, поэтому администратор без ключа решение не принимает
+	if principal.AccountPublicID == "" || !transfer.CanDecide(principal.AccountPublicID) {
+		return er.ErrNotApprover
+	}
+
+	return nil
+}
+
+// decision проверяет, что вызывающий может принять решение по переводу, и формирует решение.
+// Внутренний метод, используется в ApprovePendingTransfer и RejectPendingTransfer.
+func (s *pendingTransferService) decision(ctx context.Context, transfer *models.PendingTransfer, approved bool,
+	req dto.PendingTransferDecisionRequest) (*models.PendingTransferDecision, error) {
+	if transfer.Status != models.PendingTransferPending {
+		return nil, fmt.Errorf("%w: transfer is %s", er.ErrPendingTransferNotPending, transfer.Status)
+	}
+
+	if err := s.approver(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	principal, _ := access.Principal(ctx)
+	decision := &models.PendingTransferDecision{
+		AccountID: principal.AccountPublicID,
+		Approved:  approved,
+		Comment:   strings.TrimSpace(req.Comment),
+	}
+	if principal.KeyID != "" {
+		decision.KeyID = &principal.KeyID
+	}
+
+	return decision, nil
+}
+
+// parsePendingTransferStatus проверяет статус выборки отложенных переводов (пустая строка - pending).
+func parsePendingTransferStatus(status string) (models.PendingTransferStatus, error) {
+	if status == "" {
+		return models.PendingTransferPending, nil
+	}
+
+	for _, known := range models.PendingTransferStatuses {
+		if models.PendingTransferStatus(status) == known {
+			return known, nil
+		}
+	}
+
+	return "", er.ErrInvalidPendingTransferStatus
+}
@@ -0,0 +1,160 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"net/http"
+)
+
+// checkRisk проверяет подготовленный перевод правилами и порогом согласования кошелька
+// отправителя. Решение deny возвращается ошибкой ErrTransferDenied с причиной из правила,
+// решение review (в том числе перевод сверх порога согласования) - результатом
+// (nil, если перевод можно выполнять). Без проверки правилами перевод разрешен.
+// Внутренний метод, используется в TransferMoney, TransferBatch и CreateHold.
+func (s *walletService) checkRisk(ctx context.Context, transaction *models.Transaction) (*models.RiskDecision, error) {
+	if s.risk != nil {
+		decision, err := s.risk.Evaluate(ctx, transaction)
+		if err != nil {
+			return nil, err
+		}
+
+		switch decision.Outcome {
+		case models.RiskOutcomeDeny:
+			return nil, fmt.Errorf("%w: %s", er.ErrTransferDenied, decision.Reason)
+		case models.RiskOutcomeReview:
+			return decision, nil
+		}
+	}
+
+	policy, err := s.approvalPolicy(ctx, transaction.From)
+	if err != nil || policy == nil || !policy.RequiresApproval(transaction.Amount) {
+		return nil, err
+	}
+
+	return &models.RiskDecision{
+		Outcome: models.RiskOutcomeReview,
+		Rule:    models.ApprovalThresholdRule,
+		Reason: fmt.Sprintf("amount exceeds the wallet's approval threshold of %s %s",
+			policy.Threshold.String(), transaction.Currency),
+	}, nil
+}
+
+// approvalPolicy возвращает политику согласования кошелька отправителя (nil - без согласования).
+// Внутренний метод, используется в checkRisk и park.
+func (s *walletService) approvalPolicy(ctx context.Context, address string) (*models.ApprovalPolicy, error) {
+	if s.pendingRepo == nil {
+		return nil, nil
+	}
+
+	policy, err := s.pendingRepo.ApprovalPolicy(ctx, address)
+	if errors.Is(err, er.ErrWalletNotFound) {
+		return nil, er.ErrWalletSenderNotFound
+	}
+	return policy, err
+}
+
+// requireAllowed проверяет перевод правилами там, где перевод нельзя отложить:
+// решение review возвращается ошибкой ErrTransferReviewRequired.
+// Внутренний метод, используется в TransferBatch и CreateHold.
+func (s *walletService) requireAllowed(ctx context.Context, transaction *models.Transaction) error {
+	review, err := s.checkRisk(ctx, transaction)
+	if err != nil {
+		return err
+	}
+	if review != nil {
+		return fmt.Errorf("%w: %s", er.ErrTransferReviewRequired, review.Reason)
+	}
+	return nil
+}
+
+// park откладывает перевод с решением review в очередь согласования. Сохраняются параметры
+// запроса (с подписью), поэтому перевод можно выполнить позже так же, как исходный запрос,
+// и политика согласования кошелька отправителя на момент запроса. Расчетная сумма списания
+// резервируется на кошельке отправителя, номер подписанного перевода расходуется.
+// Конкурентный запрос с тем же ключом идемпотентности получает перевод, отложенный первым.
+// Внутренний метод, используется в TransferMoney.
+func (s *walletService) park(ctx context.Context, req dto.TransactionRequest, transaction *models.Transaction,
+	decision *models.RiskDecision, idempotencyKey, requestHash string) (*dto.StoredResponse, error) {
+	policy, err := s.approvalPolicy(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		policy = models.DefaultApprovalPolicy()
+	}
+
+	pending := &models.PendingTransfer{
+		PublicID:          uuid.NewString(),
+		Status:            models.PendingTransferPending,
+		From:              req.From,
+		To:                req.To,
+		Amount:            req.Amount,
+		TargetAmount:      req.TargetAmount,
+		Currency:          transaction.Currency,
+		TargetCurrency:    transaction.TargetCurrency,
+		Debit:             transaction.Debited(),
+		Nonce:             transaction.Nonce,
+		Signature:         transaction.Signature,
+		Rule:              decision.Rule,
+		Reason:            decision.Reason,
+		RequiredApprovals: policy.RequiredApprovals,
+		Approvers:         policy.Approvers,
+	}
+
+	// Учетная запись перевода задает и область ключа идемпотентности (см. idempotencyAccount)
+	if principal, err := access.Principal(ctx); err == nil && principal.AccountPublicID != "" {
+		pending.AccountID = &principal.AccountPublicID
+		pending.KeyID = &principal.KeyID
+	}
+
+	if idempotencyKey != "" {
+		pending.IdempotencyKey = &idempotencyKey
+		pending.RequestHash = &requestHash
+	}
+
+	err = s.pendingRepo.CreatePendingTransfer(ctx, pending)
+	if errors.Is(err, er.ErrIdempotencyKeyExists) {
+		// Конкурентный запрос с тем же ключом зафиксировался первым
+		return s.replay(ctx, idempotencyKey, requestHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newStoredResponse(http.StatusAccepted, dto.NewPendingTransferResponse(pending))
+}
+
+// replayPending возвращает отложенный по ключу идемпотентности учетной записи accountID
+// перевод в текущем состоянии.
+// Внутренний метод, используется в replay.
+func (s *walletService) replayPending(ctx context.Context, accountID, idempotencyKey,
+	requestHash string) (*dto.StoredResponse, error) {
+	if s.pendingRepo == nil {
+		return nil, er.ErrIdempotencyKeyNotFound
+	}
+
+	pending, err := s.pendingRepo.PendingTransferByIdempotencyKey(ctx, accountID, idempotencyKey)
+	if errors.Is(err, er.ErrPendingTransferNotFound) {
+		return nil, er.ErrIdempotencyKeyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if pending.RequestHash == nil || *pending.RequestHash != requestHash {
+		return nil, er.ErrIdempotencyKeyReused
+	}
+
+	response, err := newStoredResponse(http.StatusAccepted, dto.NewPendingTransferResponse(pending))
+	if err != nil {
+		return nil, err
+	}
+	response.Replayed = true
+
+	return response, nil
+}
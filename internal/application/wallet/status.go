@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"strings"
+)
+
+// maxStatusReasonLength - максимальная длина причины изменения статуса кошелька.
+const maxStatusReasonLength = 500
+
+// SetWalletStatus замораживает или размораживает кошелек и сохраняет изменение в истории статусов.
+// Перевод, начатый после изменения, проверяет новый статус под блокировкой кошелька.
+// Разрешение на операцию (wallets:freeze) проверяется на уровне маршрута.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - address: адрес кошелька
+//   - req: новый статус (active, frozen_in, frozen_out, frozen) и причина изменения
+//
+// Возвращает:
+//   - *dto.WalletResponse: кошелек с новым статусом
+//   - error: ошибка, если статус не изменен
+//
+// Возможные ошибки:
+//   - ErrUnauthenticated: анонимный запрос
+//   - ErrInvalidWalletStatus: неизвестный статус, closed или пустая причина
+//   - ErrWalletNotFound: кошелек не найден или закрыт
+func (s *walletService) SetWalletStatus(ctx context.Context, address string,
+	req dto.WalletStatusRequest) (*dto.WalletResponse, error) {
+	principal, err := access.Principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.WalletStatus(req.Status)
+	switch status {
+	case models.WalletStatusActive, models.WalletStatusFrozenIn, models.WalletStatusFrozenOut,
+		models.WalletStatusFrozen:
+	default:
+		return nil, fmt.Errorf("%w: status must be active, frozen_in, frozen_out or frozen", er.ErrInvalidWalletStatus)
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" || len(reason) > maxStatusReasonLength {
+		return nil, fmt.Errorf("%w: reason must be 1-%d characters", er.ErrInvalidWalletStatus, maxStatusReasonLength)
+	}
+
+	change := &models.WalletStatusChange{ToStatus: status, Reason: reason}
+	if principal.AccountPublicID != "" {
+		change.AccountID = &principal.AccountPublicID
+		change.KeyID = &principal.KeyID
+	}
+
+	wallet, err := s.walletRepo.UpdateWalletStatus(ctx, address, change)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+// WalletStatusHistory возвращает историю статусов кошелька, в том числе закрытого.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - []dto.WalletStatusChangeResponse: изменения статуса от новых к старым
+//   - error: ErrWalletNotFound или ошибка репозитория
+func (s *walletService) WalletStatusHistory(ctx context.Context,
+	address string) ([]dto.WalletStatusChangeResponse, error) {
+	wallet, err := s.walletRepo.WalletIncludingClosed(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.walletRepo.WalletStatusChanges(ctx, wallet.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.WalletStatusChangeResponse, 0, len(changes))
+	for i := range changes {
+		resp = append(resp, dto.NewWalletStatusChangeResponse(&changes[i]))
+	}
+
+	return resp, nil
+}
@@ -6,99 +6,648 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
 	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
 	"github.com/normalniydada/case_infotecs/internal/domain/models"
 	"github.com/normalniydada/case_infotecs/internal/domain/repository"
 	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/normalniydada/case_infotecs/pkg/walletsign"
 	"github.com/shopspring/decimal"
+	"net/http"
+	"strconv"
+	"time"
 )
 
 // walletService реализует интерфейс WalletService.
-// Содержит репозиторий для работы с данными кошельков.
+// Содержит репозитории для работы с данными кошельков, валютами, курсами обмена,
+// тарифами комиссий, лимитами расходов, ключами идемпотентности и учетными записями владельцев,
+// а также проверку переводов правилами и очередь согласования отложенных переводов.
 type walletService struct {
-	walletRepo repository.WalletRepository
+	walletRepo      repository.WalletRepository
+	accountRepo     repository.AccountRepository
+	currencyRepo    repository.CurrencyRepository
+	fxRateProvider  repository.FXRateProvider
+	feeSchedules    repository.FeeScheduleProvider
+	limits          repository.LimitProvider
+	idempotencyRepo repository.IdempotencyRepository
+	risk            service.RiskEngine
+	pendingRepo     repository.PendingTransferRepository
 }
 
 // NewWalletService создает новый экземпляр сервиса для работы с кошельками.
 //
 // Параметры:
 //   - walletRepo: репозиторий для доступа к данным кошельков
+//   - accountRepo: репозиторий учетных записей (владельцев кошельков)
+//   - currencyRepo: репозиторий справочника валют
+//   - fxRateProvider: источник курсов для переводов между валютами
+//   - feeSchedules: источник тарифов комиссии за переводы
+//   - limits: источник уровней лимитов расходов
+//   - idempotencyRepo: репозиторий сохраненных результатов переводов
+//   - risk: проверка переводов правилами
+//   - pendingRepo: репозиторий отложенных переводов и политик согласования
 //
 // Возвращает:
 //   - service.WalletService: реализацию интерфейса сервиса кошельков
-func NewWalletService(walletRepo repository.WalletRepository) service.WalletService {
-	return &walletService{walletRepo: walletRepo}
+func NewWalletService(walletRepo repository.WalletRepository, accountRepo repository.AccountRepository,
+	currencyRepo repository.CurrencyRepository, fxRateProvider repository.FXRateProvider,
+	feeSchedules repository.FeeScheduleProvider, limits repository.LimitProvider,
+	idempotencyRepo repository.IdempotencyRepository, risk service.RiskEngine,
+	pendingRepo repository.PendingTransferRepository) service.WalletService {
+	return &walletService{
+		walletRepo:      walletRepo,
+		accountRepo:     accountRepo,
+		currencyRepo:    currencyRepo,
+		fxRateProvider:  fxRateProvider,
+		feeSchedules:    feeSchedules,
+		limits:          limits,
+		idempotencyRepo: idempotencyRepo,
+		risk:            risk,
+		pendingRepo:     pendingRepo,
+	}
 }
 
-// Balance возвращает текущий баланс указанного кошелька.
+// Balance возвращает текущий баланс указанного кошелька (общий и доступный) и его валюту.
 //
 // Параметры:
 //   - ctx: контекст выполнения
 //   - address: адрес кошелька
 //
 // Возвращает:
-//   - decimal.Decimal: текущий баланс кошелька
+//   - *dto.BalanceResponse: общий баланс, доступный баланс (за вычетом холдов) и валюта
 //   - error: ошибка, если кошелек не найден или произошла другая ошибка
 //
 // Возможные ошибки:
 //   - ErrWalletNotFound: если кошелек не найден
 //   - Другие ошибки репозитория: при проблемах доступа к данным
-func (s *walletService) Balance(ctx context.Context, address string) (decimal.Decimal, error) {
+func (s *walletService) Balance(ctx context.Context, address string) (*dto.BalanceResponse, error) {
 	wallet, err := s.walletRepo.Wallet(ctx, address)
 	if err != nil {
-		return decimal.NewFromInt(0), fmt.Errorf("error while getting balance: %w", err)
+		return nil, fmt.Errorf("error while getting balance: %w", err)
 	}
 
-	return wallet.Balance, nil
+	return &dto.BalanceResponse{
+		Balance:   wallet.Balance,
+		Available: wallet.Available(),
+		Total:     wallet.Balance,
+		Currency:  wallet.Currency,
+	}, nil
 }
 
 // TransferMoney выполняет перевод средств между кошельками.
 // Проверяет валидность параметров перед выполнением перевода.
+// Если передан ключ идемпотентности, результат первого успешного перевода
+// сохраняется вместе с транзакцией, а повторные запросы с тем же ключом
+// и теми же параметрами получают сохраненный ответ без повторного списания.
+//
+// Если кошельки в разных валютах, средства конвертируются по курсу FXRateProvider
+// за вычетом спреда. В запросе задается ровно одна из сумм: amount (списание)
+// или target_amount (зачисление); вторая рассчитывается по курсу.
+// Комиссия по тарифу валюты отправителя списывается сверх суммы перевода.
+//
+// Публичный идентификатор и время создания транзакции назначаются до записи в БД,
+// чтобы тело ответа (квитанция о переводе) могло быть сохранено в той же транзакции БД.
+//
+// Переводить средства может только владелец кошелька отправителя (субъект операции
+// из контекста). Владение проверяется и до повтора по ключу идемпотентности, поэтому
+// сохраненный ответ не выдается чужой учетной записи.
+//
+// Перевод с кошелька, имеющего открытый ключ, должен быть подписан его закрытым ключом.
+// Подпись проверяется до блокировки кошельков, номер перевода (nonce) - под блокировкой
+// кошелька отправителя, поэтому перехваченный подписанный запрос нельзя выполнить повторно.
+//
+// Перед выполнением перевод проверяется правилами (RiskEngine). Перевод с решением deny
+// отклоняется, с решением review или с суммой больше порога согласования кошелька
+// отправителя - не выполняется, а откладывается до согласования (ответ 202 с данными
+// отложенного перевода): сумма списания резервируется, перевод выполняется после одобрения
+// согласующими (см. PendingTransferService). Повтор запроса по ключу идемпотентности
+// возвращает отложенный перевод в текущем состоянии.
 //
 // Параметры:
-//   - ctx: контекст выполнения
-//   - from: адрес кошелька отправителя
-//   - to: адрес кошелька получателя
-//   - amount: сумма перевода (должна быть положительной)
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - req: параметры перевода (отправитель, получатель, сумма списания или зачисления)
+//   - idempotencyKey: значение заголовка Idempotency-Key (пустая строка - без идемпотентности)
 //
 // Возвращает:
+//   - *dto.StoredResponse: ответ, который нужно отдать клиенту (данные созданной транзакции
+//     или, со статусом 202, отложенного перевода)
 //   - error: ошибка, если перевод не удался
 //
 // Возможные ошибки:
+//   - ErrUnauthenticated: запрос без API-ключа
+//   - ErrWalletForbidden: кошелек отправителя не принадлежит вызывающему
+//   - ErrSignatureRequired, ErrInvalidSignature: перевод с кошелька с ключом не подписан или подпись неверна
+//   - ErrNonceReused: номер перевода не больше номера последнего перевода с кошелька
 //   - ErrSameWalletTransfer: при попытке перевода на тот же кошелек
+//   - ErrAmountAmbiguous: если заданы обе суммы или ни одной
 //   - ErrInvalidAmount: при невалидной сумме перевода (<= 0)
-//   - ErrInsufficientFunds: если недостаточно средств на кошельке отправителя
+//   - ErrInvalidAmountPrecision: если сумма не представима в валюте кошелька
+//   - ErrFXRateNotFound: если курс для пары валют неизвестен
+//   - ErrInsufficientFunds: если недостаточно средств на кошельке отправителя (с учетом комиссии)
 //   - ErrWalletNotFound: если один из кошельков не найден
-func (s *walletService) TransferMoney(ctx context.Context, from, to string, amount decimal.Decimal) error {
-	if from == to {
+//   - ErrWalletSenderFrozen, ErrWalletReceiverFrozen: перевод заблокирован статусом кошелька
+//   - *er.LimitExceededError: перевод превышает лимит расходов отправителя
+//   - ErrTransferDenied: перевод отклонен правилом проверки
+//   - ErrIdempotencyKeyReused: если ключ уже использован с другими параметрами
+func (s *walletService) TransferMoney(ctx context.Context, req dto.TransactionRequest,
+	idempotencyKey string) (*dto.StoredResponse, error) {
+	if err := validateTransferRequest(req); err != nil {
+		return nil, err
+	}
+
+	sender, err := s.authorizeSender(ctx, req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = verifySignature(sender, req); err != nil {
+		return nil, err
+	}
+
+	var requestHash string
+	if idempotencyKey != "" {
+		requestHash = hashTransferRequest(req)
+
+		// Повтор запроса: отдаем сохраненный результат
+		stored, err := s.replay(ctx, idempotencyKey, requestHash)
+		if !errors.Is(err, er.ErrIdempotencyKeyNotFound) {
+			return stored, err
+		}
+	}
+
+	transaction, err := s.prepareTransfer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.checkRisk(ctx, transaction)
+	if err != nil {
+		return nil, err
+	}
+	if review != nil {
+		return s.park(ctx, req, transaction, review, idempotencyKey, requestHash)
+	}
+
+	response, err := newStoredResponse(http.StatusOK, dto.NewTransactionResponse(transaction))
+	if err != nil {
+		return nil, err
+	}
+
+	var record *models.IdempotencyKey
+	if idempotencyKey != "" {
+		record = &models.IdempotencyKey{
+			AccountID:    idempotencyAccount(ctx),
+			Key:          idempotencyKey,
+			RequestHash:  requestHash,
+			StatusCode:   response.StatusCode,
+			ResponseBody: response.Body,
+		}
+	}
+
+	err = s.walletRepo.Transfer(ctx, transaction, record)
+	if errors.Is(err, er.ErrIdempotencyKeyExists) {
+		// Конкурентный запрос с тем же ключом зафиксировался первым
+		return s.replay(ctx, idempotencyKey, requestHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// QuoteTransfer рассчитывает перевод без его выполнения: суммы списания и зачисления,
+// курс обмена и комиссию. Баланс отправителя не проверяется и не блокируется,
+// поэтому фактический перевод может отличаться, если курс или тариф изменятся.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - req: параметры перевода (как в TransferMoney)
+//
+// Возвращает:
+//   - *dto.TransferQuote: расчет перевода
+//   - error: те же ошибки валидации, что и TransferMoney
+func (s *walletService) QuoteTransfer(ctx context.Context, req dto.TransactionRequest) (*dto.TransferQuote, error) {
+	if err := validateTransferRequest(req); err != nil {
+		return nil, err
+	}
+
+	transaction, err := s.prepareTransfer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto.NewTransferQuote(transaction), nil
+}
+
+// validateTransferRequest проверяет параметры перевода, не требующие обращения к БД.
+func validateTransferRequest(req dto.TransactionRequest) error {
+	if req.From == req.To {
 		return er.ErrSameWalletTransfer
 	}
 
-	if amount.LessThanOrEqual(decimal.NewFromInt(0)) {
+	if req.Amount.IsZero() == req.TargetAmount.IsZero() {
+		return er.ErrAmountAmbiguous
+	}
+
+	if req.Amount.IsNegative() || req.TargetAmount.IsNegative() {
 		return er.ErrInvalidAmount
 	}
 
-	return s.walletRepo.Transfer(ctx, from, to, amount)
+	return nil
+}
+
+// prepareTransfer определяет валюты кошельков, рассчитывает суммы перевода и комиссию
+// и формирует транзакцию. Валюта кошелька не меняется после создания, поэтому
+// ее можно прочитать без блокировки; репозиторий повторно сверяет валюты под блокировкой.
+// Внутренний метод, используется в TransferMoney.
+func (s *walletService) prepareTransfer(ctx context.Context, req dto.TransactionRequest) (*models.Transaction, error) {
+	sender, err := s.walletRepo.Wallet(ctx, req.From)
+	if errors.Is(err, er.ErrWalletNotFound) {
+		return nil, er.ErrWalletSenderNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	receiver, err := s.walletRepo.Wallet(ctx, req.To)
+	if errors.Is(err, er.ErrWalletNotFound) {
+		return nil, er.ErrWalletReceiverNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	transaction := &models.Transaction{
+		PublicID: uuid.NewString(),
+		From:     req.From,
+		To:       req.To,
+	}
+	if req.Signature != "" {
+		transaction.Nonce = &req.Nonce
+		transaction.Signature = &req.Signature
+	}
+	// PostgreSQL хранит время с точностью до микросекунд
+	transaction.CreatedAt = time.Now().Truncate(time.Microsecond)
+
+	source, err := s.price(ctx, transaction, sender.Currency, receiver.Currency, req.Amount, req.TargetAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyFee(transaction, source)
+
+	return transaction, nil
+}
+
+// authorizeSender проверяет, что вызывающий владеет кошельком отправителя, и возвращает этот кошелек.
+// Внутренний метод, используется в TransferMoney, TransferBatch и CreateHold.
+func (s *walletService) authorizeSender(ctx context.Context, address string) (*models.Wallet, error) {
+	if _, err := access.Principal(ctx); err != nil {
+		return nil, err
+	}
+
+	sender, err := s.walletRepo.Wallet(ctx, address)
+	if errors.Is(err, er.ErrWalletNotFound) {
+		return nil, er.ErrWalletSenderNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = access.Authorize(ctx, sender); err != nil {
+		return nil, err
+	}
+	return sender, nil
 }
 
-// CreateWallet создает новый кошелек с указанным начальным балансом.
-// Генерирует уникальный адрес кошелька автоматически.
+// verifySignature проверяет подпись перевода с кошелька отправителя. Перевод с кошелька,
+// имеющего открытый ключ, должен быть подписан; кошелек без ключа подпись не принимает.
+// Номер перевода сверяется с номером кошелька в репозитории под блокировкой кошелька.
+// Внутренний метод, используется в TransferMoney и TransferBatch.
+func verifySignature(sender *models.Wallet, req dto.TransactionRequest) error {
+	if sender.PublicKey == nil {
+		if req.Signature != "" {
+			return fmt.Errorf("%w: sender's wallet has no public key", er.ErrInvalidSignature)
+		}
+		return nil
+	}
+
+	if req.Signature == "" {
+		return er.ErrSignatureRequired
+	}
+
+	if req.Nonce <= 0 {
+		return er.ErrNonceReused
+	}
+
+	publicKey, err := walletsign.ParsePublicKey(*sender.PublicKey)
+	if err != nil {
+		return fmt.Errorf("error parsing public key of wallet %s: %w", sender.Address, err)
+	}
+
+	if !walletsign.Verify(publicKey, walletsign.Transfer{
+		From:         req.From,
+		To:           req.To,
+		Amount:       req.Amount,
+		TargetAmount: req.TargetAmount,
+		Nonce:        req.Nonce,
+	}, req.Signature) {
+		return er.ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// authorizeAny проверяет, что вызывающий владеет хотя бы одним из кошельков (в том числе закрытых).
+// Внутренний метод, используется для операций с холдами, которыми могут управлять обе стороны.
+func (s *walletService) authorizeAny(ctx context.Context, addresses ...string) error {
+	if _, err := access.Principal(ctx); err != nil {
+		return err
+	}
+
+	wallets := make([]*models.Wallet, 0, len(addresses))
+	for _, address := range addresses {
+		wallet, err := s.walletRepo.WalletIncludingClosed(ctx, address)
+		if err != nil {
+			return err
+		}
+		wallets = append(wallets, wallet)
+	}
+
+	return access.Authorize(ctx, wallets...)
+}
+
+// replay возвращает сохраненный результат запроса по ключу идемпотентности
+// или отложенный по этому ключу перевод. Ключ ищется среди ключей учетной записи вызывающего.
+// Внутренний метод, используется в TransferMoney и TransferBatch.
+func (s *walletService) replay(ctx context.Context, idempotencyKey, requestHash string) (*dto.StoredResponse, error) {
+	var accountID string
+	if account := idempotencyAccount(ctx); account != nil {
+		accountID = *account
+	}
+
+	record, err := s.idempotencyRepo.IdempotencyKey(ctx, accountID, idempotencyKey)
+	if errors.Is(err, er.ErrIdempotencyKeyNotFound) {
+		return s.replayPending(ctx, accountID, idempotencyKey, requestHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, er.ErrIdempotencyKeyReused
+	}
+
+	return &dto.StoredResponse{
+		StatusCode: record.StatusCode,
+		Body:       record.ResponseBody,
+		Replayed:   true,
+	}, nil
+}
+
+// idempotencyAccount возвращает учетную запись, которой принадлежат ключи идемпотентности
+// вызывающего: nil для привилегированного клиента без учетной записи.
+func idempotencyAccount(ctx context.Context) *string {
+	principal, err := access.Principal(ctx)
+	if err != nil || principal.AccountPublicID == "" {
+		return nil
+	}
+	return &principal.AccountPublicID
+}
+
+// CreateWallet создает новый кошелек с указанным начальным балансом в указанной валюте.
+// Адрес кошелька с открытым ключом - SHA-256 ключа; переводы с такого кошелька подписываются.
+// Открытый ключ обязателен для учетной записи; кошельку без ключа, созданному
+// привилегированным клиентом, адрес генерируется автоматически.
+// Владельцем кошелька становится учетная запись вызывающего. Привилегированный клиент
+// может назначить владельцем любую учетную запись или создать кошелек без владельца.
 //
 // Параметры:
-//   - ctx: контекст выполнения
-//   - balance: начальный баланс кошелька
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - balance: начальный баланс кошелька (не может быть отрицательным)
+//   - currency: код валюты кошелька (пустая строка - models.DefaultCurrency)
+//   - owner: публичный идентификатор учетной записи владельца (пустая строка - вызывающий)
+//   - publicKey: открытый ключ Ed25519 в hex-формате (пустая строка - кошелек без ключа)
 //
 // Возвращает:
+//   - *dto.WalletResponse: данные созданного кошелька
 //   - error: ошибка, если создание не удалось
-func (s *walletService) CreateWallet(ctx context.Context, balance decimal.Decimal) error {
+//
+// Возможные ошибки:
+//   - ErrUnauthenticated: запрос без API-ключа
+//   - ErrAccountForbidden: владелец - чужая учетная запись без привилегированного доступа
+//   - ErrInvalidAPIKeyID, ErrAccountNotFound: невалидная или несуществующая учетная запись владельца
+//   - ErrInvalidPublicKey: невалидный ключ или ключ не передан учетной записью
+//   - ErrInvalidInitialBalance: при отрицательном начальном балансе
+//   - ErrCurrencyNotFound: при неизвестной валюте
+//   - ErrInvalidAmountPrecision: если баланс не представим в валюте
+//   - ErrWalletExists: кошелек с этим ключом уже существует или коллизия сгенерированного адреса
+func (s *walletService) CreateWallet(ctx context.Context, balance decimal.Decimal,
+	currencyCode, owner, publicKey string) (*dto.WalletResponse, error) {
+	if balance.IsNegative() {
+		return nil, er.ErrInvalidInitialBalance
+	}
+
+	ownerID, err := s.walletOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	address, key, err := walletKey(ctx, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if currencyCode == "" {
+		currencyCode = models.DefaultCurrency
+	}
+
+	currency, err := s.currencyRepo.Currency(ctx, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if !currency.ValidAmount(balance) {
+		return nil, er.ErrInvalidAmountPrecision
+	}
+
 	wallet := models.Wallet{
-		Address: generateWalletAddress(),
-		Balance: balance,
+		Address:   address,
+		Balance:   balance,
+		Currency:  currency.Code,
+		Status:    models.WalletStatusActive,
+		OwnerID:   ownerID,
+		PublicKey: key,
+	}
+
+	if err := s.walletRepo.CreateWallet(ctx, &wallet); err != nil {
+		return nil, err
+	}
+
+	return toWalletResponse(&wallet), nil
+}
+
+// walletOwner определяет владельца создаваемого кошелька.
+// Внутренний метод, используется в CreateWallet.
+//
+// Возвращает:
+//   - *uint: внутренний идентификатор учетной записи владельца (nil - без владельца)
+//   - error: ошибка доступа или поиска учетной записи
+func (s *walletService) walletOwner(ctx context.Context, owner string) (*uint, error) {
+	principal, err := access.Principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner == "" {
+		if principal.AccountID == 0 {
+			// Привилегированный клиент без учетной записи
+			return nil, nil
+		}
+		return &principal.AccountID, nil
+	}
+
+	publicID, err := uuid.Parse(owner)
+	if err != nil {
+		return nil, er.ErrInvalidAPIKeyID
+	}
+
+	if !principal.Privileged && publicID.String() != principal.AccountPublicID {
+		return nil, er.ErrAccountForbidden
+	}
+
+	account, err := s.accountRepo.Account(ctx, publicID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &account.ID, nil
+}
+
+// walletKey определяет адрес и открытый ключ создаваемого кошелька.
+// Внутренний метод, используется в CreateWallet.
+//
+// Возвращает:
+//   - string: адрес кошелька (SHA-256 ключа или сгенерированный для кошелька без ключа)
+//   - *string: открытый ключ в каноническом hex-формате (nil - кошелек без ключа)
+//   - error: ErrInvalidPublicKey при невалидном ключе или отсутствии ключа у непривилегированного вызывающего
+func walletKey(ctx context.Context, publicKey string) (string, *string, error) {
+	if publicKey == "" {
+		if principal, err := access.Principal(ctx); err != nil || !principal.Privileged {
+			return "", nil, fmt.Errorf("%w: public_key is required", er.ErrInvalidPublicKey)
+		}
+		return generateWalletAddress(), nil, nil
+	}
+
+	key, err := walletsign.ParsePublicKey(publicKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", er.ErrInvalidPublicKey, err)
+	}
+
+	encoded := walletsign.EncodePublicKey(key)
+	return walletsign.Address(key), &encoded, nil
+}
+
+// Wallet возвращает полную информацию о кошельке, в том числе о закрытом.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - *dto.WalletResponse: данные кошелька
+//   - error: ошибка, если кошелек не найден или произошла другая ошибка
+//
+// Возможные ошибки:
+//   - ErrWalletNotFound: если кошелек не найден
+func (s *walletService) Wallet(ctx context.Context, address string) (*dto.WalletResponse, error) {
+	wallet, err := s.walletRepo.WalletIncludingClosed(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting wallet: %w", err)
+	}
+
+	return toWalletResponse(wallet), nil
+}
+
+// CloseWallet закрывает кошелек. Закрыть можно только кошелек с нулевым балансом.
+// Закрыть кошелек может только его владелец.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - error: ошибка, если закрытие не удалось
+//
+// Возможные ошибки:
+//   - ErrUnauthenticated: запрос без API-ключа
+//   - ErrWalletForbidden: кошелек не принадлежит вызывающему
+//   - ErrWalletNotFound: если кошелек не найден или уже закрыт
+//   - ErrWalletNotEmpty: если баланс кошелька не равен нулю
+//   - ErrWalletFrozen: если кошелек заморожен
+func (s *walletService) CloseWallet(ctx context.Context, address string) error {
+	if _, err := access.Principal(ctx); err != nil {
+		return err
+	}
+
+	wallet, err := s.walletRepo.Wallet(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	if err = access.Authorize(ctx, wallet); err != nil {
+		return err
 	}
 
-	return s.walletRepo.CreateWallet(ctx, &wallet)
+	return s.walletRepo.CloseWallet(ctx, address)
+}
+
+// EnsureWallet создает служебный кошелек с заданным адресом и нулевым балансом, если его нет.
+// Используется при старте приложения для кошельков-сборщиков комиссий.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//   - currency: код валюты кошелька
+//
+// Возвращает:
+//   - error: ошибка, если кошелек существует в другой валюте, закрыт или не удалось его создать
+func (s *walletService) EnsureWallet(ctx context.Context, address, currency string) error {
+	wallet, err := s.walletRepo.WalletIncludingClosed(ctx, address)
+	if err == nil {
+		// Замороженный сборщик не мешает старту: статус сборщика при переводах не проверяется
+		if wallet.Status == models.WalletStatusClosed {
+			return fmt.Errorf("wallet %s is %s", address, wallet.Status)
+		}
+		if wallet.Currency != currency {
+			return fmt.Errorf("wallet %s: %w", address, er.ErrCurrencyMismatch)
+		}
+		return nil
+	}
+
+	if !errors.Is(err, er.ErrWalletNotFound) {
+		return err
+	}
+
+	if _, err = s.currencyRepo.Currency(ctx, currency); err != nil {
+		return err
+	}
+
+	err = s.walletRepo.CreateWallet(ctx, &models.Wallet{
+		Address:  address,
+		Balance:  decimal.Zero,
+		Currency: currency,
+		Status:   models.WalletStatusActive,
+	})
+	if errors.Is(err, er.ErrWalletExists) {
+		// Кошелек создан параллельно запущенным экземпляром приложения
+		return nil
+	}
+
+	return err
 }
 
 // CountWallets возвращает общее количество кошельков в системе.
@@ -113,7 +662,69 @@ func (s *walletService) CountWallets(ctx context.Context) (int64, error) {
 	return s.walletRepo.Count(ctx)
 }
 
-// generateWalletAddress генерирует уникальный адрес кошелька.
+// toWalletResponse преобразует модель кошелька в DTO ответа.
+func toWalletResponse(wallet *models.Wallet) *dto.WalletResponse {
+	resp := &dto.WalletResponse{
+		Address:   wallet.Address,
+		Balance:   wallet.Balance,
+		Currency:  wallet.Currency,
+		Status:    string(wallet.Status),
+		CreatedAt: wallet.CreatedAt,
+	}
+
+	if wallet.PublicKey != nil {
+		resp.PublicKey = wallet.PublicKey
+		resp.Nonce = &wallet.Nonce
+	}
+
+	if wallet.DeletedAt.Valid {
+		resp.ClosedAt = &wallet.DeletedAt.Time
+	}
+
+	return resp
+}
+
+// newStoredResponse сериализует тело ответа на запрос перевода.
+//
+// Параметры:
+//   - statusCode: HTTP-код ответа
+//   - body: тело ответа
+//
+// Возвращает:
+//   - *dto.StoredResponse: ответ в готовом к отправке виде
+//   - error: ошибка сериализации
+func newStoredResponse(statusCode int, body any) (*dto.StoredResponse, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding response: %w", err)
+	}
+
+	return &dto.StoredResponse{StatusCode: statusCode, Body: data}, nil
+}
+
+// hashTransferRequest вычисляет отпечаток параметров перевода.
+// Используется для сравнения повторного запроса с первым по ключу идемпотентности.
+// Сумма зачисления входит в отпечаток только если она задана, поэтому отпечатки
+// запросов с суммой списания совпадают с сохраненными до появления конвертации.
+// Номер подписанного перевода входит в отпечаток так же; сама подпись не входит -
+// она однозначно определяется остальными параметрами.
+//
+// Возвращает:
+//   - string: SHA-256 хеш параметров в hex-формате
+func hashTransferRequest(req dto.TransactionRequest) string {
+	payload := req.From + "\n" + req.To + "\n" + req.Amount.String()
+	if !req.TargetAmount.IsZero() {
+		payload += "\ntarget:" + req.TargetAmount.String()
+	}
+	if req.Signature != "" {
+		payload += "\nnonce:" + strconv.FormatInt(req.Nonce, 10)
+	}
+
+	hash := sha256.Sum256([]byte(payload))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateWalletAddress генерирует уникальный адрес кошелька без открытого ключа.
 // Использует UUID и SHA-256 хеш для создания адреса.
 //
 // Возвращает:
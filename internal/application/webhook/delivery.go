@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"log"
+	"time"
+)
+
+// Publish создает доставки события outbox подпискам кошельков, которых оно касается:
+// transfer.incoming - подпискам получателя, transfer.outgoing - подпискам отправителя.
+// Повторная публикация события не создает повторных доставок.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - event: событие outbox
+//
+// Возвращает:
+//   - error: ошибка разбора события или репозитория (событие будет опубликовано повторно)
+func (s *webhookService) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	if event.EventType != models.EventTransferCompleted {
+		return nil
+	}
+
+	var transfer models.TransferCompleted
+	if err := json.Unmarshal(event.Payload, &transfer); err != nil {
+		return fmt.Errorf("error decoding event %d: %w", event.ID, err)
+	}
+
+	subscriptions, err := s.repo.Subscriptions(ctx, transfer.From, transfer.To)
+	if err != nil {
+		return fmt.Errorf("error getting webhook subscriptions: %w", err)
+	}
+
+	now := time.Now()
+	var deliveries []models.WebhookDelivery
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+
+		var eventType string
+		switch {
+		case subscription.Wallet == transfer.To && subscription.Subscribed(models.WebhookEventTransferIncoming):
+			eventType = models.WebhookEventTransferIncoming
+		case subscription.Wallet == transfer.From && subscription.Subscribed(models.WebhookEventTransferOutgoing):
+			eventType = models.WebhookEventTransferOutgoing
+		default:
+			continue
+		}
+
+		deliveries = append(deliveries, models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      eventType,
+			Payload:        event.Payload,
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+		})
+	}
+
+	return s.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// DeliverDue отправляет доставки, срок попытки которых наступил.
+// Доставки захватываются пачками по policy.BatchSize, пока захватывать нечего.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - int: количество выполненных попыток
+//   - error: ошибка репозитория
+func (s *webhookService) DeliverDue(ctx context.Context) (int, error) {
+	var total int
+	for {
+		deliveries, err := s.repo.ClaimDue(ctx, time.Now(), s.policy.Lease, s.policy.BatchSize)
+		if err != nil {
+			return total, err
+		}
+
+		for i := range deliveries {
+			if err = s.deliver(ctx, &deliveries[i]); err != nil {
+				return total, err
+			}
+			total++
+		}
+
+		if len(deliveries) < s.policy.BatchSize {
+			return total, nil
+		}
+	}
+}
+
+// deliver выполняет одну попытку доставки и записывает ее результат.
+// Доставка успешна при ответе 2xx; иначе повторяется с экспоненциальной задержкой
+// до policy.MaxAttempts попыток. Доставки удаленных подписок отменяются без отправки.
+// Внутренний метод, используется в DeliverDue.
+func (s *webhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	subscription := delivery.Subscription
+	if subscription == nil || subscription.DeletedAt.Valid {
+		delivery.Status = models.WebhookDeliveryCancelled
+		return s.complete(ctx, delivery)
+	}
+
+	body, err := json.Marshal(dto.WebhookEvent{
+		ID:        delivery.PublicID,
+		EventID:   delivery.EventID,
+		Type:      delivery.EventType,
+		CreatedAt: delivery.CreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding webhook delivery: %w", err)
+	}
+
+	now := time.Now()
+	status, err := s.sender.Send(ctx, subscription.URL, body, map[string]string{
+		"X-Webhook-ID":    delivery.PublicID,
+		"X-Webhook-Event": delivery.EventType,
+		SignatureHeader:   Sign(subscription.Secret, now, body),
+	})
+	if ctx.Err() != nil {
+		// Приложение останавливается: доставку отправит другой экземпляр после окончания аренды
+		return ctx.Err()
+	}
+
+	delivery.Attempts++
+	delivery.LastStatusCode = nil
+	delivery.LastError = nil
+
+	if err == nil {
+		delivery.LastStatusCode = &status
+		if status >= 200 && status <= 299 {
+			delivery.Status = models.WebhookDeliverySucceeded
+			delivery.DeliveredAt = &now
+			return s.complete(ctx, delivery)
+		}
+		err = fmt.Errorf("receiver responded with status %d", status)
+	}
+
+	message := err.Error()
+	delivery.LastError = &message
+
+	if delivery.Attempts >= s.policy.MaxAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(s.backoff(delivery.Attempts))
+	}
+
+	return s.complete(ctx, delivery)
+}
+
+// complete записывает результат попытки доставки.
+// Потеря аренды не является ошибкой: доставку уже обрабатывает другой вызов.
+func (s *webhookService) complete(ctx context.Context, delivery *models.WebhookDelivery) error {
+	err := s.repo.CompleteDelivery(ctx, delivery)
+	if errors.Is(err, er.ErrWebhookDeliveryClaimLost) {
+		log.Printf("[WARN] Webhook delivery %s was claimed again before completion", delivery.PublicID)
+		return nil
+	}
+	return err
+}
+
+// backoff возвращает задержку перед попыткой после attempts неудачных попыток.
+func (s *webhookService) backoff(attempts int) time.Duration {
+	delay := s.policy.RetryBackoff
+	for i := 1; i < attempts && delay < s.policy.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.policy.MaxBackoff)
+}
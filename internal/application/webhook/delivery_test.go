@@ -0,0 +1,323 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/events"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const (
+	testSecret       = "whsec_test"
+	testSubscription = "6f1c3a52-8a8e-4b1e-9d52-1f0a3b7c9e10"
+	testDelivery     = "0b5d2c7e-3f4a-4c8b-a1d2-9e8f7a6b5c4d"
+)
+
+// fakeWebhookRepository хранит одну подписку и одну доставку в памяти.
+// Неиспользуемые методы не реализованы.
+type fakeWebhookRepository struct {
+	repository.WebhookRepository
+	subscription *models.WebhookSubscription
+	delivery     *models.WebhookDelivery
+	created      *models.WebhookSubscription
+}
+
+func (r *fakeWebhookRepository) CreateSubscription(_ context.Context, subscription *models.WebhookSubscription) error {
+	r.created = subscription
+	return nil
+}
+
+func (r *fakeWebhookRepository) Subscription(_ context.Context, publicID string) (*models.WebhookSubscription, error) {
+	if r.subscription == nil || r.subscription.PublicID != publicID {
+		return nil, er.ErrWebhookNotFound
+	}
+	return r.subscription, nil
+}
+
+func (r *fakeWebhookRepository) Delivery(_ context.Context, subscriptionID uint,
+	publicID string) (*models.WebhookDelivery, error) {
+	if r.delivery == nil || r.delivery.SubscriptionID != subscriptionID || r.delivery.PublicID != publicID {
+		return nil, er.ErrWebhookDeliveryNotFound
+	}
+	delivery := *r.delivery
+	return &delivery, nil
+}
+
+func (r *fakeWebhookRepository) Redeliver(_ context.Context, delivery *models.WebhookDelivery, now time.Time) error {
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	*r.delivery = *delivery
+	return nil
+}
+
+func (r *fakeWebhookRepository) ClaimDue(_ context.Context, now time.Time, _ time.Duration,
+	_ int) ([]models.WebhookDelivery, error) {
+	if r.delivery == nil || r.delivery.Status != models.WebhookDeliveryPending || r.delivery.NextAttemptAt.After(now) {
+		return nil, nil
+	}
+	delivery := *r.delivery
+	delivery.Subscription = r.subscription
+	return []models.WebhookDelivery{delivery}, nil
+}
+
+func (r *fakeWebhookRepository) CompleteDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	*r.delivery = *delivery
+	r.delivery.Subscription = nil
+	return nil
+}
+
+// fakeWalletRepository возвращает один кошелек владельца с идентификатором 1.
+type fakeWalletRepository struct {
+	repository.WalletRepository
+}
+
+func (r *fakeWalletRepository) Wallet(_ context.Context, address string) (*models.Wallet, error) {
+	owner := uint(1)
+	return &models.Wallet{Address: address, OwnerID: &owner, Status: models.WalletStatusActive}, nil
+}
+
+func (r *fakeWalletRepository) WalletIncludingClosed(ctx context.Context, address string) (*models.Wallet, error) {
+	return r.Wallet(ctx, address)
+}
+
+// newTestService создает сервис с доставкой в памяти на URL receiver.
+func newTestService(receiver string, sender repository.WebhookSender,
+	policy Policy) (*webhookService, *fakeWebhookRepository) {
+	subscription := &models.WebhookSubscription{
+		PublicID:   testSubscription,
+		Wallet:     "wallet-a",
+		URL:        receiver,
+		EventTypes: []string{models.WebhookEventTransferIncoming},
+		Secret:     testSecret,
+	}
+	subscription.ID = 7
+
+	repo := &fakeWebhookRepository{
+		subscription: subscription,
+		delivery: &models.WebhookDelivery{
+			PublicID:       testDelivery,
+			SubscriptionID: subscription.ID,
+			EventID:        42,
+			EventType:      models.WebhookEventTransferIncoming,
+			Payload:        []byte(`{"transaction_id":"t1","amount":"10"}`),
+			Status:         models.WebhookDeliveryPending,
+		},
+	}
+
+	return NewWebhookService(repo, &fakeWalletRepository{}, sender, policy).(*webhookService), repo
+}
+
+// attempt выполняет одну попытку доставки и возвращает время до и после нее.
+func attempt(t *testing.T, s *webhookService, repo *fakeWebhookRepository) (time.Time, time.Time) {
+	t.Helper()
+
+	delivery := *repo.delivery
+	delivery.Subscription = repo.subscription
+
+	before := time.Now()
+	if err := s.deliver(context.Background(), &delivery); err != nil {
+		t.Fatalf("deliver() error = %v", err)
+	}
+	return before, time.Now()
+}
+
+// TestDeliverSigned проверяет, что получатель может проверить подпись доставки
+// и что ответ 2xx завершает доставку.
+func TestDeliverSigned(t *testing.T) {
+	var verified atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("X-Webhook-ID") == testDelivery &&
+			r.Header.Get("X-Webhook-Event") == models.WebhookEventTransferIncoming &&
+			VerifySignature(testSecret, r.Header.Get(SignatureHeader), body, time.Now(), time.Minute) {
+			verified.Store(true)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	s, repo := newTestService(server.URL, events.NewHTTPSender(time.Second), Policy{MaxAttempts: 3})
+	attempt(t, s, repo)
+
+	if !verified.Load() {
+		t.Fatal("receiver could not verify the delivery headers and signature")
+	}
+	if repo.delivery.Status != models.WebhookDeliverySucceeded || repo.delivery.Attempts != 1 ||
+		repo.delivery.DeliveredAt == nil {
+		t.Fatalf("delivery = %s after %d attempts (delivered at %v), want succeeded after 1",
+			repo.delivery.Status, repo.delivery.Attempts, repo.delivery.DeliveredAt)
+	}
+	if repo.delivery.LastStatusCode == nil || *repo.delivery.LastStatusCode != http.StatusNoContent {
+		t.Fatalf("last status code = %v, want %d", repo.delivery.LastStatusCode, http.StatusNoContent)
+	}
+}
+
+// TestDeliverRetryBackoff проверяет, что после ответа вне 2xx попытка назначается повторно
+// с удваивающейся задержкой, не превышающей MaxBackoff.
+func TestDeliverRetryBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	s, repo := newTestService(server.URL, events.NewHTTPSender(time.Second), Policy{
+		MaxAttempts:  10,
+		RetryBackoff: time.Second,
+		MaxBackoff:   5 * time.Second,
+	})
+
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		before, after := attempt(t, s, repo)
+
+		delivery := repo.delivery
+		if delivery.Status != models.WebhookDeliveryPending || delivery.Attempts != i+1 {
+			t.Fatalf("attempt %d: delivery = %s after %d attempts, want pending after %d",
+				i+1, delivery.Status, delivery.Attempts, i+1)
+		}
+		if delivery.LastStatusCode == nil || *delivery.LastStatusCode != http.StatusInternalServerError ||
+			delivery.LastError == nil {
+			t.Fatalf("attempt %d: last status code = %v, error = %v, want 500 with error",
+				i+1, delivery.LastStatusCode, delivery.LastError)
+		}
+		if delivery.NextAttemptAt.Before(before.Add(want)) || delivery.NextAttemptAt.After(after.Add(want)) {
+			t.Fatalf("attempt %d: next attempt in %v, want %v", i+1, delivery.NextAttemptAt.Sub(before), want)
+		}
+	}
+}
+
+// TestDeliverRetryTimeout проверяет, что таймаут получателя считается неудачной попыткой.
+func TestDeliverRetryTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	s, repo := newTestService(server.URL, events.NewHTTPSender(50*time.Millisecond), Policy{
+		MaxAttempts:  3,
+		RetryBackoff: time.Minute,
+		MaxBackoff:   time.Hour,
+	})
+	before, after := attempt(t, s, repo)
+
+	delivery := repo.delivery
+	if delivery.Status != models.WebhookDeliveryPending || delivery.Attempts != 1 {
+		t.Fatalf("delivery = %s after %d attempts, want pending after 1", delivery.Status, delivery.Attempts)
+	}
+	if delivery.LastStatusCode != nil || delivery.LastError == nil {
+		t.Fatalf("last status code = %v, error = %v, want no status with error",
+			delivery.LastStatusCode, delivery.LastError)
+	}
+	if delivery.NextAttemptAt.Before(before.Add(time.Minute)) || delivery.NextAttemptAt.After(after.Add(time.Minute)) {
+		t.Fatalf("next attempt in %v, want %v", delivery.NextAttemptAt.Sub(before), time.Minute)
+	}
+}
+
+// TestDeliverFailsAfterMaxAttempts проверяет, что после MaxAttempts неудачных попыток
+// доставка помечается неуспешной и больше не захватывается.
+func TestDeliverFailsAfterMaxAttempts(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	s, repo := newTestService(server.URL, events.NewHTTPSender(time.Second), Policy{
+		BatchSize:   10,
+		MaxAttempts: 3,
+	})
+
+	// Нулевая задержка: каждая следующая попытка наступает сразу
+	for i := 0; i < 5; i++ {
+		if _, err := s.DeliverDue(context.Background()); err != nil {
+			t.Fatalf("DeliverDue() error = %v", err)
+		}
+	}
+
+	if repo.delivery.Status != models.WebhookDeliveryFailed || repo.delivery.Attempts != 3 {
+		t.Fatalf("delivery = %s after %d attempts, want failed after 3", repo.delivery.Status, repo.delivery.Attempts)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Fatalf("receiver got %d requests, want 3", got)
+	}
+}
+
+// TestRedeliver проверяет, что ручная повторная доставка сбрасывает число попыток
+// и доставка отправляется снова.
+func TestRedeliver(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if healthy.Load() {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	s, repo := newTestService(server.URL, events.NewHTTPSender(time.Second), Policy{
+		BatchSize:   10,
+		MaxAttempts: 1,
+	})
+
+	if _, err := s.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("DeliverDue() error = %v", err)
+	}
+	if repo.delivery.Status != models.WebhookDeliveryFailed {
+		t.Fatalf("delivery = %s, want failed", repo.delivery.Status)
+	}
+
+	stranger := models.WithPrincipal(context.Background(), &models.Principal{AccountID: 2})
+	if _, err := s.Redeliver(stranger, testSubscription, testDelivery); !errors.Is(err, er.ErrWalletForbidden) {
+		t.Fatalf("Redeliver() by another account error = %v, want %v", err, er.ErrWalletForbidden)
+	}
+
+	owner := models.WithPrincipal(context.Background(), &models.Principal{AccountID: 1})
+	resp, err := s.Redeliver(owner, testSubscription, testDelivery)
+	if err != nil {
+		t.Fatalf("Redeliver() error = %v", err)
+	}
+	if resp.Status != string(models.WebhookDeliveryPending) || resp.Attempts != 0 {
+		t.Fatalf("Redeliver() = %s after %d attempts, want pending after 0", resp.Status, resp.Attempts)
+	}
+
+	healthy.Store(true)
+	if _, err = s.DeliverDue(context.Background()); err != nil {
+		t.Fatalf("DeliverDue() error = %v", err)
+	}
+	if repo.delivery.Status != models.WebhookDeliverySucceeded || repo.delivery.Attempts != 1 {
+		t.Fatalf("delivery = %s after %d attempts, want succeeded after 1", repo.delivery.Status, repo.delivery.Attempts)
+	}
+}
+
+// TestDeliverRejectsPrivateTarget проверяет, что отправитель по умолчанию не подключается
+// к loopback-адресу, даже если подписка на него уже существует.
+func TestDeliverRejectsPrivateTarget(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	s, repo := newTestService(server.URL, events.NewPublicHTTPSender(time.Second), Policy{MaxAttempts: 3})
+	attempt(t, s, repo)
+
+	if requests.Load() != 0 {
+		t.Fatal("sender connected to a loopback receiver")
+	}
+	if repo.delivery.Status != models.WebhookDeliveryPending || repo.delivery.LastError == nil {
+		t.Fatalf("delivery = %s (error %v), want pending with error", repo.delivery.Status, repo.delivery.LastError)
+	}
+}
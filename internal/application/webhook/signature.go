@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader - заголовок запроса с подписью тела вебхука.
+const SignatureHeader = "X-Webhook-Signature"
+
+// Sign вычисляет подпись тела вебхука в формате "t=<unix-время>,v1=<hex>", где v1 -
+// HMAC-SHA256 строки "<unix-время>.<тело>" с секретом подписки. Время входит в подпись,
+// чтобы получатель мог отклонять повторно отправленные перехваченные запросы.
+//
+// Параметры:
+//   - secret: секрет подписки
+//   - timestamp: время отправки
+//   - body: тело запроса
+//
+// Возвращает:
+//   - string: значение заголовка SignatureHeader
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac(secret, t, body))
+}
+
+// VerifySignature проверяет подпись тела вебхука на стороне получателя.
+//
+// Параметры:
+//   - secret: секрет подписки
+//   - header: значение заголовка SignatureHeader
+//   - body: тело запроса
+//   - now: текущее время
+//   - tolerance: допустимое расхождение времени подписи с now
+//
+// Возвращает:
+//   - bool: подпись верна и время подписи в пределах tolerance
+func VerifySignature(secret, header string, body []byte, now time.Time, tolerance time.Duration) bool {
+	var t, v1 string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			v1 = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return false
+	}
+	if diff := now.Sub(time.Unix(unix, 0)); diff > tolerance || diff < -tolerance {
+		return false
+	}
+
+	signature, err := hex.DecodeString(v1)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(signature, mac(secret, t, body))
+}
+
+// mac вычисляет HMAC-SHA256 строки "<t>.<body>".
+func mac(secret, t string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(t))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
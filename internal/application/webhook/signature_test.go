@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+// TestSignFormat проверяет формат заголовка подписи.
+func TestSignFormat(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	header := Sign("whsec_test", now, []byte(`{"id":"1"}`))
+
+	if !regexp.MustCompile(`^t=1700000000,v1=[0-9a-f]{64}$`).MatchString(header) {
+		t.Fatalf("Sign() = %q, want t=<unix>,v1=<hex sha256>", header)
+	}
+}
+
+// TestVerifySignature проверяет, что получатель принимает только подпись того же тела
+// тем же секретом в пределах допустимого расхождения времени.
+func TestVerifySignature(t *testing.T) {
+	secret := "whsec_test"
+	body := []byte(`{"id":"1","type":"transfer.incoming"}`)
+	signedAt := time.Unix(1700000000, 0)
+	header := Sign(secret, signedAt, body)
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   []byte
+		now    time.Time
+		want   bool
+	}{
+		{"valid", secret, header, body, signedAt.Add(time.Minute), true},
+		{"tampered body", secret, header, []byte(`{"id":"2","type":"transfer.incoming"}`), signedAt, false},
+		{"wrong secret", "whsec_other", header, body, signedAt, false},
+		{"expired", secret, header, body, signedAt.Add(6 * time.Minute), false},
+		{"from the future", secret, header, body, signedAt.Add(-6 * time.Minute), false},
+		{"missing timestamp", secret, header[len("t=1700000000,"):], body, signedAt, false},
+		{"malformed signature", secret, "t=1700000000,v1=zz", body, signedAt, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, tt.header, tt.body, tt.now, 5*time.Minute); got != tt.want {
+				t.Fatalf("VerifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
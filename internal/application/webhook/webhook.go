@@ -0,0 +1,357 @@
+// Package webhook предоставляет сервисный слой для подписок на вебхуки.
+// Реализует управление подписками, создание доставок из событий outbox,
+// подписанную отправку доставок и повторные попытки.
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/normalniydada/case_infotecs/internal/application/access"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"net"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// deliveriesLimit - число последних доставок в ответе на запрос журнала доставок.
+const deliveriesLimit = 100
+
+// Policy задает параметры отправки доставок.
+type Policy struct {
+	BatchSize    int           // Максимальное число доставок, захватываемых за один раз
+	Lease        time.Duration // Срок аренды захваченной доставки
+	MaxAttempts  int           // Максимальное число попыток доставки
+	RetryBackoff time.Duration // Задержка перед первой повторной попыткой (далее удваивается)
+	MaxBackoff   time.Duration // Максимальная задержка перед повторной попыткой
+
+	// AllowPrivateTargets разрешает подписки на loopback, частные и link-local адреса
+	// (только для локальной разработки и тестов).
+	AllowPrivateTargets bool
+}
+
+// webhookService реализует интерфейс WebhookService.
+type webhookService struct {
+	repo       repository.WebhookRepository
+	walletRepo repository.WalletRepository
+	sender     repository.WebhookSender
+	policy     Policy
+}
+
+// NewWebhookService создает новый экземпляр сервиса вебхуков.
+//
+// Параметры:
+//   - repo: репозиторий подписок и доставок
+//   - walletRepo: репозиторий кошельков (проверка кошелька подписки и его владельца)
+//   - sender: отправитель HTTP-запросов
+//   - policy: параметры отправки и повторных попыток
+//
+// Возвращает:
+//   - service.WebhookService: реализацию интерфейса сервиса
+func NewWebhookService(repo repository.WebhookRepository, walletRepo repository.WalletRepository,
+	sender repository.WebhookSender, policy Policy) service.WebhookService {
+	return &webhookService{repo: repo, walletRepo: walletRepo, sender: sender, policy: policy}
+}
+
+// CreateWebhook создает подписку на события кошелька и генерирует ее секрет.
+// Подписаться на события кошелька может только его владелец.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - req: кошелек, URL и типы событий
+//
+// Возвращает:
+//   - *dto.WebhookResponse: созданная подписка вместе с секретом
+//   - error: ошибка, если подписка не создана
+//
+// Возможные ошибки:
+//   - ErrInvalidWebhook: URL не http(s), указывает на внутренний адрес (если это не разрешено
+//     политикой) или список событий пуст или содержит неизвестный тип
+//   - ErrUnauthenticated, ErrWalletForbidden: запрос без API-ключа или от чужой учетной записи
+//   - ErrWalletNotFound: кошелек не найден
+func (s *webhookService) CreateWebhook(ctx context.Context, req dto.WebhookRequest) (*dto.WebhookResponse, error) {
+	if err := validateWebhook(req); err != nil {
+		return nil, err
+	}
+
+	if !s.policy.AllowPrivateTargets {
+		if err := validateTarget(ctx, req.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := access.Principal(ctx); err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.walletRepo.Wallet(ctx, req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = access.Authorize(ctx, wallet); err != nil {
+		return nil, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &models.WebhookSubscription{
+		PublicID:   uuid.NewString(),
+		Wallet:     req.Wallet,
+		URL:        req.URL,
+		EventTypes: slices.Compact(slices.Sorted(slices.Values(req.Events))),
+		Secret:     secret,
+	}
+
+	if err = s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewWebhookResponse(subscription)
+	resp.Secret = secret
+	return &resp, nil
+}
+
+// Webhook возвращает подписку на вебхук (без секрета).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: публичный идентификатор подписки (UUID)
+//
+// Возвращает:
+//   - *dto.WebhookResponse: подписка
+//   - error: ErrInvalidWebhookID, ErrWebhookNotFound или ошибка репозитория
+func (s *webhookService) Webhook(ctx context.Context, id string) (*dto.WebhookResponse, error) {
+	subscription, err := s.subscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewWebhookResponse(subscription)
+	return &resp, nil
+}
+
+// Webhooks возвращает подписки кошелька (без секретов).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - []dto.WebhookResponse: подписки (пустой список, если их нет)
+//   - error: ошибка репозитория
+func (s *webhookService) Webhooks(ctx context.Context, address string) ([]dto.WebhookResponse, error) {
+	subscriptions, err := s.repo.Subscriptions(ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook subscriptions: %w", err)
+	}
+
+	resp := make([]dto.WebhookResponse, 0, len(subscriptions))
+	for i := range subscriptions {
+		resp = append(resp, dto.NewWebhookResponse(&subscriptions[i]))
+	}
+
+	return resp, nil
+}
+
+// DeleteWebhook удаляет подписку и отменяет ее неотправленные доставки.
+// Удалить подписку может только владелец кошелька подписки.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор подписки (UUID)
+//
+// Возвращает:
+//   - error: ErrInvalidWebhookID, ErrWebhookNotFound, ErrUnauthenticated, ErrWalletForbidden
+//     или ошибка репозитория
+func (s *webhookService) DeleteWebhook(ctx context.Context, id string) error {
+	subscription, err := s.subscription(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.authorize(ctx, subscription); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSubscription(ctx, subscription)
+}
+
+// Deliveries возвращает журнал последних доставок подписки.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: публичный идентификатор подписки (UUID)
+//   - status: фильтр по статусу (pending, succeeded, failed, cancelled; пустая строка - все)
+//
+// Возвращает:
+//   - []dto.WebhookDeliveryResponse: доставки от новых к старым
+//   - error: ErrInvalidWebhookID, ErrWebhookNotFound, ErrInvalidWebhook (неизвестный статус)
+//     или ошибка репозитория
+func (s *webhookService) Deliveries(ctx context.Context, id string,
+	status string) ([]dto.WebhookDeliveryResponse, error) {
+	subscription, err := s.subscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter *models.WebhookDeliveryStatus
+	if status != "" {
+		st := models.WebhookDeliveryStatus(status)
+		switch st {
+		case models.WebhookDeliveryPending, models.WebhookDeliverySucceeded,
+			models.WebhookDeliveryFailed, models.WebhookDeliveryCancelled:
+			filter = &st
+		default:
+			return nil, fmt.Errorf("%w: unknown delivery status %q", er.ErrInvalidWebhook, status)
+		}
+	}
+
+	deliveries, err := s.repo.Deliveries(ctx, subscription.ID, filter, deliveriesLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting webhook deliveries: %w", err)
+	}
+
+	resp := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		resp = append(resp, dto.NewWebhookDeliveryResponse(&deliveries[i]))
+	}
+
+	return resp, nil
+}
+
+// Redeliver назначает немедленную повторную доставку (например, после исправления
+// получателя). Число попыток сбрасывается; тело и идентификатор доставки не меняются.
+// Запросить повторную доставку может только владелец кошелька подписки.
+//
+// Параметры:
+//   - ctx: контекст выполнения (содержит субъект операции)
+//   - id: публичный идентификатор подписки (UUID)
+//   - deliveryID: публичный идентификатор доставки (UUID)
+//
+// Возвращает:
+//   - *dto.WebhookDeliveryResponse: доставка, ожидающая отправки
+//   - error: ErrInvalidWebhookID, ErrWebhookNotFound, ErrUnauthenticated, ErrWalletForbidden,
+//     ErrWebhookDeliveryNotFound или ошибка репозитория
+func (s *webhookService) Redeliver(ctx context.Context, id, deliveryID string) (*dto.WebhookDeliveryResponse, error) {
+	subscription, err := s.subscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.authorize(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	publicID, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, er.ErrInvalidWebhookID
+	}
+
+	delivery, err := s.repo.Delivery(ctx, subscription.ID, publicID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.Redeliver(ctx, delivery, time.Now()); err != nil {
+		return nil, err
+	}
+
+	resp := dto.NewWebhookDeliveryResponse(delivery)
+	return &resp, nil
+}
+
+// subscription разбирает идентификатор и возвращает подписку.
+func (s *webhookService) subscription(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	publicID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, er.ErrInvalidWebhookID
+	}
+
+	return s.repo.Subscription(ctx, publicID.String())
+}
+
+// authorize проверяет, что вызывающий владеет кошельком подписки (в том числе закрытым).
+func (s *webhookService) authorize(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if _, err := access.Principal(ctx); err != nil {
+		return err
+	}
+
+	wallet, err := s.walletRepo.WalletIncludingClosed(ctx, subscription.Wallet)
+	if err != nil {
+		return err
+	}
+
+	return access.Authorize(ctx, wallet)
+}
+
+// validateWebhook проверяет URL и типы событий подписки.
+func validateWebhook(req dto.WebhookRequest) error {
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) url", er.ErrInvalidWebhook)
+	}
+
+	if len(req.Events) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", er.ErrInvalidWebhook)
+	}
+
+	for _, event := range req.Events {
+		if !slices.Contains(models.WebhookEventTypes, event) {
+			return fmt.Errorf("%w: unknown event type %q", er.ErrInvalidWebhook, event)
+		}
+	}
+
+	return nil
+}
+
+// validateTarget проверяет, что все адреса хоста URL подписки публичные.
+// Имя хоста разрешается при создании подписки; отправитель повторяет проверку при
+// подключении, поэтому последующая смена DNS-записи не открывает доступ к внутренним адресам.
+func validateTarget(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: url must be an absolute http(s) url", er.ErrInvalidWebhook)
+	}
+
+	host := u.Hostname()
+	var ips []net.IP
+	if ip := net.ParseIP(host); ip != nil {
+		ips = []net.IP{ip}
+	} else {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return fmt.Errorf("%w: cannot resolve url host %q", er.ErrInvalidWebhook, host)
+		}
+		for _, addr := range addrs {
+			ips = append(ips, addr.IP)
+		}
+	}
+
+	for _, ip := range ips {
+		if !models.PublicWebhookAddress(ip) {
+			return fmt.Errorf("%w: url must not point to a private, loopback or link-local address", er.ErrInvalidWebhook)
+		}
+	}
+
+	return nil
+}
+
+// newSecret генерирует секрет подписки.
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
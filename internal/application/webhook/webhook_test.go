@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/events"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"testing"
+	"time"
+)
+
+// TestCreateWebhookRejectsInternalTargets проверяет, что подписка на не-http(s) URL
+// и на внутренние адреса отклоняется.
+func TestCreateWebhookRejectsInternalTargets(t *testing.T) {
+	s, _ := newTestService("", events.NewHTTPSender(time.Second), Policy{})
+	ctx := models.WithPrincipal(context.Background(), &models.Principal{AccountID: 1})
+
+	for _, target := range []string{
+		"ftp://93.184.216.34/hook",
+		"file:///etc/passwd",
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://10.0.0.5/hook",
+		"http://192.168.1.1/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://0.0.0.0/hook",
+	} {
+		t.Run(target, func(t *testing.T) {
+			_, err := s.CreateWebhook(ctx, dto.WebhookRequest{
+				Wallet: "wallet-a",
+				URL:    target,
+				Events: []string{models.WebhookEventTransferIncoming},
+			})
+			if !errors.Is(err, er.ErrInvalidWebhook) {
+				t.Fatalf("CreateWebhook(%q) error = %v, want %v", target, err, er.ErrInvalidWebhook)
+			}
+		})
+	}
+}
+
+// TestCreateWebhookAllowPrivateTargets проверяет, что политика разрешает внутренние адреса
+// и подписка создается с секретом.
+func TestCreateWebhookAllowPrivateTargets(t *testing.T) {
+	s, repo := newTestService("", events.NewHTTPSender(time.Second), Policy{AllowPrivateTargets: true})
+	ctx := models.WithPrincipal(context.Background(), &models.Principal{AccountID: 1})
+
+	resp, err := s.CreateWebhook(ctx, dto.WebhookRequest{
+		Wallet: "wallet-a",
+		URL:    "http://127.0.0.1:8080/hook",
+		Events: []string{models.WebhookEventTransferIncoming},
+	})
+	if err != nil {
+		t.Fatalf("CreateWebhook() error = %v", err)
+	}
+	if repo.created == nil || resp.Secret == "" || repo.created.Secret != resp.Secret {
+		t.Fatal("CreateWebhook() did not store the subscription with its secret")
+	}
+}
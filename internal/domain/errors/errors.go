@@ -2,7 +2,11 @@
 // Ошибки сгруппированы в соответствии с их происхождением в слоистой архитектуре:
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+)
 
 // Ошибки уровня репозитория (data access layer).
 // Возникают при взаимодействии с хранилищем данных.
@@ -22,9 +26,122 @@ var (
 	// ErrWalletExists возвращается при попытке создать уже существующий кошелек.
 	ErrWalletExists = errors.New("wallet already exists")
 
+	// ErrWalletSenderFrozen возвращается, если списания с кошелька отправителя заблокированы
+	// (статус frozen_out или frozen).
+	// HTTP-аналог: 423 Locked
+	ErrWalletSenderFrozen = errors.New("sender's wallet is frozen for outgoing transfers")
+
+	// ErrWalletReceiverFrozen возвращается, если зачисления на кошелек получателя заблокированы
+	// (статус frozen_in или frozen).
+	// HTTP-аналог: 423 Locked
+	ErrWalletReceiverFrozen = errors.New("receiver's wallet is frozen for incoming transfers")
+
+	// ErrWalletFrozen возвращается при попытке закрыть замороженный кошелек.
+	// HTTP-аналог: 423 Locked
+	ErrWalletFrozen = errors.New("wallet is frozen")
+
+	// ErrConcurrentUpdate возвращается, когда транзакция БД прервана взаимоблокировкой или ошибкой
+	// сериализации при всех попытках повтора. Изменения не зафиксированы, запрос можно повторить.
+	// HTTP-аналог: 503 Service Unavailable (с заголовком Retry-After)
+	ErrConcurrentUpdate = errors.New("conflict with concurrent updates, retry the request")
+
 	// ErrNotEnoughMoney возвращается при недостаточном балансе для перевода.
 	// HTTP-аналог: 422 Unprocessable Entity
 	ErrNotEnoughMoney = errors.New("insufficient funds in the sender's wallet")
+
+	// ErrLimitExceeded возвращается, если перевод превышает лимит расходов кошелька отправителя.
+	// Возвращается в составе *LimitExceededError.
+	// HTTP-аналог: 422 Unprocessable Entity
+	ErrLimitExceeded = errors.New("spending limit exceeded")
+
+	// ErrRefundNotEnoughMoney возвращается, если у получателя исходной транзакции
+	// уже нет средств для возврата.
+	// HTTP-аналог: 422 Unprocessable Entity
+	ErrRefundNotEnoughMoney = errors.New("insufficient funds in the receiver's wallet to refund the transaction")
+
+	// ErrWalletNotEmpty возвращается при попытке закрыть кошелек с ненулевым балансом.
+	// HTTP-аналог: 409 Conflict
+	ErrWalletNotEmpty = errors.New("wallet balance must be zero to close it")
+
+	// ErrCurrencyNotFound возвращается при указании неизвестной валюты.
+	// HTTP-аналог: 400 Bad Request
+	ErrCurrencyNotFound = errors.New("currency not found")
+
+	// ErrFXRateNotFound возвращается, если поставщик курсов не знает курс для пары валют.
+	// HTTP-аналог: 400 Bad Request
+	ErrFXRateNotFound = errors.New("exchange rate is not available for the currency pair")
+
+	// ErrFeeCollectorNotFound возвращается, если кошелек-сборщик комиссий из тарифа
+	// не существует или ведется в другой валюте (ошибка конфигурации).
+	// HTTP-аналог: 500 Internal Server Error
+	ErrFeeCollectorNotFound = errors.New("fee collector wallet not found")
+
+	// ErrHoldNotFound возвращается, если холд с указанным идентификатором не найден.
+	// HTTP-аналог: 404 Not Found
+	ErrHoldNotFound = errors.New("hold not found")
+
+	// ErrPendingTransferNotFound возвращается, если отложенный перевод с указанным идентификатором не найден.
+	// HTTP-аналог: 404 Not Found
+	ErrPendingTransferNotFound = errors.New("pending transfer not found")
+
+	// ErrHoldNotActive возвращается при попытке списать или отменить
+	// уже списанный, отмененный или истекший холд.
+	// HTTP-аналог: 409 Conflict
+	ErrHoldNotActive = errors.New("hold is not active")
+
+	// ErrPendingTransferNotPending возвращается при попытке одобрить или отклонить
+	// отложенный перевод, который уже выполнен или отклонен.
+	// HTTP-аналог: 409 Conflict
+	ErrPendingTransferNotPending = errors.New("pending transfer is not pending")
+
+	// ErrAlreadyDecided возвращается, если учетная запись уже одобрила или отклонила отложенный перевод.
+	// HTTP-аналог: 409 Conflict
+	ErrAlreadyDecided = errors.New("account has already decided on this pending transfer")
+
+	// ErrScheduledTransferNotFound возвращается, если запланированный перевод не найден.
+	// HTTP-аналог: 404 Not Found
+	ErrScheduledTransferNotFound = errors.New("scheduled transfer not found")
+
+	// ErrScheduledTransferFinished возвращается при попытке изменить завершенный,
+	// остановленный из-за ошибки или отмененный запланированный перевод.
+	// HTTP-аналог: 409 Conflict
+	ErrScheduledTransferFinished = errors.New("scheduled transfer is no longer active")
+
+	// ErrScheduledTransferClaimLost возвращается, если аренда запланированного перевода
+	// истекла и его захватил другой экземпляр планировщика.
+	ErrScheduledTransferClaimLost = errors.New("scheduled transfer claim was lost")
+
+	// ErrWebhookNotFound возвращается, если подписка на вебхук не найдена или удалена.
+	// HTTP-аналог: 404 Not Found
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// ErrWebhookDeliveryNotFound возвращается, если доставка вебхука не найдена.
+	// HTTP-аналог: 404 Not Found
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	// ErrWebhookDeliveryClaimLost возвращается, если аренда доставки истекла
+	// или доставка была запрошена повторно во время отправки.
+	ErrWebhookDeliveryClaimLost = errors.New("webhook delivery claim was lost")
+
+	// ErrAccountNotFound возвращается, если учетная запись не найдена.
+	// HTTP-аналог: 404 Not Found
+	ErrAccountNotFound = errors.New("account not found")
+
+	// ErrAPIKeyNotFound возвращается, если API-ключ не найден.
+	// HTTP-аналог: 404 Not Found
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	// ErrNonceReused возвращается, если номер подписанного перевода не больше номера
+	// последнего перевода с кошелька (повтор или устаревший запрос).
+	// HTTP-аналог: 409 Conflict
+	ErrNonceReused = errors.New("nonce must be greater than the wallet's last nonce")
+
+	// ErrIdempotencyKeyNotFound возвращается когда результат для ключа идемпотентности не сохранен.
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+	// ErrIdempotencyKeyExists возвращается когда ключ идемпотентности уже занят
+	// конкурентным запросом, успевшим зафиксировать свою транзакцию.
+	ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
 )
 
 // Ошибки уровня сервиса (business logic layer).
@@ -34,6 +151,14 @@ var (
 	// HTTP-аналог: 404 Not Found
 	ErrTransactionNotFound = errors.New("no transactions")
 
+	// ErrTransactionIDNotFound возвращается когда транзакция с указанным идентификатором не найдена.
+	// HTTP-аналог: 404 Not Found
+	ErrTransactionIDNotFound = errors.New("transaction not found")
+
+	// ErrInvalidTransactionID возвращается при невалидном идентификаторе транзакции (не UUID).
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidTransactionID = errors.New("invalid transaction id")
+
 	// ErrSameWalletTransfer возвращается при попытке перевода самому себе.
 	// HTTP-аналог: 400 Bad Request
 	ErrSameWalletTransfer = errors.New("impossible to send money to yourself")
@@ -41,6 +166,157 @@ var (
 	// ErrInvalidAmount возвращается при невалидной сумме перевода (<= 0).
 	// HTTP-аналог: 400 Bad Request
 	ErrInvalidAmount = errors.New("the sum must be positive")
+
+	// ErrCurrencyMismatch возвращается, если валюта кошелька не совпадает с валютой перевода.
+	// HTTP-аналог: 400 Bad Request
+	ErrCurrencyMismatch = errors.New("wallet currency does not match the transfer currency")
+
+	// ErrInvalidAmountPrecision возвращается, если сумма содержит больше знаков после запятой,
+	// чем допускает валюта, или не кратна минимальной единице валюты.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidAmountPrecision = errors.New("amount precision is not supported by the currency")
+
+	// ErrAmountAmbiguous возвращается, если в запросе перевода указаны обе суммы
+	// (списания и зачисления) или не указана ни одна.
+	// HTTP-аналог: 400 Bad Request
+	ErrAmountAmbiguous = errors.New("exactly one of amount and target_amount must be set")
+
+	// ErrCaptureExceedsHold возвращается при попытке списать больше зарезервированной суммы.
+	// HTTP-аналог: 400 Bad Request
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds the held amount")
+
+	// ErrInvalidHoldTTL возвращается при отрицательном или слишком большом сроке холда.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidHoldTTL = errors.New("invalid hold ttl")
+
+	// ErrInvalidHoldID возвращается при невалидном идентификаторе холда (не UUID).
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidHoldID = errors.New("invalid hold id")
+
+	// ErrRefundExceedsOriginal возвращается, если возврат превысит сумму исходной транзакции
+	// с учетом уже выполненных возвратов.
+	// HTTP-аналог: 409 Conflict
+	ErrRefundExceedsOriginal = errors.New("refund amount exceeds the refundable amount of the transaction")
+
+	// ErrRefundOfRefund возвращается при попытке вернуть транзакцию, которая сама является возвратом.
+	// HTTP-аналог: 400 Bad Request
+	ErrRefundOfRefund = errors.New("refund transactions cannot be refunded")
+
+	// ErrInvalidBatchSize возвращается, если пакет переводов пуст или содержит слишком много переводов.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidBatchSize = errors.New("invalid number of transfers in the batch")
+
+	// ErrInvalidSchedule возвращается при невалидном расписании перевода
+	// (слишком короткий период, окончание раньше начала, неизвестный статус).
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidSchedule = errors.New("invalid transfer schedule")
+
+	// ErrInvalidScheduledTransferID возвращается при невалидном идентификаторе запланированного перевода.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidScheduledTransferID = errors.New("invalid scheduled transfer id")
+
+	// ErrInvalidWebhook возвращается при невалидной подписке на вебхук
+	// (URL не http(s) или указывает на внутренний адрес, неизвестный или пустой список событий).
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidWebhook = errors.New("invalid webhook subscription")
+
+	// ErrInvalidWebhookID возвращается при невалидном идентификаторе подписки или доставки вебхука.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidWebhookID = errors.New("invalid webhook id")
+
+	// ErrUnauthenticated возвращается, если операция требует API-ключа, а запрос его не содержит.
+	// HTTP-аналог: 401 Unauthorized
+	ErrUnauthenticated = errors.New("authentication required")
+
+	// ErrInvalidAPIKey возвращается при неизвестном или отозванном API-ключе.
+	// HTTP-аналог: 401 Unauthorized
+	ErrInvalidAPIKey = errors.New("invalid api key")
+
+	// ErrWalletForbidden возвращается, если кошелек не принадлежит учетной записи вызывающего.
+	// HTTP-аналог: 403 Forbidden
+	ErrWalletForbidden = errors.New("wallet does not belong to the caller")
+
+	// ErrAccountForbidden возвращается при операции с чужой учетной записью без привилегированного доступа.
+	// HTTP-аналог: 403 Forbidden
+	ErrAccountForbidden = errors.New("account does not belong to the caller")
+
+	// ErrPermissionDenied возвращается, если роль вызывающего не имеет разрешения на операцию.
+	// HTTP-аналог: 403 Forbidden
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrInvalidAccount возвращается при невалидных параметрах учетной записи или API-ключа
+	// (пустое или слишком длинное имя, неизвестная роль, не указана учетная запись ключа).
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidAccount = errors.New("invalid account")
+
+	// ErrInvalidAPIKeyID возвращается при невалидном идентификаторе API-ключа или учетной записи.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidAPIKeyID = errors.New("invalid api key id")
+
+	// ErrInvalidPublicKey возвращается при невалидном открытом ключе кошелька
+	// или его отсутствии при создании кошелька учетной записью.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidPublicKey = errors.New("invalid wallet public key")
+
+	// ErrSignatureRequired возвращается при неподписанном переводе с кошелька, имеющего открытый ключ,
+	// а также при создании холда, запланированного перевода или возврата с такого кошелька (их нельзя подписать).
+	// HTTP-аналог: 400 Bad Request
+	ErrSignatureRequired = errors.New("transfers from this wallet must be signed")
+
+	// ErrInvalidSignature возвращается, если подпись перевода не соответствует
+	// открытому ключу кошелька отправителя или у кошелька нет ключа.
+	// HTTP-аналог: 403 Forbidden
+	ErrInvalidSignature = errors.New("invalid transfer signature")
+
+	// ErrInvalidWalletStatus возвращается при неизвестном или недопустимом статусе кошелька
+	// (закрыть кошелек можно только через DELETE) или отсутствии причины изменения.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidWalletStatus = errors.New("invalid wallet status")
+
+	// ErrInvalidLimit возвращается при неизвестном уровне лимитов или отрицательном лимите.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidLimit = errors.New("invalid spending limit")
+
+	// ErrTransferDenied возвращается, если перевод отклонен правилом проверки переводов.
+	// Текст ошибки содержит причину из правила.
+	// HTTP-аналог: 403 Forbidden
+	ErrTransferDenied = errors.New("transfer denied by risk rules")
+
+	// ErrTransferReviewRequired возвращается, если правило проверки требует ручной проверки перевода,
+	// а операцию нельзя отложить (пакетный перевод, холд): такой перевод выполняется через POST /send.
+	// HTTP-аналог: 403 Forbidden
+	ErrTransferReviewRequired = errors.New("transfer requires review")
+
+	// ErrInvalidPendingTransferID возвращается при невалидном идентификаторе отложенного перевода (не UUID).
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidPendingTransferID = errors.New("invalid pending transfer id")
+
+	// ErrInvalidPendingTransferStatus возвращается при неизвестном статусе в выборке отложенных переводов.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidPendingTransferStatus = errors.New("invalid pending transfer status")
+
+	// ErrSelfApproval возвращается, если инициатор перевода пытается одобрить свой перевод.
+	// HTTP-аналог: 403 Forbidden
+	ErrSelfApproval = errors.New("the initiator of a transfer cannot approve it")
+
+	// ErrNotApprover возвращается, если учетная запись не входит в число согласующих
+	// кошелька отправителя или решение принимает клиент без учетной записи.
+	// HTTP-аналог: 403 Forbidden
+	ErrNotApprover = errors.New("account is not an approver of the sender's wallet")
+
+	// ErrInvalidApprovalPolicy возвращается при невалидной политике согласования кошелька:
+	// отрицательном пороге, числе одобрений меньше 1 или больше числа согласующих.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidApprovalPolicy = errors.New("invalid approval policy")
+
+	// ErrInvalidInitialBalance возвращается при отрицательном начальном балансе кошелька.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidInitialBalance = errors.New("initial balance must not be negative")
+
+	// ErrIdempotencyKeyReused возвращается при повторе ключа идемпотентности
+	// с параметрами, отличающимися от первого запроса.
+	// HTTP-аналог: 409 Conflict
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different payload")
 )
 
 // Ошибки уровня обработчиков (API layer).
@@ -50,4 +326,65 @@ var (
 	// Используется для пагинации и лимитирования выборок.
 	// HTTP-аналог: 400 Bad Request
 	ErrInvalidCount = errors.New("invalid count query-params")
+
+	// ErrInvalidIdempotencyKey возвращается при слишком длинном заголовке Idempotency-Key.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidIdempotencyKey = errors.New("invalid Idempotency-Key header")
+
+	// ErrInvalidHistoryFilter возвращается при невалидных параметрах выборки истории транзакций
+	// (направление, даты, суммы, размер страницы).
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidHistoryFilter = errors.New("invalid transaction history filter")
+
+	// ErrInvalidCursor возвращается при невалидном курсоре пагинации.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+	// ErrInvalidLastEventID возвращается при невалидном Last-Event-ID потока транзакций.
+	// HTTP-аналог: 400 Bad Request
+	ErrInvalidLastEventID = errors.New("invalid Last-Event-ID")
+
+	// ErrStreamClosed возвращается при подписке на поток транзакций во время остановки приложения.
+	// HTTP-аналог: 503 Service Unavailable
+	ErrStreamClosed = errors.New("transaction stream is closed")
+
+	// ErrRateLimited возвращается, когда клиент или кошелек отправителя исчерпал лимит частоты запросов.
+	// HTTP-аналог: 429 Too Many Requests
+	ErrRateLimited = errors.New("rate limit exceeded")
 )
+
+// BatchLegError описывает ошибку одного перевода пакета.
+// Пакет выполняется атомарно, поэтому ошибка любого перевода отменяет весь пакет.
+// HTTP-аналог определяется ошибкой Err.
+type BatchLegError struct {
+	Leg int   // Номер перевода в пакете (с нуля)
+	Err error // Ошибка перевода
+}
+
+// Error возвращает описание ошибки с номером перевода.
+func (e *BatchLegError) Error() string {
+	return fmt.Sprintf("leg %d: %v", e.Leg, e.Err)
+}
+
+// Unwrap возвращает ошибку перевода для errors.Is и errors.As.
+func (e *BatchLegError) Unwrap() error {
+	return e.Err
+}
+
+// LimitExceededError описывает превышение лимита расходов кошелька.
+// HTTP-аналог: 422 Unprocessable Entity
+type LimitExceededError struct {
+	Period    string          // Вид лимита: single, daily, weekly или monthly
+	Limit     decimal.Decimal // Значение лимита
+	Remaining decimal.Decimal // Сумма, которую еще можно перевести в текущем периоде
+}
+
+// Error возвращает описание ошибки с видом лимита и остатком.
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s: %s limit %s, remaining %s", ErrLimitExceeded, e.Period, e.Limit, e.Remaining)
+}
+
+// Unwrap возвращает ErrLimitExceeded для errors.Is.
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
@@ -0,0 +1,72 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Account представляет учетную запись клиента API - владельца кошельков.
+// Role - роль учетной записи, определяющая разрешенные операции API
+// (пустая строка - роль по умолчанию из конфигурации).
+type Account struct {
+	gorm.Model
+	PublicID string `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	Name     string `gorm:"type:string;not null"`
+	Role     string `gorm:"type:string;not null;default:''"`
+}
+
+// APIKey представляет API-ключ учетной записи.
+// Ключ показывается клиенту только при создании; в БД хранится его SHA-256 (Hash)
+// и начало ключа (Prefix), по которому владелец отличает ключи в списке.
+// Отозванный ключ (RevokedAt задан) не принимается.
+type APIKey struct {
+	gorm.Model
+	PublicID   string     `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	AccountID  uint       `gorm:"not null;index"`
+	Account    *Account   `gorm:"foreignKey:AccountID"`
+	Name       string     `gorm:"type:string;not null;default:''"`
+	Prefix     string     `gorm:"type:string;not null"`
+	Hash       string     `gorm:"type:string;uniqueIndex;not null"`
+	LastUsedAt *time.Time `gorm:"type:timestamptz"`
+	RevokedAt  *time.Time `gorm:"type:timestamptz"`
+}
+
+// Principal описывает субъект, от имени которого выполняется операция:
+// учетную запись, предъявившую API-ключ, или привилегированного клиента (администратора,
+// фоновую задачу). Привилегированный субъект может распоряжаться любым кошельком.
+type Principal struct {
+	AccountID       uint   // Внутренний идентификатор учетной записи (0 - без учетной записи)
+	AccountPublicID string // Публичный идентификатор учетной записи
+	KeyID           string // Публичный идентификатор предъявленного API-ключа
+	Role            string // Роль учетной записи (пустая строка - роль по умолчанию)
+	Privileged      bool   // Привилегированный доступ
+}
+
+// SystemPrincipal - субъект фоновых задач приложения (планировщика, инициализации).
+var SystemPrincipal = &Principal{Privileged: true}
+
+// Owns сообщает, может ли субъект распоряжаться кошельком.
+func (p *Principal) Owns(wallet *Wallet) bool {
+	if p.Privileged {
+		return true
+	}
+	return p.AccountID != 0 && wallet.OwnerID != nil && *wallet.OwnerID == p.AccountID
+}
+
+// principalKey - ключ контекста, под которым хранится субъект операции.
+type principalKey struct{}
+
+// WithPrincipal возвращает контекст, содержащий субъект операции.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext возвращает субъект операции из контекста.
+// Второе значение false означает анонимный запрос.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,35 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// AuditOutcome описывает результат операции, записанной в журнал аудита.
+type AuditOutcome string
+
+const (
+	AuditOutcomeDenied AuditOutcome = "denied" // Операция отклонена: у роли нет разрешения
+)
+
+// AuditEvent представляет запись журнала аудита - попытку операции API и ее результат.
+// AccountID и KeyID - публичные идентификаторы учетной записи и API-ключа субъекта
+// (nil для анонимного запроса и администратора без ключа), Role - роль, по которой
+// проверялось разрешение Permission. Path - шаблон маршрута, URI - фактический путь запроса.
+// RemoteAddr - адрес клиента (за доверенным прокси - из X-Forwarded-For), PeerAddr - адрес
+// TCP-соединения, который клиент не может подменить заголовком.
+type AuditEvent struct {
+	gorm.Model
+	AccountID  *string      `gorm:"type:uuid;index"`
+	KeyID      *string      `gorm:"type:uuid"`
+	Role       string       `gorm:"type:string;not null"`
+	Permission Permission   `gorm:"type:string;not null"`
+	Method     string       `gorm:"type:string;not null"`
+	Path       string       `gorm:"type:string;not null"`
+	URI        string       `gorm:"type:string;not null"`
+	RemoteAddr string       `gorm:"type:string;not null"`
+	PeerAddr   string       `gorm:"type:string;not null"`
+	Outcome    AuditOutcome `gorm:"type:string;not null"`
+	Reason     string       `gorm:"type:text;not null"`
+}
@@ -0,0 +1,59 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// DefaultCurrency - валюта кошелька, если она не указана при создании.
+const DefaultCurrency = "RUB"
+
+// Currency представляет валюту кошельков: код ISO 4217 или символ пользовательского токена.
+// Определяет допустимую точность сумм и минимальную единицу, которой кратна любая сумма.
+type Currency struct {
+	Code      string          `gorm:"type:string;primaryKey"`
+	Decimals  int32           `gorm:"not null"`                    // Количество знаков после запятой
+	MinUnit   decimal.Decimal `gorm:"type:numeric(20,8);not null"` // Минимальная единица суммы
+	CreatedAt time.Time       `gorm:"not null"`
+}
+
+// ValidAmount проверяет, что сумма представима в валюте:
+// не содержит лишних знаков после запятой и кратна минимальной единице.
+//
+// Параметры:
+//   - amount: проверяемая сумма
+//
+// Возвращает:
+//   - bool: true, если сумма допустима для валюты
+func (c *Currency) ValidAmount(amount decimal.Decimal) bool {
+	if !amount.Equal(amount.Truncate(c.Decimals)) {
+		return false
+	}
+	return amount.Mod(c.MinUnit).IsZero()
+}
+
+// RoundDown округляет сумму вниз до ближайшего кратного минимальной единице валюты.
+// Используется для суммы зачисления при конвертации, чтобы не зачислить больше расчетного.
+//
+// Параметры:
+//   - amount: округляемая сумма
+//
+// Возвращает:
+//   - decimal.Decimal: сумма, представимая в валюте
+func (c *Currency) RoundDown(amount decimal.Decimal) decimal.Decimal {
+	return amount.Div(c.MinUnit).Floor().Mul(c.MinUnit)
+}
+
+// RoundUp округляет сумму вверх до ближайшего кратного минимальной единице валюты.
+// Используется для суммы списания при конвертации, чтобы не списать меньше расчетного.
+//
+// Параметры:
+//   - amount: округляемая сумма
+//
+// Возвращает:
+//   - decimal.Decimal: сумма, представимая в валюте
+func (c *Currency) RoundUp(amount decimal.Decimal) decimal.Decimal {
+	return amount.Div(c.MinUnit).Ceil().Mul(c.MinUnit)
+}
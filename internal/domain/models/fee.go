@@ -0,0 +1,61 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import "github.com/shopspring/decimal"
+
+// FeeTier представляет ступень тарифа: комиссия для сумм не больше UpTo.
+// Ступень без UpTo применяется ко всем суммам, не попавшим в предыдущие ступени.
+type FeeTier struct {
+	UpTo    *decimal.Decimal // Верхняя граница суммы (включительно), nil - без границы
+	Flat    decimal.Decimal  // Фиксированная часть комиссии
+	Percent decimal.Decimal  // Процент от суммы (0.5 = 0.5%)
+}
+
+// FeeSchedule представляет тариф комиссии за переводы в одной валюте.
+// Комиссия списывается с отправителя сверх суммы перевода и зачисляется
+// на кошелек-сборщик комиссий Collector.
+//
+// Комиссия рассчитывается как Flat + Percent% от суммы, либо по первой подходящей
+// ступени Tiers (ступени упорядочены по возрастанию UpTo), после чего ограничивается
+// снизу Min и сверху Max.
+type FeeSchedule struct {
+	Currency  string
+	Collector string           // Адрес кошелька-сборщика комиссий
+	Flat      decimal.Decimal  // Фиксированная часть комиссии
+	Percent   decimal.Decimal  // Процент от суммы (0.5 = 0.5%)
+	Tiers     []FeeTier        // Ступени тарифа (необязательно)
+	Min       *decimal.Decimal // Минимальная комиссия (необязательно)
+	Max       *decimal.Decimal // Максимальная комиссия (необязательно)
+}
+
+// Fee рассчитывает комиссию за перевод суммы amount без округления до единицы валюты.
+//
+// Параметры:
+//   - amount: сумма перевода в валюте тарифа
+//
+// Возвращает:
+//   - decimal.Decimal: комиссия (не отрицательна)
+func (s *FeeSchedule) Fee(amount decimal.Decimal) decimal.Decimal {
+	flat, percent := s.Flat, s.Percent
+	for _, tier := range s.Tiers {
+		if tier.UpTo == nil || amount.LessThanOrEqual(*tier.UpTo) {
+			flat, percent = tier.Flat, tier.Percent
+			break
+		}
+	}
+
+	fee := flat.Add(amount.Mul(percent).Div(decimal.NewFromInt(100)))
+
+	if s.Min != nil && fee.LessThan(*s.Min) {
+		fee = *s.Min
+	}
+	if s.Max != nil && fee.GreaterThan(*s.Max) {
+		fee = *s.Max
+	}
+
+	if fee.IsNegative() {
+		return decimal.Zero
+	}
+	return fee
+}
@@ -0,0 +1,23 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import "github.com/shopspring/decimal"
+
+// FXRate представляет курс обмена валюты Base на валюту Quote.
+// Rate - средний курс (сколько единиц Quote стоит одна единица Base),
+// Spread - доля, удерживаемая при обмене (например, 0.005 = 0.5%).
+type FXRate struct {
+	Base   string
+	Quote  string
+	Rate   decimal.Decimal
+	Spread decimal.Decimal
+}
+
+// Applied возвращает курс, применяемый к переводу: средний курс за вычетом спреда.
+//
+// Возвращает:
+//   - decimal.Decimal: количество единиц Quote, зачисляемое за одну единицу Base
+func (r *FXRate) Applied() decimal.Decimal {
+	return r.Rate.Mul(decimal.NewFromInt(1).Sub(r.Spread))
+}
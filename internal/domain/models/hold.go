@@ -0,0 +1,40 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"time"
+)
+
+// HoldStatus описывает состояние холда.
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"   // Средства зарезервированы
+	HoldStatusCaptured HoldStatus = "captured" // Холд списан (полностью или частично)
+	HoldStatusVoided   HoldStatus = "voided"   // Холд отменен, резерв снят
+	HoldStatusExpired  HoldStatus = "expired"  // Срок холда истек, резерв снят
+)
+
+// Hold представляет резервирование средств на кошельке отправителя (авторизацию)
+// до подтверждения перевода получателю.
+//
+// Пока холд активен, Reserved входит в Wallet.Held кошелька From и уменьшает доступный
+// баланс, не меняя баланс и журнал проводок. Списание (capture) переводит получателю
+// не больше Amount и снимает весь резерв; отмена (void) и истечение срока снимают резерв
+// без перевода. Reserved включает комиссию, рассчитанную при создании холда.
+type Hold struct {
+	gorm.Model
+	PublicID       string          `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	From           string          `gorm:"type:string;not null"`
+	To             string          `gorm:"type:string;not null"`
+	Amount         decimal.Decimal `gorm:"type:numeric(20,8);not null"` // Максимальная сумма списания
+	Reserved       decimal.Decimal `gorm:"type:numeric(20,8);not null"` // Зарезервировано на кошельке
+	Currency       string          `gorm:"type:string;not null"`
+	CapturedAmount decimal.Decimal `gorm:"type:numeric(20,8);not null;default:0"`
+	Status         HoldStatus      `gorm:"type:string;not null;default:active"`
+	ExpiresAt      time.Time       `gorm:"not null"`
+	TransactionID  *string         `gorm:"type:uuid"` // Публичный идентификатор транзакции списания
+}
@@ -0,0 +1,23 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey представляет сохраненный результат запроса с ключом идемпотентности.
+// Запись создается в той же транзакции БД, что и Transaction, поэтому
+// результат сохраняется тогда и только тогда, когда перевод был выполнен.
+// Ключ уникален в пределах учетной записи вызывающего (AccountID; nil - привилегированный
+// клиент без учетной записи), поэтому одинаковые ключи разных учетных записей не конфликтуют.
+// Наследует базовые поля gorm.Model (ID, CreatedAt, UpdatedAt, DeletedAt).
+type IdempotencyKey struct {
+	gorm.Model
+	AccountID     *string `gorm:"type:uuid"`
+	Key           string  `gorm:"type:string;not null"`
+	RequestHash   string  `gorm:"type:string;not null"` // SHA-256 от параметров запроса
+	StatusCode    int     `gorm:"not null"`             // HTTP-код первого ответа
+	ResponseBody  []byte  `gorm:"type:jsonb;not null"`  // Тело первого ответа
+	TransactionID uint    `gorm:"index;not null"`       // Транзакция, созданная первым запросом
+}
@@ -0,0 +1,61 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// LedgerEntryType задает сторону проводки в журнале двойной записи.
+type LedgerEntryType string
+
+const (
+	LedgerEntryDebit  LedgerEntryType = "debit"  // Списание со счета
+	LedgerEntryCredit LedgerEntryType = "credit" // Зачисление на счет
+)
+
+// IssuanceAccount возвращает системный счет, с которого выпускаются начальные балансы
+// кошельков в указанной валюте. Не является кошельком: его баланс отрицателен
+// и равен сумме всех выпущенных в валюте средств.
+//
+// Параметры:
+//   - currency: код валюты
+//
+// Возвращает:
+//   - string: идентификатор системного счета
+func IssuanceAccount(currency string) string {
+	return "system:issuance:" + currency
+}
+
+// ExchangeAccount возвращает системный счет обменного пункта в указанной валюте.
+// При конвертации сумма списания зачисляется на счет валюты отправителя,
+// а сумма зачисления списывается со счета валюты получателя, поэтому проводки
+// сбалансированы внутри каждой валюты. Баланс счета показывает открытую
+// валютную позицию (включая доход от спреда).
+//
+// Параметры:
+//   - currency: код валюты
+//
+// Возвращает:
+//   - string: идентификатор системного счета
+func ExchangeAccount(currency string) string {
+	return "system:fx:" + currency
+}
+
+// LedgerEntry представляет проводку в журнале двойной записи.
+// Каждая операция с деньгами записывает сбалансированный набор проводок:
+// сумма зачислений равна сумме списаний. Баланс кошелька (Wallet.Balance)
+// является кэшем, который должен совпадать с суммой проводок по его адресу.
+//
+// Проводки неизменяемы, поэтому модель не использует gorm.Model
+// (UpdatedAt и мягкое удаление для журнала не имеют смысла).
+type LedgerEntry struct {
+	ID            uint             `gorm:"primarykey"`
+	CreatedAt     time.Time        `gorm:"not null"`
+	TransactionID *uint            `gorm:"index"`                       // Транзакция-основание (nil для начального баланса)
+	Account       string           `gorm:"type:string;index;not null"`  // Адрес кошелька или системный счет
+	Type          LedgerEntryType  `gorm:"type:string;not null"`        // Сторона проводки
+	Amount        decimal.Decimal  `gorm:"type:numeric(20,8);not null"` // Сумма проводки (всегда положительна)
+	BalanceAfter  *decimal.Decimal `gorm:"type:numeric(20,8)"`          // Баланс кошелька после проводки (nil для системных счетов)
+}
@@ -0,0 +1,178 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"time"
+)
+
+// LimitPeriod описывает вид лимита расходов кошелька.
+type LimitPeriod string
+
+const (
+	LimitPeriodSingle  LimitPeriod = "single"  // Максимальная сумма одного перевода
+	LimitPeriodDaily   LimitPeriod = "daily"   // Расход за календарные сутки (UTC)
+	LimitPeriodWeekly  LimitPeriod = "weekly"  // Расход за календарную неделю с понедельника (UTC)
+	LimitPeriodMonthly LimitPeriod = "monthly" // Расход за календарный месяц (UTC)
+)
+
+// SpendingLimits представляет лимиты расходов кошелька в его валюте.
+// Лимит nil не ограничивает расход. Расходом считается сумма исходящих переводов
+// (Amount, без комиссии); возвраты в расход не входят.
+type SpendingLimits struct {
+	MaxSingle *decimal.Decimal // Максимальная сумма одного перевода
+	Daily     *decimal.Decimal // Лимит расхода за сутки
+	Weekly    *decimal.Decimal // Лимит расхода за неделю
+	Monthly   *decimal.Decimal // Лимит расхода за месяц
+}
+
+// Empty сообщает, что ни один лимит не задан.
+func (l SpendingLimits) Empty() bool {
+	return l.MaxSingle == nil && l.Daily == nil && l.Weekly == nil && l.Monthly == nil
+}
+
+// Override возвращает лимиты, в которых заданные в override значения заменяют текущие.
+func (l SpendingLimits) Override(override SpendingLimits) SpendingLimits {
+	if override.MaxSingle != nil {
+		l.MaxSingle = override.MaxSingle
+	}
+	if override.Daily != nil {
+		l.Daily = override.Daily
+	}
+	if override.Weekly != nil {
+		l.Weekly = override.Weekly
+	}
+	if override.Monthly != nil {
+		l.Monthly = override.Monthly
+	}
+	return l
+}
+
+// WalletLimit представляет индивидуальные настройки лимитов кошелька:
+// уровень (Tier, пустая строка - уровень по умолчанию) и значения, заменяющие лимиты уровня.
+// Кошелек без записи использует лимиты уровня по умолчанию.
+type WalletLimit struct {
+	gorm.Model
+	WalletID  uint             `gorm:"not null;uniqueIndex"`
+	Tier      string           `gorm:"type:string;not null;default:''"`
+	MaxSingle *decimal.Decimal `gorm:"type:numeric(20,8)"`
+	Daily     *decimal.Decimal `gorm:"type:numeric(20,8)"`
+	Weekly    *decimal.Decimal `gorm:"type:numeric(20,8)"`
+	Monthly   *decimal.Decimal `gorm:"type:numeric(20,8)"`
+}
+
+// Limits возвращает индивидуальные значения лимитов кошелька.
+func (l *WalletLimit) Limits() SpendingLimits {
+	return SpendingLimits{MaxSingle: l.MaxSingle, Daily: l.Daily, Weekly: l.Weekly, Monthly: l.Monthly}
+}
+
+// LimitWindows содержит начала текущих периодов лимитов (UTC).
+type LimitWindows struct {
+	Day   time.Time
+	Week  time.Time
+	Month time.Time
+}
+
+// NewLimitWindows возвращает начала суток, недели (с понедельника) и месяца, содержащих момент at.
+func NewLimitWindows(at time.Time) LimitWindows {
+	at = at.UTC()
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	// Неделя начинается с понедельника: воскресенье (0) - седьмой день недели
+	weekday := (int(day.Weekday()) + 6) % 7
+
+	return LimitWindows{
+		Day:   day,
+		Week:  day.AddDate(0, 0, -weekday),
+		Month: time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// Earliest возвращает самое раннее начало периода: неделя может начаться в прошлом месяце.
+func (w LimitWindows) Earliest() time.Time {
+	if w.Week.Before(w.Month) {
+		return w.Week
+	}
+	return w.Month
+}
+
+// Reset возвращает момент окончания текущего периода (nil для лимита одного перевода).
+func (w LimitWindows) Reset(period LimitPeriod) *time.Time {
+	var reset time.Time
+	switch period {
+	case LimitPeriodDaily:
+		reset = w.Day.AddDate(0, 0, 1)
+	case LimitPeriodWeekly:
+		reset = w.Week.AddDate(0, 0, 7)
+	case LimitPeriodMonthly:
+		reset = w.Month.AddDate(0, 1, 0)
+	default:
+		return nil
+	}
+	return &reset
+}
+
+// LimitUsage представляет расход кошелька за текущие периоды лимитов.
+type LimitUsage struct {
+	Daily   decimal.Decimal
+	Weekly  decimal.Decimal
+	Monthly decimal.Decimal
+}
+
+// LimitHeadroom описывает один лимит кошелька: значение, расход за текущий период и остаток.
+// Для лимита одного перевода Used равен нулю, а ResetsAt - nil.
+type LimitHeadroom struct {
+	Period    LimitPeriod
+	Limit     decimal.Decimal
+	Used      decimal.Decimal
+	Remaining decimal.Decimal
+	ResetsAt  *time.Time
+}
+
+// Headroom возвращает заданные лимиты с остатками при расходе usage.
+// Остаток не бывает отрицательным (лимит могли уменьшить после переводов).
+//
+// Параметры:
+//   - usage: расход за текущие периоды
+//   - windows: текущие периоды
+//
+// Возвращает:
+//   - []LimitHeadroom: лимиты в порядке single, daily, weekly, monthly (только заданные)
+func (l SpendingLimits) Headroom(usage LimitUsage, windows LimitWindows) []LimitHeadroom {
+	periods := []struct {
+		period LimitPeriod
+		limit  *decimal.Decimal
+		used   decimal.Decimal
+	}{
+		{LimitPeriodSingle, l.MaxSingle, decimal.Zero},
+		{LimitPeriodDaily, l.Daily, usage.Daily},
+		{LimitPeriodWeekly, l.Weekly, usage.Weekly},
+		{LimitPeriodMonthly, l.Monthly, usage.Monthly},
+	}
+
+	var headroom []LimitHeadroom
+	for _, p := range periods {
+		if p.limit == nil {
+			continue
+		}
+		headroom = append(headroom, LimitHeadroom{
+			Period:    p.period,
+			Limit:     *p.limit,
+			Used:      p.used,
+			Remaining: decimal.Max(p.limit.Sub(p.used), decimal.Zero),
+			ResetsAt:  windows.Reset(p.period),
+		})
+	}
+	return headroom
+}
+
+// LimitStatus представляет лимиты кошелька и их использование на момент At.
+type LimitStatus struct {
+	Wallet  *Wallet
+	Tier    string
+	Limits  SpendingLimits
+	Usage   LimitUsage
+	Windows LimitWindows
+	At      time.Time
+}
@@ -0,0 +1,67 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"encoding/json"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"time"
+)
+
+// EventTransferCompleted - тип события о зафиксированном переводе.
+const EventTransferCompleted = "TransferCompleted"
+
+// OutboxEvent представляет событие в транзакционном outbox.
+// Событие записывается в той же транзакции БД, что и изменение, о котором оно сообщает,
+// поэтому публикуется тогда и только тогда, когда изменение зафиксировано.
+//
+// ID задает порядок публикации. События с общим ключом упорядочивания (OrderingKeys,
+// например адрес кошелька) публикуются строго в порядке ID; остальные могут обгонять
+// друг друга, если публикация одного из них откладывается после ошибки.
+// PublishSeq - фактический порядок публикации: номера видны читателям по возрастанию,
+// поэтому по ним можно без пропусков продолжать чтение опубликованных событий.
+type OutboxEvent struct {
+	gorm.Model
+	EventType     string          `gorm:"type:string;not null"`
+	OrderingKeys  []string        `gorm:"type:jsonb;serializer:json;not null"`
+	Payload       json.RawMessage `gorm:"type:jsonb;not null"`
+	PublishedAt   *time.Time      `gorm:""`
+	PublishSeq    *int64          `gorm:""`                   // Порядковый номер публикации
+	Attempts      int             `gorm:"not null;default:0"` // Число неудачных попыток публикации
+	NextAttemptAt *time.Time      `gorm:""`                   // Время следующей попытки после ошибки
+	LastError     *string         `gorm:"type:text"`
+}
+
+// TransferCompleted - данные события EventTransferCompleted.
+// Amount списано с From в валюте Currency, TargetAmount зачислено To в валюте TargetCurrency.
+type TransferCompleted struct {
+	TransactionID  string          `json:"transaction_id"`
+	From           string          `json:"from"`
+	To             string          `json:"to"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+	TargetAmount   decimal.Decimal `json:"target_amount"`
+	TargetCurrency string          `json:"target_currency"`
+	Fee            decimal.Decimal `json:"fee"`
+	BatchID        *string         `json:"batch_id,omitempty"`
+	RefundOf       *string         `json:"refund_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// NewTransferCompleted формирует данные события о переводе из транзакции.
+func NewTransferCompleted(transaction *Transaction) TransferCompleted {
+	return TransferCompleted{
+		TransactionID:  transaction.PublicID,
+		From:           transaction.From,
+		To:             transaction.To,
+		Amount:         transaction.Amount,
+		Currency:       transaction.Currency,
+		TargetAmount:   transaction.TargetAmount,
+		TargetCurrency: transaction.TargetCurrency,
+		Fee:            transaction.Fee,
+		BatchID:        transaction.BatchID,
+		RefundOf:       transaction.RefundOf,
+		CreatedAt:      transaction.CreatedAt,
+	}
+}
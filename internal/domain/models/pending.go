@@ -0,0 +1,128 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ApprovalThresholdRule - имя правила, которым откладывается перевод сверх порога согласования кошелька.
+const ApprovalThresholdRule = "approval_threshold"
+
+// PendingTransferStatus описывает состояние отложенного перевода.
+type PendingTransferStatus string
+
+const (
+	PendingTransferPending  PendingTransferStatus = "pending"  // Перевод ожидает решения
+	PendingTransferExecuted PendingTransferStatus = "executed" // Перевод одобрен и выполнен
+	PendingTransferRejected PendingTransferStatus = "rejected" // Перевод отклонен, резерв снят
+)
+
+// PendingTransferStatuses - все статусы отложенных переводов.
+var PendingTransferStatuses = []PendingTransferStatus{
+	PendingTransferPending, PendingTransferExecuted, PendingTransferRejected,
+}
+
+// PendingTransfer представляет перевод, отложенный до согласования: правилом проверки
+// (решение review) или порогом согласования кошелька отправителя.
+// Сохраняются параметры запроса в том виде, в каком они подписаны (одна из сумм Amount
+// и TargetAmount равна нулю, Nonce и Signature - nil для неподписанного перевода),
+// валюты кошельков и расчетная сумма списания Debit (с комиссией) на момент запроса.
+// Rule и Reason - сработавшее правило. AccountID и KeyID - учетная запись и API-ключ
+// инициатора перевода (nil для администратора без ключа и планировщика).
+// IdempotencyKey и RequestHash позволяют повторить запрос без создания второго перевода;
+// ключ уникален в пределах учетной записи AccountID.
+//
+// Пока перевод ожидает решения, Reserved (Debit на момент запроса) входит в Wallet.Held
+// кошелька From (как резерв холда), а номер подписанного перевода уже израсходован. RequiredApprovals и Approvers -
+// политика согласования кошелька на момент запроса: перевод выполняется, когда его одобрят
+// RequiredApprovals учетных записей из Approvers (пустой список - любые учетные записи
+// с разрешением transfers:approve), и отклоняется первым отказом. TransactionID - транзакция
+// выполненного перевода.
+type PendingTransfer struct {
+	gorm.Model
+	PublicID          string                `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	Status            PendingTransferStatus `gorm:"type:string;not null;default:pending"`
+	From              string                `gorm:"type:string;not null"`
+	To                string                `gorm:"type:string;not null"`
+	Amount            decimal.Decimal       `gorm:"type:numeric(20,8);not null;default:0"`
+	TargetAmount      decimal.Decimal       `gorm:"type:numeric(20,8);not null;default:0"`
+	Currency          string                `gorm:"type:string;not null"`
+	TargetCurrency    string                `gorm:"type:string;not null"`
+	Debit             decimal.Decimal       `gorm:"type:numeric(20,8);not null"`
+	Reserved          decimal.Decimal       `gorm:"type:numeric(20,8);not null;default:0"` // Зарезервировано на кошельке
+	Nonce             *int64                `gorm:"type:bigint"`
+	Signature         *string               `gorm:"type:string"`
+	Rule              string                `gorm:"type:string;not null"`
+	Reason            string                `gorm:"type:text;not null"`
+	AccountID         *string               `gorm:"type:uuid"`
+	KeyID             *string               `gorm:"type:uuid"`
+	IdempotencyKey    *string               `gorm:"type:string"`
+	RequestHash       *string               `gorm:"type:string"`
+	RequiredApprovals int                   `gorm:"not null;default:1"`
+	Approvers         []string              `gorm:"type:jsonb;serializer:json;not null"`
+	TransactionID     *string               `gorm:"type:uuid"` // Публичный идентификатор транзакции перевода
+	Decisions         []PendingTransferDecision
+}
+
+// Approvals возвращает число одобрений перевода.
+func (t *PendingTransfer) Approvals() int {
+	approvals := 0
+	for i := range t.Decisions {
+		if t.Decisions[i].Approved {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+// CanDecide сообщает, может ли учетная запись accountID принимать решение по переводу.
+func (t *PendingTransfer) CanDecide(accountID string) bool {
+	if len(t.Approvers) == 0 {
+		return true
+	}
+	for _, approver := range t.Approvers {
+		if approver == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// PendingTransferDecision представляет решение согласующего по отложенному переводу:
+// одобрение (Approved) или отказ. Учетная запись принимает по переводу одно решение.
+type PendingTransferDecision struct {
+	ID                uint      `gorm:"primarykey"`
+	CreatedAt         time.Time `gorm:"not null"`
+	PendingTransferID uint      `gorm:"not null;uniqueIndex:idx_pending_transfer_decisions_account"`
+	AccountID         string    `gorm:"type:uuid;not null;uniqueIndex:idx_pending_transfer_decisions_account"`
+	KeyID             *string   `gorm:"type:uuid"`
+	Approved          bool      `gorm:"not null"`
+	Comment           string    `gorm:"type:text;not null;default:''"`
+}
+
+// ApprovalPolicy представляет политику согласования переводов с кошелька (N из M):
+// перевод с суммой списания (Amount, без комиссии) больше Threshold откладывается до одобрения
+// RequiredApprovals согласующими из Approvers (публичные идентификаторы учетных записей;
+// пустой список - любые учетные записи с разрешением transfers:approve).
+// Threshold nil - переводы откладываются только правилами проверки. Кошелек без записи
+// использует политику по умолчанию: без порога, одно одобрение.
+type ApprovalPolicy struct {
+	gorm.Model
+	WalletID          uint             `gorm:"not null;uniqueIndex"`
+	Threshold         *decimal.Decimal `gorm:"type:numeric(20,8)"`
+	RequiredApprovals int              `gorm:"not null;default:1"`
+	Approvers         []string         `gorm:"type:jsonb;serializer:json;not null"`
+}
+
+// DefaultApprovalPolicy возвращает политику согласования кошелька без записи.
+func DefaultApprovalPolicy() *ApprovalPolicy {
+	return &ApprovalPolicy{RequiredApprovals: 1, Approvers: []string{}}
+}
+
+// RequiresApproval сообщает, превышает ли сумма списания amount порог согласования.
+func (p *ApprovalPolicy) RequiresApproval(amount decimal.Decimal) bool {
+	return p.Threshold != nil && amount.GreaterThan(*p.Threshold)
+}
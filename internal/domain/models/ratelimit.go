@@ -0,0 +1,82 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit - ограничение частоты запросов корзиной токенов (token bucket).
+// Корзина вмещает Burst токенов и пополняется на Requests токенов за Period;
+// каждый запрос забирает один токен, запрос при пустой корзине отклоняется.
+type RateLimit struct {
+	Requests int           // Число запросов за период (скорость пополнения корзины)
+	Period   time.Duration // Период пополнения
+	Burst    int           // Емкость корзины: число запросов, которые можно выполнить подряд
+}
+
+// Enabled сообщает, задано ли ограничение.
+func (l RateLimit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0 && l.Burst > 0
+}
+
+// Take забирает токен из корзины, предварительно пополнив ее за время с последнего запроса.
+// Корзина изменяется и в случае отказа, чтобы сохранить время пересчета.
+//
+// Параметры:
+//   - bucket: состояние корзины (пустое значение - новая, заполненная корзина)
+//   - now: текущее время
+//
+// Возвращает:
+//   - RateLimitDecision: решение и остаток корзины
+func (l RateLimit) Take(bucket *RateLimitBucket, now time.Time) RateLimitDecision {
+	rate := float64(l.Requests) / l.Period.Seconds() // токенов в секунду
+
+	tokens := float64(l.Burst)
+	if !bucket.CheckedAt.IsZero() {
+		elapsed := max(now.Sub(bucket.CheckedAt).Seconds(), 0)
+		tokens = math.Min(tokens, bucket.Tokens+elapsed*rate)
+	}
+
+	decision := RateLimitDecision{Limit: l.Burst}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = refillTime(1-tokens, rate)
+	}
+
+	bucket.Tokens = tokens
+	bucket.CheckedAt = now
+	bucket.FullAt = now.Add(refillTime(float64(l.Burst)-tokens, rate))
+
+	decision.Remaining = int(math.Floor(tokens))
+	decision.Reset = bucket.FullAt.Sub(now)
+
+	return decision
+}
+
+// refillTime возвращает время, за которое корзина пополнится на tokens токенов.
+func refillTime(tokens, rate float64) time.Duration {
+	return time.Duration(math.Ceil(tokens / rate * float64(time.Second)))
+}
+
+// RateLimitBucket представляет корзину токенов одного ключа ограничения
+// (API-ключа, адреса клиента или кошелька отправителя).
+// Заполненная корзина (FullAt в прошлом) равносильна отсутствующей, поэтому такие корзины удаляются.
+type RateLimitBucket struct {
+	Key       string    `gorm:"type:text;primaryKey"`
+	Tokens    float64   `gorm:"not null"`       // Число токенов на момент CheckedAt
+	CheckedAt time.Time `gorm:"not null"`       // Время последнего пересчета корзины
+	FullAt    time.Time `gorm:"index;not null"` // Время, к которому корзина заполнится
+}
+
+// RateLimitDecision - решение ограничения частоты по запросу.
+type RateLimitDecision struct {
+	Allowed    bool          // Запрос разрешен
+	Limit      int           // Емкость корзины
+	Remaining  int           // Число запросов, которые можно выполнить сразу
+	Reset      time.Duration // Время до полного пополнения корзины
+	RetryAfter time.Duration // Время до следующего разрешенного запроса (только при отказе)
+}
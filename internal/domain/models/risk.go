@@ -0,0 +1,64 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// RiskOutcome описывает решение правила проверки перевода.
+type RiskOutcome string
+
+const (
+	RiskOutcomeAllow  RiskOutcome = "allow"  // Перевод выполняется
+	RiskOutcomeDeny   RiskOutcome = "deny"   // Перевод отклоняется
+	RiskOutcomeReview RiskOutcome = "review" // Перевод откладывается в очередь на проверку
+)
+
+// RiskFacts содержит данные перевода, по которым проверяются условия правил.
+// Amount транзакции - сумма в валюте отправителя.
+type RiskFacts struct {
+	Transaction *Transaction
+	Sender      *Wallet
+	Receiver    *Wallet
+	Now         time.Time
+	// Outgoing возвращает число и сумму (в валюте отправителя) исходящих переводов отправителя
+	// без возвратов, созданных начиная с since. Результат по одному since запоминается.
+	Outgoing func(since time.Time) (int64, decimal.Decimal, error)
+}
+
+// RiskCondition - условие правила проверки перевода. Условия разных видов
+// (сумма, возраст кошелька, частота переводов и другие) подключаются в источнике правил.
+type RiskCondition interface {
+	// Match сообщает, выполняется ли условие для перевода.
+	Match(facts *RiskFacts) (bool, error)
+}
+
+// RiskRule представляет правило проверки перевода: если выполняются все условия,
+// перевод получает решение Outcome с причиной Reason.
+type RiskRule struct {
+	Name       string
+	Outcome    RiskOutcome
+	Reason     string
+	Conditions []RiskCondition
+}
+
+// Match сообщает, выполняются ли все условия правила.
+func (r *RiskRule) Match(facts *RiskFacts) (bool, error) {
+	for _, condition := range r.Conditions {
+		matched, err := condition.Match(facts)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// RiskDecision представляет результат проверки перевода правилами.
+// Rule и Reason пусты, если ни одно правило не сработало (перевод разрешен).
+type RiskDecision struct {
+	Outcome RiskOutcome
+	Rule    string
+	Reason  string
+}
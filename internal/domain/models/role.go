@@ -0,0 +1,46 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+// Permission - разрешение на группу операций API. Маршрут API требует одно разрешение,
+// роль субъекта запроса (viewer, operator, auditor, admin и другие из конфигурации)
+// определяет набор разрешений.
+type Permission string
+
+const (
+	PermissionWalletsRead      Permission = "wallets:read"      // Чтение кошельков, транзакций, холдов, расписаний и подписок
+	PermissionWalletsCreate    Permission = "wallets:create"    // Создание кошелька
+	PermissionWalletsMint      Permission = "wallets:mint"      // Выпуск средств: начальный баланс кошелька
+	PermissionWalletsClose     Permission = "wallets:close"     // Закрытие кошелька
+	PermissionWalletsFreeze    Permission = "wallets:freeze"    // Заморозка и разморозка кошелька
+	PermissionLimitsManage     Permission = "limits:manage"     // Назначение уровня и лимитов расходов кошелька
+	PermissionTransfersCreate  Permission = "transfers:create"  // Переводы, возвраты, холды и запланированные переводы
+	PermissionTransfersApprove Permission = "transfers:approve" // Очередь отложенных переводов, одобрение и отклонение
+	PermissionApprovalsManage  Permission = "approvals:manage"  // Политики согласования переводов кошельков
+	PermissionWebhooksManage   Permission = "webhooks:manage"   // Подписки на вебхуки и повторная доставка
+	PermissionKeysManage       Permission = "keys:manage"       // API-ключи своей учетной записи
+	PermissionAccountsManage   Permission = "accounts:manage"   // Создание учетных записей и назначение ролей
+	PermissionLedgerVerify     Permission = "ledger:verify"     // Проверка журнала двойной записи
+	PermissionAuditRead        Permission = "audit:read"        // Чтение журнала аудита
+)
+
+// PermissionAll - разрешение-шаблон в конфигурации роли: роль получает все разрешения.
+const PermissionAll Permission = "*"
+
+// Permissions содержит все разрешения, которые можно назначить роли.
+var Permissions = []Permission{
+	PermissionWalletsRead,
+	PermissionWalletsCreate,
+	PermissionWalletsMint,
+	PermissionWalletsClose,
+	PermissionWalletsFreeze,
+	PermissionLimitsManage,
+	PermissionTransfersCreate,
+	PermissionTransfersApprove,
+	PermissionApprovalsManage,
+	PermissionWebhooksManage,
+	PermissionKeysManage,
+	PermissionAccountsManage,
+	PermissionLedgerVerify,
+	PermissionAuditRead,
+}
@@ -0,0 +1,71 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ScheduledTransferStatus описывает состояние запланированного перевода.
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferActive    ScheduledTransferStatus = "active"    // Перевод выполняется по расписанию
+	ScheduledTransferPaused    ScheduledTransferStatus = "paused"    // Выполнение приостановлено
+	ScheduledTransferCompleted ScheduledTransferStatus = "completed" // Расписание исчерпано
+	ScheduledTransferFailed    ScheduledTransferStatus = "failed"    // Выполнение остановлено из-за ошибки
+	ScheduledTransferCancelled ScheduledTransferStatus = "cancelled" // Перевод отменен
+)
+
+// ScheduledTransfer представляет запланированный (разовый или регулярный) перевод.
+//
+// OccurrenceAt - плановое время текущего выполнения, NextRunAt - время следующей
+// попытки (после неудачной попытки сдвигается на время задержки). Регулярный перевод
+// повторяется каждые IntervalSeconds секунд до EndAt; при IntervalSeconds = 0 перевод разовый.
+// Attempts - число неудачных попыток текущего выполнения.
+//
+// ClaimToken и ClaimedUntil - аренда строки планировщиком: пока аренда не истекла,
+// другие экземпляры приложения перевод не выполняют.
+type ScheduledTransfer struct {
+	gorm.Model
+	PublicID        string                  `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	From            string                  `gorm:"type:string;not null"`
+	To              string                  `gorm:"type:string;not null"`
+	Amount          decimal.Decimal         `gorm:"type:numeric(20,8);not null"`
+	IntervalSeconds int64                   `gorm:"not null;default:0"`
+	EndAt           *time.Time              `gorm:""`
+	Status          ScheduledTransferStatus `gorm:"type:string;not null;default:active"`
+	OccurrenceAt    time.Time               `gorm:"not null"`
+	NextRunAt       time.Time               `gorm:"not null"`
+	Attempts        int                     `gorm:"not null;default:0"`
+	ClaimToken      *string                 `gorm:"type:uuid"`
+	ClaimedUntil    *time.Time              `gorm:""`
+}
+
+// Interval возвращает период регулярного перевода (ноль для разового).
+func (s *ScheduledTransfer) Interval() time.Duration {
+	return time.Duration(s.IntervalSeconds) * time.Second
+}
+
+// ScheduledTransferRunStatus описывает результат попытки выполнения запланированного перевода.
+type ScheduledTransferRunStatus string
+
+const (
+	ScheduledTransferRunSucceeded ScheduledTransferRunStatus = "succeeded" // Перевод выполнен
+	ScheduledTransferRunFailed    ScheduledTransferRunStatus = "failed"    // Перевод не выполнен
+	ScheduledTransferRunParked    ScheduledTransferRunStatus = "parked"    // Перевод отложен правилом проверки
+)
+
+// ScheduledTransferRun представляет попытку выполнения запланированного перевода.
+type ScheduledTransferRun struct {
+	gorm.Model
+	ScheduledTransferID uint                       `gorm:"not null;index"`
+	OccurrenceAt        time.Time                  `gorm:"not null"`
+	Attempt             int                        `gorm:"not null"`
+	Status              ScheduledTransferRunStatus `gorm:"type:string;not null"`
+	TransactionID       *string                    `gorm:"type:uuid"` // Публичный идентификатор созданной транзакции
+	PendingTransferID   *string                    `gorm:"type:uuid"` // Публичный идентификатор отложенного перевода
+	Error               *string                    `gorm:"type:text"`
+}
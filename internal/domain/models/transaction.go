@@ -10,9 +10,61 @@ import (
 // Transaction представляет модель транзакции между кошельками в системе.
 // Содержит информацию об отправителе, получателе и сумме перевода.
 // Реализует gorm.Model для базовых полей (ID, CreatedAt, UpdatedAt, DeletedAt).
+// PublicID - стабильный внешний идентификатор (UUID), который получает клиент;
+// внутренний ID из gorm.Model наружу не передается.
+//
+// Amount списывается с отправителя в валюте Currency, TargetAmount зачисляется
+// получателю в валюте TargetCurrency. Для перевода между кошельками в одной валюте
+// суммы совпадают, Rate равен 1, Spread - 0. Для конвертации сохраняются примененный
+// курс (за вычетом спреда) и спред, чтобы обмен можно было проверить.
+//
+// Fee - комиссия в валюте Currency, списываемая с отправителя сверх Amount
+// и зачисляемая на кошелек-сборщик FeeWallet (nil, если комиссии нет).
+//
+// Возврат - транзакция в обратном направлении, у которой RefundOf содержит PublicID
+// исходной транзакции. RefundedAmount исходной транзакции - сумма, уже возвращенная
+// отправителю в валюте Currency; она не может превысить Amount. Комиссия не возвращается.
+//
+// BatchID связывает транзакции, выполненные одним атомарным пакетом (nil для одиночного перевода).
+//
+// Nonce и Signature - номер и подпись перевода с кошелька, имеющего открытый ключ
+// (nil для неподписанных переводов). Подпись проверяется открытым ключом отправителя.
 type Transaction struct {
 	gorm.Model
-	From   string          `gorm:"type:string;not null"`
-	To     string          `gorm:"type:string;not null"`
-	Amount decimal.Decimal `gorm:"type:numeric(20,8);not null"`
+	PublicID       string          `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	From           string          `gorm:"type:string;not null"`
+	To             string          `gorm:"type:string;not null"`
+	Amount         decimal.Decimal `gorm:"type:numeric(20,8);not null"`
+	Currency       string          `gorm:"type:string;not null;default:RUB"`
+	TargetAmount   decimal.Decimal `gorm:"type:numeric(20,8);not null"`
+	TargetCurrency string          `gorm:"type:string;not null"`
+	Rate           decimal.Decimal `gorm:"type:numeric(30,16);not null"`
+	Spread         decimal.Decimal `gorm:"type:numeric(10,8);not null"`
+	Fee            decimal.Decimal `gorm:"type:numeric(20,8);not null;default:0"`
+	FeeWallet      *string         `gorm:"type:string"`
+	RefundOf       *string         `gorm:"type:uuid"`
+	RefundedAmount decimal.Decimal `gorm:"type:numeric(20,8);not null;default:0"`
+	BatchID        *string         `gorm:"type:uuid"`
+	Nonce          *int64          `gorm:"type:bigint"`
+	Signature      *string         `gorm:"type:string"`
+}
+
+// Debited возвращает полную сумму списания с отправителя: сумма перевода и комиссия.
+func (t *Transaction) Debited() decimal.Decimal {
+	return t.Amount.Add(t.Fee)
+}
+
+// IsExchange сообщает, выполняется ли в транзакции конвертация валют.
+func (t *Transaction) IsExchange() bool {
+	return t.Currency != t.TargetCurrency
+}
+
+// IsRefund сообщает, является ли транзакция возвратом другой транзакции.
+func (t *Transaction) IsRefund() bool {
+	return t.RefundOf != nil
+}
+
+// Refundable возвращает сумму, которую еще можно вернуть отправителю, в валюте Currency.
+func (t *Transaction) Refundable() decimal.Decimal {
+	return t.Amount.Sub(t.RefundedAmount)
 }
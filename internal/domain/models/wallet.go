@@ -7,12 +7,76 @@ import (
 	"gorm.io/gorm"
 )
 
+// WalletStatus описывает состояние жизненного цикла кошелька.
+// Замороженный кошелек не удаляется: блокируются только переводы в одном или обоих направлениях.
+type WalletStatus string
+
+const (
+	WalletStatusActive    WalletStatus = "active"     // Кошелек доступен для переводов
+	WalletStatusFrozenIn  WalletStatus = "frozen_in"  // Зачисления на кошелек заблокированы
+	WalletStatusFrozenOut WalletStatus = "frozen_out" // Списания с кошелька заблокированы
+	WalletStatusFrozen    WalletStatus = "frozen"     // Зачисления и списания заблокированы
+	WalletStatusClosed    WalletStatus = "closed"     // Кошелек закрыт (мягко удален)
+)
+
+// CanSend сообщает, разрешены ли списания с кошелька в этом статусе.
+func (s WalletStatus) CanSend() bool {
+	return s == WalletStatusActive || s == WalletStatusFrozenIn
+}
+
+// CanReceive сообщает, разрешены ли зачисления на кошелек в этом статусе.
+func (s WalletStatus) CanReceive() bool {
+	return s == WalletStatusActive || s == WalletStatusFrozenOut
+}
+
+// Frozen сообщает, заблокированы ли переводы кошелька хотя бы в одном направлении.
+func (s WalletStatus) Frozen() bool {
+	return s == WalletStatusFrozenIn || s == WalletStatusFrozenOut || s == WalletStatusFrozen
+}
+
+// WalletStatusChange представляет запись истории статусов кошелька.
+// Каждое изменение статуса администратором сохраняется с обязательной причиной.
+// AccountID и KeyID - публичные идентификаторы учетной записи и API-ключа, изменивших статус
+// (nil для администратора без ключа).
+type WalletStatusChange struct {
+	gorm.Model
+	WalletID   uint         `gorm:"not null;index"`
+	FromStatus WalletStatus `gorm:"type:string;not null"`
+	ToStatus   WalletStatus `gorm:"type:string;not null"`
+	Reason     string       `gorm:"type:text;not null"`
+	AccountID  *string      `gorm:"type:uuid"`
+	KeyID      *string      `gorm:"type:uuid"`
+}
+
 // Wallet представляет модель кошелька в системе.
-// Содержит уникальный адрес и текущий баланс.
+// Содержит уникальный адрес, текущий баланс, валюту и статус.
+// Валюта задается при создании и не меняется: переводы в другую валюту конвертируются по курсу.
 // Наследует базовые поля gorm.Model (ID, CreatedAt, UpdatedAt, DeletedAt).
+// Закрытие кошелька выполняется через мягкое удаление (DeletedAt).
+// Status блокирует переводы замороженного кошелька (см. WalletStatus.CanSend и CanReceive).
+// Balance - кэшированная проекция журнала проводок (LedgerEntry): изменяется
+// только вместе с записью проводок и сверяется с ними проверкой согласованности.
+// Held - сумма, зарезервированная активными холдами (Hold): входит в Balance,
+// но недоступна для переводов. Журнал проводок холды не затрагивают.
+// OwnerID - учетная запись владельца (Account): только владелец может переводить
+// средства с кошелька. Кошельки без владельца доступны только администратору.
+// PublicKey - открытый ключ Ed25519 в hex-формате (nil у кошельков без ключа): адрес
+// такого кошелька - SHA-256 ключа, а переводы с него подписываются закрытым ключом.
+// Nonce - номер последнего подписанного перевода: следующий должен быть больше.
 // Используется для хранения информации о пользовательских кошельках и их балансах.
 type Wallet struct {
 	gorm.Model
-	Address string          `gorm:"type:string;uniqueIndex;not null"`
-	Balance decimal.Decimal `gorm:"type:numeric(20,8);not null;default:0"`
+	Address   string          `gorm:"type:string;uniqueIndex;not null"`
+	Balance   decimal.Decimal `gorm:"type:numeric(20,8);not null;default:0"`
+	Held      decimal.Decimal `gorm:"type:numeric(20,8);not null;default:0"`
+	Currency  string          `gorm:"type:string;not null;default:RUB"`
+	Status    WalletStatus    `gorm:"type:string;not null;default:active"`
+	OwnerID   *uint           `gorm:"index"`
+	PublicKey *string         `gorm:"type:string;uniqueIndex"`
+	Nonce     int64           `gorm:"not null;default:0"`
+}
+
+// Available возвращает сумму, доступную для переводов и новых холдов: баланс за вычетом холдов.
+func (w *Wallet) Available() decimal.Decimal {
+	return w.Balance.Sub(w.Held)
 }
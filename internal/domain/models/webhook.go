@@ -0,0 +1,84 @@
+// Package models содержит бизнес-сущности и их представление в базе данных.
+// Определяет структуры данных, используемые на всех уровнях приложения.
+package models
+
+import (
+	"encoding/json"
+	"gorm.io/gorm"
+	"net"
+	"time"
+)
+
+// Типы событий, на которые можно подписать вебхук.
+const (
+	WebhookEventTransferIncoming = "transfer.incoming" // Зачисление перевода на кошелек
+	WebhookEventTransferOutgoing = "transfer.outgoing" // Списание перевода с кошелька
+)
+
+// WebhookEventTypes - все типы событий вебхуков.
+var WebhookEventTypes = []string{WebhookEventTransferIncoming, WebhookEventTransferOutgoing}
+
+// PublicWebhookAddress сообщает, можно ли отправлять вебхуки на адрес ip.
+// Отклоняются адреса loopback, частных сетей, link-local (в том числе метаданные облака
+// 169.254.169.254), multicast и неопределенный адрес, чтобы подписка не могла обращаться
+// к внутренним сервисам от имени сервера.
+func PublicWebhookAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// WebhookSubscription представляет подписку на события кошелька.
+// Доставки подписываются HMAC-SHA256 с секретом подписки; секрет показывается
+// только при создании подписки.
+type WebhookSubscription struct {
+	gorm.Model
+	PublicID   string   `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	Wallet     string   `gorm:"type:string;not null;index"`
+	URL        string   `gorm:"type:text;not null"`
+	EventTypes []string `gorm:"type:jsonb;serializer:json;not null"`
+	Secret     string   `gorm:"type:string;not null"`
+}
+
+// Subscribed сообщает, подписана ли подписка на события типа eventType.
+func (s *WebhookSubscription) Subscribed(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus описывает состояние доставки вебхука.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Доставка ожидает отправки или повтора
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // Получатель ответил 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // Попытки доставки исчерпаны
+	WebhookDeliveryCancelled WebhookDeliveryStatus = "cancelled" // Подписка удалена до доставки
+)
+
+// WebhookDelivery представляет доставку события подписчику и журнал ее попыток:
+// число попыток, код ответа и ошибку последней попытки.
+//
+// EventID - идентификатор события outbox; одно событие доставляется подписке не более
+// одного раза (не считая повторов после ошибок и ручной повторной доставки).
+// ClaimToken и ClaimedUntil - аренда доставки отправителем (см. ScheduledTransfer).
+type WebhookDelivery struct {
+	gorm.Model
+	PublicID       string                `gorm:"type:uuid;uniqueIndex;not null;default:gen_random_uuid()"`
+	SubscriptionID uint                  `gorm:"not null"`
+	Subscription   *WebhookSubscription  `gorm:""`
+	EventID        uint                  `gorm:"not null"`
+	EventType      string                `gorm:"type:string;not null"`
+	Payload        json.RawMessage       `gorm:"type:jsonb;not null"`
+	Status         WebhookDeliveryStatus `gorm:"type:string;not null;default:pending"`
+	Attempts       int                   `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time             `gorm:"not null"`
+	LastStatusCode *int                  `gorm:""`
+	LastError      *string               `gorm:"type:text"`
+	DeliveredAt    *time.Time            `gorm:""`
+	ClaimToken     *string               `gorm:"type:uuid"`
+	ClaimedUntil   *time.Time            `gorm:""`
+}
@@ -0,0 +1,22 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"time"
+)
+
+// AccountRepository определяет контракт для работы с учетными записями и их API-ключами.
+type AccountRepository interface {
+	CreateAccount(ctx context.Context, account *models.Account, key *models.APIKey) error
+	Account(ctx context.Context, publicID string) (*models.Account, error)
+	UpdateAccountRole(ctx context.Context, account *models.Account, role string) error
+	CreateAPIKey(ctx context.Context, key *models.APIKey) error
+	APIKey(ctx context.Context, publicID string) (*models.APIKey, error)
+	APIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	APIKeys(ctx context.Context, accountID uint) ([]models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, key *models.APIKey, now time.Time) error
+	TouchAPIKey(ctx context.Context, key *models.APIKey, now time.Time, interval time.Duration) error
+}
@@ -0,0 +1,15 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// AuditRepository определяет контракт для работы с журналом аудита.
+// Записи журнала только добавляются и не изменяются.
+type AuditRepository interface {
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	AuditEvents(ctx context.Context, beforeID uint, limit int) ([]models.AuditEvent, error)
+}
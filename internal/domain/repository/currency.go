@@ -0,0 +1,13 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// CurrencyRepository определяет контракт для работы со справочником валют.
+type CurrencyRepository interface {
+	Currency(ctx context.Context, code string) (*models.Currency, error)
+}
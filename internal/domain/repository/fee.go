@@ -0,0 +1,14 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import "github.com/normalniydada/case_infotecs/internal/domain/models"
+
+// FeeScheduleProvider определяет контракт источника тарифов комиссии.
+// Реализации должны быть безопасны для конкурентного вызова.
+type FeeScheduleProvider interface {
+	// Schedule возвращает тариф для валюты или nil, если переводы в валюте бесплатны.
+	Schedule(currency string) *models.FeeSchedule
+	// Schedules возвращает все настроенные тарифы.
+	Schedules() []*models.FeeSchedule
+}
@@ -0,0 +1,16 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// FXRateProvider определяет контракт источника курсов обмена валют.
+// Реализации должны быть безопасны для конкурентного вызова.
+type FXRateProvider interface {
+	// Rate возвращает курс обмена base на quote.
+	// Если курс неизвестен, возвращает er.ErrFXRateNotFound.
+	Rate(ctx context.Context, base, quote string) (*models.FXRate, error)
+}
@@ -0,0 +1,19 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"time"
+)
+
+// HoldRepository определяет контракт для работы с холдами (резервированием средств).
+// Все изменяющие методы выполняются атомарно вместе с изменением Wallet.Held.
+type HoldRepository interface {
+	CreateHold(ctx context.Context, hold *models.Hold) error
+	Hold(ctx context.Context, publicID string) (*models.Hold, error)
+	CaptureHold(ctx context.Context, publicID string, transaction *models.Transaction) (*models.Hold, error)
+	VoidHold(ctx context.Context, publicID string) (*models.Hold, error)
+	ExpireHolds(ctx context.Context, now time.Time, limit int) (int, error)
+}
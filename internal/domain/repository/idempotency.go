@@ -0,0 +1,14 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// IdempotencyRepository определяет контракт для работы с хранилищем ключей идемпотентности.
+// Запись ключей выполняется в WalletRepository.Transfer, здесь описано только чтение.
+type IdempotencyRepository interface {
+	IdempotencyKey(ctx context.Context, accountID, key string) (*models.IdempotencyKey, error)
+}
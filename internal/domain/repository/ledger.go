@@ -0,0 +1,22 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+)
+
+// BalanceMismatch описывает расхождение кэшированного баланса кошелька с журналом проводок.
+type BalanceMismatch struct {
+	Address       string          // Адрес кошелька
+	Balance       decimal.Decimal // Баланс из таблицы wallets
+	LedgerBalance decimal.Decimal // Сумма проводок по кошельку
+}
+
+// LedgerRepository определяет контракт для проверки согласованности журнала двойной записи.
+// Запись проводок выполняется WalletRepository в транзакциях переводов.
+type LedgerRepository interface {
+	BalanceMismatches(ctx context.Context) ([]BalanceMismatch, error)
+	UnbalancedTransactions(ctx context.Context) ([]string, error)
+}
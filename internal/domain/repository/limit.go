@@ -0,0 +1,16 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import "github.com/normalniydada/case_infotecs/internal/domain/models"
+
+// LimitProvider определяет контракт источника лимитов расходов по уровням кошельков.
+// Реализации должны быть безопасны для конкурентного вызова.
+type LimitProvider interface {
+	// DefaultTier возвращает уровень кошельков, которым уровень не назначен.
+	DefaultTier() string
+	// HasTier сообщает, описан ли уровень.
+	HasTier(tier string) bool
+	// Limits возвращает лимиты уровня для валюты (пустые, если лимиты не настроены).
+	Limits(tier, currency string) models.SpendingLimits
+}
@@ -0,0 +1,31 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"time"
+)
+
+// OutboxRepository определяет контракт для чтения и отметки событий транзакционного outbox.
+// События записываются репозиториями, изменяющими данные, в их транзакциях БД.
+type OutboxRepository interface {
+	// WithRelayLock выполняет fn под блокировкой ретранслятора, общей для всех экземпляров
+	// приложения. Репозиторий, переданный в fn, работает в транзакции, удерживающей блокировку.
+	// Если блокировка занята, fn не вызывается и возвращается false.
+	WithRelayLock(ctx context.Context, fn func(repo OutboxRepository) error) (bool, error)
+	Pending(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id uint, publishedAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, lastError string, nextAttemptAt time.Time) error
+	Published(ctx context.Context, afterSeq int64, eventType, wallet string, limit int) ([]models.OutboxEvent, error)
+	LastPublishSeq(ctx context.Context) (int64, error)
+}
+
+// EventPublisher определяет контракт приемника событий outbox
+// (канал внутри процесса, файл, HTTP-вебхук).
+// Ошибка публикации означает, что событие будет опубликовано повторно,
+// поэтому приемник должен допускать повторную доставку (at-least-once).
+type EventPublisher interface {
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
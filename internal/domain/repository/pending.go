@@ -0,0 +1,24 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// PendingTransferRepository определяет контракт для работы с отложенными переводами
+// и политиками согласования кошельков. Создание, выполнение и отклонение перевода
+// выполняются атомарно вместе с изменением Wallet.Held.
+type PendingTransferRepository interface {
+	CreatePendingTransfer(ctx context.Context, transfer *models.PendingTransfer) error
+	PendingTransfer(ctx context.Context, publicID string) (*models.PendingTransfer, error)
+	PendingTransferByIdempotencyKey(ctx context.Context, accountID, key string) (*models.PendingTransfer, error)
+	PendingTransfers(ctx context.Context, status models.PendingTransferStatus, limit int) ([]models.PendingTransfer, error)
+	ApprovePendingTransfer(ctx context.Context, publicID string, decision *models.PendingTransferDecision,
+		transaction *models.Transaction) (*models.PendingTransfer, error)
+	RejectPendingTransfer(ctx context.Context, publicID string,
+		decision *models.PendingTransferDecision) (*models.PendingTransfer, error)
+	ApprovalPolicy(ctx context.Context, address string) (*models.ApprovalPolicy, error)
+	SetApprovalPolicy(ctx context.Context, address string, policy *models.ApprovalPolicy) error
+}
@@ -0,0 +1,18 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// RateLimitStore определяет контракт хранилища корзин токенов ограничения частоты запросов.
+// Хранилище в памяти подходит для одного экземпляра приложения, хранилище в БД
+// разделяет корзины между несколькими экземплярами.
+type RateLimitStore interface {
+	// Take атомарно забирает токен из корзины ключа по ограничению limit.
+	Take(ctx context.Context, key string, limit models.RateLimit) (models.RateLimitDecision, error)
+	// Sweep удаляет заполненные корзины и возвращает их число.
+	Sweep(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,14 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import "github.com/normalniydada/case_infotecs/internal/domain/models"
+
+// RiskRuleSource определяет контракт источника правил проверки переводов.
+// Правила могут меняться во время работы приложения, поэтому реализации должны
+// быть безопасны для конкурентного вызова.
+type RiskRuleSource interface {
+	// Rules возвращает действующие правила в порядке проверки.
+	// Возвращенный срез не изменяется источником.
+	Rules() []models.RiskRule
+}
@@ -0,0 +1,16 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import "github.com/normalniydada/case_infotecs/internal/domain/models"
+
+// AccessPolicy определяет контракт источника политики доступа: ролей и их разрешений.
+// Реализации должны быть безопасны для конкурентного вызова.
+type AccessPolicy interface {
+	// Role возвращает роль субъекта операции (nil - анонимный запрос).
+	Role(principal *models.Principal) string
+	// Allows сообщает, есть ли у роли разрешение. Неизвестная роль не имеет разрешений.
+	Allows(role string, permission models.Permission) bool
+	// HasRole сообщает, описана ли роль в политике.
+	HasRole(role string) bool
+}
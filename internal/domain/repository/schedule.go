@@ -0,0 +1,21 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"time"
+)
+
+// ScheduledTransferRepository определяет контракт для работы с запланированными переводами
+// и журналом их выполнения.
+type ScheduledTransferRepository interface {
+	CreateScheduledTransfer(ctx context.Context, transfer *models.ScheduledTransfer) error
+	ScheduledTransfer(ctx context.Context, publicID string) (*models.ScheduledTransfer, error)
+	ScheduledTransfers(ctx context.Context, address string) ([]models.ScheduledTransfer, error)
+	UpdateScheduledTransfer(ctx context.Context, transfer *models.ScheduledTransfer) error
+	Runs(ctx context.Context, scheduledTransferID uint, limit int) ([]models.ScheduledTransferRun, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.ScheduledTransfer, error)
+	CompleteRun(ctx context.Context, transfer *models.ScheduledTransfer, run *models.ScheduledTransferRun) error
+}
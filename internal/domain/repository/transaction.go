@@ -5,10 +5,47 @@ package repository
 import (
 	"context"
 	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/shopspring/decimal"
+	"time"
 )
 
+// TransactionDirection задает направление транзакций относительно кошелька.
+type TransactionDirection string
+
+const (
+	DirectionIncoming TransactionDirection = "incoming" // Входящие транзакции (кошелек - получатель)
+	DirectionOutgoing TransactionDirection = "outgoing" // Исходящие транзакции (кошелек - отправитель)
+	DirectionBoth     TransactionDirection = "both"     // Входящие и исходящие транзакции
+)
+
+// TransactionCursor задает позицию в выборке для keyset-пагинации.
+// Выборка упорядочена по (CreatedAt, ID) в порядке убывания, поэтому
+// вставка новых транзакций не сдвигает уже выданные страницы.
+type TransactionCursor struct {
+	CreatedAt time.Time // Время создания последней выданной транзакции
+	ID        uint      // Внутренний идентификатор последней выданной транзакции
+}
+
+// TransactionFilter описывает параметры выборки истории транзакций кошелька.
+// Nil-значения необязательных полей означают отсутствие ограничения.
+type TransactionFilter struct {
+	Address     string               // Адрес кошелька
+	Direction   TransactionDirection // Направление транзакций
+	CreatedFrom *time.Time           // Нижняя граница времени создания (включительно)
+	CreatedTo   *time.Time           // Верхняя граница времени создания (не включительно)
+	MinAmount   *decimal.Decimal     // Минимальная сумма (включительно)
+	MaxAmount   *decimal.Decimal     // Максимальная сумма (включительно)
+	After       *TransactionCursor   // Позиция, после которой начинается страница
+	Limit       int                  // Размер страницы
+}
+
 // TransactionRepository определяет контракт для работы с хранилищем транзакций.
 // Описывает методы доступа к данным транзакций.
 type TransactionRepository interface {
 	LastNTransactions(ctx context.Context, n int) ([]models.Transaction, error)
+	Transaction(ctx context.Context, publicID string) (*models.Transaction, error)
+	WalletTransactions(ctx context.Context, filter TransactionFilter) ([]models.Transaction, error)
+	Refunds(ctx context.Context, publicID string) ([]models.Transaction, error)
+	Refund(ctx context.Context, refund *models.Transaction) error
+	OutgoingSince(ctx context.Context, address string, since time.Time) (int64, decimal.Decimal, error)
 }
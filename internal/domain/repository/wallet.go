@@ -5,7 +5,6 @@ package repository
 import (
 	"context"
 	"github.com/normalniydada/case_infotecs/internal/domain/models"
-	"github.com/shopspring/decimal"
 )
 
 // WalletRepository определяет контракт для работы с хранилищем кошельков.
@@ -13,6 +12,13 @@ import (
 type WalletRepository interface {
 	CreateWallet(ctx context.Context, wallet *models.Wallet) error
 	Wallet(ctx context.Context, address string) (*models.Wallet, error)
-	Transfer(ctx context.Context, from, to string, amount decimal.Decimal) error
+	WalletIncludingClosed(ctx context.Context, address string) (*models.Wallet, error)
+	CloseWallet(ctx context.Context, address string) error
+	UpdateWalletStatus(ctx context.Context, address string, change *models.WalletStatusChange) (*models.Wallet, error)
+	WalletStatusChanges(ctx context.Context, walletID uint) ([]models.WalletStatusChange, error)
+	WalletLimitStatus(ctx context.Context, address string) (*models.LimitStatus, error)
+	SetWalletLimit(ctx context.Context, address string, limit *models.WalletLimit) (*models.LimitStatus, error)
+	Transfer(ctx context.Context, transaction *models.Transaction, idempotencyKey *models.IdempotencyKey) error
+	TransferBatch(ctx context.Context, transactions []*models.Transaction, idempotencyKey *models.IdempotencyKey) error
 	Count(ctx context.Context) (int64, error)
 }
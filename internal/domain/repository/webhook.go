@@ -0,0 +1,30 @@
+// Package repository определяет интерфейсы для работы с хранилищами данных.
+// Содержит контракты, которые должны реализовывать репозитории приложения.
+package repository
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"time"
+)
+
+// WebhookRepository определяет контракт для работы с подписками на вебхуки и их доставками.
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	Subscription(ctx context.Context, publicID string) (*models.WebhookSubscription, error)
+	Subscriptions(ctx context.Context, wallets ...string) ([]models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscription *models.WebhookSubscription) error
+	CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error
+	Deliveries(ctx context.Context, subscriptionID uint, status *models.WebhookDeliveryStatus,
+		limit int) ([]models.WebhookDelivery, error)
+	Delivery(ctx context.Context, subscriptionID uint, publicID string) (*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]models.WebhookDelivery, error)
+	CompleteDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// WebhookSender определяет контракт отправки HTTP-запроса вебхука.
+// Возвращает код ответа получателя; ошибка означает, что ответ не получен.
+type WebhookSender interface {
+	Send(ctx context.Context, url string, body []byte, headers map[string]string) (int, error)
+}
@@ -0,0 +1,20 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// AccountService определяет контракт сервисного слоя для учетных записей и их API-ключей.
+// Authenticate проверяет ключ, предъявленный в запросе, и возвращает субъект операции.
+type AccountService interface {
+	CreateAccount(ctx context.Context, req dto.AccountRequest) (*dto.AccountResponse, error)
+	UpdateAccount(ctx context.Context, id string, req dto.UpdateAccountRequest) (*dto.AccountResponse, error)
+	CreateAPIKey(ctx context.Context, req dto.APIKeyRequest) (*dto.APIKeyResponse, error)
+	APIKeys(ctx context.Context, accountID string) ([]dto.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id string) error
+	Authenticate(ctx context.Context, key string) (*models.Principal, error)
+}
@@ -0,0 +1,15 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// AuditService определяет контракт сервисного слоя для журнала аудита.
+type AuditService interface {
+	Record(ctx context.Context, event *models.AuditEvent) error
+	Events(ctx context.Context, beforeID uint, count int) ([]dto.AuditEventResponse, error)
+}
@@ -0,0 +1,19 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// HoldService определяет контракт сервисного слоя для холдов:
+// резервирования средств (authorize), списания (capture) и отмены (void).
+// Все методы должны быть безопасны для конкурентного вызова.
+type HoldService interface {
+	CreateHold(ctx context.Context, req dto.HoldRequest) (*dto.HoldResponse, error)
+	Hold(ctx context.Context, id string) (*dto.HoldResponse, error)
+	CaptureHold(ctx context.Context, id string, req dto.CaptureHoldRequest) (*dto.HoldResponse, error)
+	VoidHold(ctx context.Context, id string) (*dto.HoldResponse, error)
+	ExpireHolds(ctx context.Context) (int, error)
+}
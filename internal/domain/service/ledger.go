@@ -0,0 +1,14 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// LedgerService определяет контракт сервисного слоя для журнала двойной записи.
+// Предоставляет проверку согласованности балансов кошельков с проводками.
+type LedgerService interface {
+	Verify(ctx context.Context) (*dto.LedgerReport, error)
+}
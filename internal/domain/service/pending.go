@@ -0,0 +1,23 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// PendingTransferService определяет контракт сервисного слоя для отложенных переводов:
+// очереди согласования, одобрения и отклонения (maker-checker) и политик согласования кошельков.
+// Все методы должны быть безопасны для конкурентного вызова.
+type PendingTransferService interface {
+	PendingTransfers(ctx context.Context, status string, count int) ([]dto.PendingTransferResponse, error)
+	PendingTransfer(ctx context.Context, id string) (*dto.PendingTransferResponse, error)
+	ApprovePendingTransfer(ctx context.Context, id string,
+		req dto.PendingTransferDecisionRequest) (*dto.PendingTransferResponse, error)
+	RejectPendingTransfer(ctx context.Context, id string,
+		req dto.PendingTransferDecisionRequest) (*dto.PendingTransferResponse, error)
+	ApprovalPolicy(ctx context.Context, address string) (*dto.ApprovalPolicyResponse, error)
+	SetApprovalPolicy(ctx context.Context, address string,
+		req dto.ApprovalPolicyRequest) (*dto.ApprovalPolicyResponse, error)
+}
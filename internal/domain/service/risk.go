@@ -0,0 +1,13 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+)
+
+// RiskEngine определяет контракт проверки переводов правилами до их выполнения.
+type RiskEngine interface {
+	Evaluate(ctx context.Context, transaction *models.Transaction) (*models.RiskDecision, error)
+}
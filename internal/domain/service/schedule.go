@@ -0,0 +1,22 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// ScheduledTransferService определяет контракт сервисного слоя для запланированных
+// (разовых и регулярных) переводов и их выполнения планировщиком.
+// Все методы должны быть безопасны для конкурентного вызова, в том числе
+// из нескольких экземпляров приложения.
+type ScheduledTransferService interface {
+	CreateScheduledTransfer(ctx context.Context, req dto.ScheduledTransferRequest) (*dto.ScheduledTransferResponse, error)
+	ScheduledTransfer(ctx context.Context, id string) (*dto.ScheduledTransferResponse, error)
+	ScheduledTransfers(ctx context.Context, address string) ([]dto.ScheduledTransferResponse, error)
+	UpdateScheduledTransfer(ctx context.Context, id string,
+		req dto.UpdateScheduledTransferRequest) (*dto.ScheduledTransferResponse, error)
+	CancelScheduledTransfer(ctx context.Context, id string) error
+	RunDue(ctx context.Context) (int, error)
+}
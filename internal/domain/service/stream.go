@@ -0,0 +1,17 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// TransactionStream определяет контракт потока зафиксированных переводов.
+// Поток строится по опубликованным событиям outbox, поэтому содержит только
+// зафиксированные переводы и работает на любом экземпляре приложения.
+type TransactionStream interface {
+	Subscribe(ctx context.Context, wallet, lastEventID string) (<-chan dto.StreamEvent, error)
+	Poll(ctx context.Context) error
+	Close()
+}
@@ -11,4 +11,8 @@ import (
 // Предоставляет бизнес-логику для операций с историей транзакций.
 type TransactionService interface {
 	LastNTransactions(ctx context.Context, n int) ([]dto.TransactionResponse, error)
+	Transaction(ctx context.Context, id string) (*dto.TransactionResponse, error)
+	WalletTransactions(ctx context.Context, address string,
+		req dto.TransactionHistoryRequest) (*dto.TransactionPage, error)
+	Refund(ctx context.Context, id string, req dto.RefundRequest) (*dto.TransactionResponse, error)
 }
@@ -4,6 +4,7 @@ package service
 
 import (
 	"context"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
 	"github.com/shopspring/decimal"
 )
 
@@ -11,8 +12,18 @@ import (
 // Предоставляет бизнес-логику для управления кошельками и переводами средств.
 // Все методы должны быть безопасны для конкурентного вызова.
 type WalletService interface {
-	Balance(ctx context.Context, address string) (decimal.Decimal, error)
-	TransferMoney(ctx context.Context, from, to string, amount decimal.Decimal) error
-	CreateWallet(ctx context.Context, balance decimal.Decimal) error
+	Balance(ctx context.Context, address string) (*dto.BalanceResponse, error)
+	TransferMoney(ctx context.Context, req dto.TransactionRequest, idempotencyKey string) (*dto.StoredResponse, error)
+	QuoteTransfer(ctx context.Context, req dto.TransactionRequest) (*dto.TransferQuote, error)
+	TransferBatch(ctx context.Context, req dto.BatchTransferRequest, idempotencyKey string) (*dto.StoredResponse, error)
+	CreateWallet(ctx context.Context, balance decimal.Decimal,
+		currency, owner, publicKey string) (*dto.WalletResponse, error)
+	Wallet(ctx context.Context, address string) (*dto.WalletResponse, error)
+	CloseWallet(ctx context.Context, address string) error
+	SetWalletStatus(ctx context.Context, address string, req dto.WalletStatusRequest) (*dto.WalletResponse, error)
+	WalletStatusHistory(ctx context.Context, address string) ([]dto.WalletStatusChangeResponse, error)
+	WalletLimits(ctx context.Context, address string) (*dto.WalletLimitsResponse, error)
+	SetWalletLimits(ctx context.Context, address string, req dto.WalletLimitsRequest) (*dto.WalletLimitsResponse, error)
+	EnsureWallet(ctx context.Context, address, currency string) error
 	CountWallets(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,24 @@
+// Package service определяет бизнес-логику приложения.
+// Содержит интерфейсы сервисного слоя, абстрагирующие бизнес-процессы.
+package service
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+)
+
+// WebhookService определяет контракт сервисного слоя для подписок на вебхуки
+// и доставки событий подписчикам.
+// Publish принимает события outbox (реализует repository.EventPublisher) и создает доставки,
+// DeliverDue отправляет их; оба метода безопасны для вызова из нескольких экземпляров приложения.
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req dto.WebhookRequest) (*dto.WebhookResponse, error)
+	Webhook(ctx context.Context, id string) (*dto.WebhookResponse, error)
+	Webhooks(ctx context.Context, address string) ([]dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id string) error
+	Deliveries(ctx context.Context, id string, status string) ([]dto.WebhookDeliveryResponse, error)
+	Redeliver(ctx context.Context, id, deliveryID string) (*dto.WebhookDeliveryResponse, error)
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+	DeliverDue(ctx context.Context) (int, error)
+}
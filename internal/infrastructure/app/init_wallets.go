@@ -5,6 +5,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
 	"github.com/normalniydada/case_infotecs/internal/domain/service"
 	"github.com/shopspring/decimal"
 	"log"
@@ -31,6 +32,7 @@ func NewWalletInitializer(walletService service.WalletService) *WalletInitialize
 
 // InitWallet инициализирует указанное количество кошельков с заданным балансом.
 // Если кошельки уже существуют в системе, инициализация пропускается.
+// Кошельки создаются от имени models.SystemPrincipal и не имеют владельца.
 //
 // Параметры:
 //   - ctx: контекст выполнения
@@ -58,6 +60,7 @@ func (wi *WalletInitializer) InitWallet(ctx context.Context, count int, balance
 	}
 
 	// Настраиваем механизм конкурентного создания
+	ctx = models.WithPrincipal(ctx, models.SystemPrincipal)
 	wg := &sync.WaitGroup{}
 	errCh := make(chan error, count)
 	var successCount int32
@@ -67,7 +70,7 @@ func (wi *WalletInitializer) InitWallet(ctx context.Context, count int, balance
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			err := wi.walletService.CreateWallet(ctx, balance)
+			_, err := wi.walletService.CreateWallet(ctx, balance, models.DefaultCurrency, "", "")
 			if err != nil {
 				errCh <- fmt.Errorf("error initializing wallet %d: %w", i+1, err)
 				return
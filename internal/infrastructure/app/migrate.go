@@ -0,0 +1,92 @@
+// Package app предоставляет точку входа и основную логику запуска приложения.
+// Управляет жизненным циклом приложения, инициализацией и graceful shutdown.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/db/postgres"
+	"log"
+	"strconv"
+)
+
+// Migrate выполняет команду управления схемой базы данных.
+//
+// Параметры:
+//   - args: аргументы команды после "migrate"
+//
+// Поддерживаемые команды:
+//
+//	migrate up         - применить все непримененные миграции
+//	migrate down [N]   - откатить N последних миграций (по умолчанию 1)
+//	migrate status     - вывести состояние миграций
+//
+// Пример использования:
+//
+//	./main migrate status
+//
+// Возвращает:
+//   - error: ошибка разбора аргументов, подключения к БД или выполнения команды.
+//     Соединение с БД закрывается и advisory-блокировка миграций снимается до возврата,
+//     поэтому вызывающий может завершить процесс с ненулевым кодом.
+func Migrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up | down [N] | status")
+	}
+
+	cfg := config.NewConfig()
+
+	migrator, closeDB, err := postgres.ProvideMigrator(&cfg.Database)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	defer func() {
+		if err := closeDB(); err != nil {
+			log.Printf("[WARN] Error closing connection to DB: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+		log.Printf("[INFO] Migrations applied: %d", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return fmt.Errorf("rollback failed: %w", err)
+		}
+		log.Printf("[INFO] Migrations rolled back: %d", reverted)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get migration status: %w", err)
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.AppliedAt != nil {
+				state = "applied at " + status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Printf("%06d_%-40s %s\n", status.Version, status.Name, state)
+		}
+
+	default:
+		return fmt.Errorf("unknown migrate command: %s", args[0])
+	}
+
+	return nil
+}
@@ -4,28 +4,59 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/application/account"
+	"github.com/normalniydada/case_infotecs/internal/application/audit"
+	"github.com/normalniydada/case_infotecs/internal/application/ledger"
+	"github.com/normalniydada/case_infotecs/internal/application/risk"
+	"github.com/normalniydada/case_infotecs/internal/application/schedule"
+	"github.com/normalniydada/case_infotecs/internal/application/stream"
 	"github.com/normalniydada/case_infotecs/internal/application/transaction"
 	"github.com/normalniydada/case_infotecs/internal/application/wallet"
+	"github.com/normalniydada/case_infotecs/internal/application/webhook"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
 	"github.com/normalniydada/case_infotecs/internal/domain/service"
 	"github.com/normalniydada/case_infotecs/internal/infrastructure/db/postgres"
 	"github.com/normalniydada/case_infotecs/internal/infrastructure/db/postgres/repositories"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/events"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/fees"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/fx"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/limits"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/ratelimit"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/rbac"
+	riskrules "github.com/normalniydada/case_infotecs/internal/infrastructure/risk"
 	"github.com/normalniydada/case_infotecs/internal/presentation/api/handlers"
+	apimw "github.com/normalniydada/case_infotecs/internal/presentation/api/middleware"
 	"github.com/normalniydada/case_infotecs/internal/presentation/api/router"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 	"log"
+	"time"
 )
 
 type Application struct {
-	cfg                *config.Config
-	echo               *echo.Echo
-	db                 *gorm.DB
-	closers            []func()
-	walletService      service.WalletService
-	transactionService service.TransactionService
+	cfg                      *config.Config
+	echo                     *echo.Echo
+	db                       *gorm.DB
+	closers                  []func()
+	accessPolicy             repository.AccessPolicy
+	auditService             service.AuditService
+	accountService           service.AccountService
+	walletService            service.WalletService
+	transactionService       service.TransactionService
+	ledgerService            service.LedgerService
+	holdService              service.HoldService
+	scheduledTransferService service.ScheduledTransferService
+	webhookService           service.WebhookService
+	transactionStream        service.TransactionStream
+	pendingTransferService   service.PendingTransferService
+	rateLimitStore           repository.RateLimitStore
+	rateLimiter              *apimw.RateLimiter
+	ipExtractor              echo.IPExtractor
+	outboxRelay              *events.Relay
 }
 
 func setupApplication(ctx context.Context) (*Application, error) {
@@ -38,17 +69,135 @@ func setupApplication(ctx context.Context) (*Application, error) {
 	}
 	log.Println("[INFO] The database is connected")
 
-	walletRepo := repositories.NewWalletRepository(db.GetDB())
+	fxRateProvider, err := fx.ProvideRateProvider(&cfg.FX)
+	if err != nil {
+		return nil, err
+	}
+	log.Println("[INFO] FX rates loaded")
+
+	feeSchedules, err := fees.ProvideFeeSchedules(cfg.Fees)
+	if err != nil {
+		return nil, err
+	}
+
+	accessPolicy, err := rbac.ProvideAccessPolicy(cfg.RBAC)
+	if err != nil {
+		return nil, err
+	}
+
+	spendingLimits, err := limits.ProvideLimits(cfg.Limits)
+	if err != nil {
+		return nil, err
+	}
+
+	riskRules, err := riskrules.ProvideRules(cfg.Risk)
+	if err != nil {
+		return nil, err
+	}
+
+	walletRepo := repositories.NewWalletRepository(db.GetDB(), spendingLimits)
+	accountRepo := repositories.NewAccountRepository(db.GetDB())
+	currencyRepo := repositories.NewCurrencyRepository(db.GetDB())
 	transactionRepo := repositories.NewTransactionRepository(db.GetDB())
+	idempotencyRepo := repositories.NewIdempotencyRepository(db.GetDB())
+	ledgerRepo := repositories.NewLedgerRepository(db.GetDB())
+	holdRepo := repositories.NewHoldRepository(db.GetDB(), spendingLimits)
+	scheduledTransferRepo := repositories.NewScheduledTransferRepository(db.GetDB())
+	outboxRepo := repositories.NewOutboxRepository(db.GetDB())
+	webhookRepo := repositories.NewWebhookRepository(db.GetDB())
+	auditRepo := repositories.NewAuditRepository(db.GetDB())
+	pendingTransferRepo := repositories.NewPendingTransferRepository(db.GetDB(), spendingLimits)
+
+	rateLimitStore, err := ratelimit.ProvideStore(cfg.RateLimit, db.GetDB())
+	if err != nil {
+		return nil, err
+	}
+
+	addressLimit, err := ratelimit.ParseLimit("address", cfg.RateLimit.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	clientLimit, err := ratelimit.ParseLimit("client", cfg.RateLimit.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	walletLimit, err := ratelimit.ParseLimit("wallet", cfg.RateLimit.Wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	ipExtractor, err := apimw.NewIPExtractor(cfg.Server.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	riskEngine := risk.NewRiskEngine(riskRules, walletRepo, transactionRepo)
+
+	webhookSender := events.NewPublicHTTPSender(cfg.Webhooks.Timeout)
+	if cfg.Webhooks.AllowPrivateTargets {
+		webhookSender = events.NewHTTPSender(cfg.Webhooks.Timeout)
+	}
+	webhookService := webhook.NewWebhookService(webhookRepo, walletRepo, webhookSender,
+		webhook.Policy{
+			BatchSize:    cfg.Webhooks.BatchSize,
+			Lease:        cfg.Webhooks.Lease,
+			MaxAttempts:  cfg.Webhooks.MaxAttempts,
+			RetryBackoff: cfg.Webhooks.RetryBackoff,
+			MaxBackoff:   cfg.Webhooks.MaxBackoff,
+
+			AllowPrivateTargets: cfg.Webhooks.AllowPrivateTargets,
+		})
+
+	// Подписки на вебхуки получают события из outbox наравне с настроенными приемниками
+	publisher, closePublisher, err := events.ProvidePublisher(&cfg.Outbox, webhookService)
+	if err != nil {
+		return nil, err
+	}
+
+	walletService := wallet.NewWalletService(walletRepo, accountRepo, currencyRepo, fxRateProvider, feeSchedules,
+		spendingLimits, idempotencyRepo, riskEngine, pendingTransferRepo)
 
 	app := &Application{
 		cfg:                cfg,
 		echo:               echo.New(),
-		walletService:      wallet.NewWalletService(walletRepo),
-		transactionService: transaction.NewTransactionService(transactionRepo),
+		accessPolicy:       accessPolicy,
+		auditService:       audit.NewAuditService(auditRepo),
+		accountService:     account.NewAccountService(accountRepo, accessPolicy),
+		walletService:      walletService,
+		transactionService: transaction.NewTransactionService(transactionRepo, currencyRepo, walletRepo),
+		ledgerService:      ledger.NewLedgerService(ledgerRepo),
+		holdService: wallet.NewHoldService(holdRepo, walletRepo, currencyRepo, fxRateProvider, feeSchedules,
+			riskEngine, pendingTransferRepo, cfg.Holds.DefaultTTL, cfg.Holds.MaxTTL),
+		scheduledTransferService: schedule.NewScheduledTransferService(scheduledTransferRepo, walletRepo, walletService,
+			schedule.Policy{
+				BatchSize:    cfg.Scheduler.BatchSize,
+				Lease:        cfg.Scheduler.Lease,
+				MaxAttempts:  cfg.Scheduler.MaxAttempts,
+				RetryBackoff: cfg.Scheduler.RetryBackoff,
+				MinInterval:  cfg.Scheduler.MinInterval,
+			}),
+		webhookService: webhookService,
+		transactionStream: stream.NewTransactionStream(outboxRepo, stream.Policy{
+			BatchSize: cfg.Stream.BatchSize,
+			Buffer:    cfg.Stream.Buffer,
+		}),
+		pendingTransferService: wallet.NewPendingTransferService(pendingTransferRepo, walletRepo, accountRepo, currencyRepo,
+			fxRateProvider, feeSchedules),
+		rateLimitStore: rateLimitStore,
+		rateLimiter:    apimw.NewRateLimiter(rateLimitStore, addressLimit, clientLimit, walletLimit),
+		ipExtractor:    ipExtractor,
 	}
 
-	app.closers = append(app.closers, func() {
+	if publisher != nil {
+		app.outboxRelay = events.NewRelay(outboxRepo, publisher, cfg.Outbox.BatchSize,
+			cfg.Outbox.RetryBackoff, cfg.Outbox.MaxBackoff)
+	} else {
+		log.Println("[WARN] No event sinks are configured, outbox events will not be published")
+	}
+
+	app.closers = append(app.closers, closePublisher, func() {
 		if sqlDB, err := db.GetDB().DB(); err == nil {
 			if err := sqlDB.Close(); err != nil {
 				log.Printf("[WARN] Error closing connection to DB: %v", err)
@@ -64,17 +213,48 @@ func setupApplication(ctx context.Context) (*Application, error) {
 		return nil, err
 	}
 
+	if err := app.initFeeCollectors(ctx, feeSchedules); err != nil {
+		return nil, err
+	}
+
+	app.verifyLedger(ctx)
+
+	app.startHoldSweeper(ctx)
+	app.startRateLimitSweeper(ctx)
+	app.startScheduler(ctx)
+	app.startOutboxRelay(ctx)
+	app.startWebhookDelivery(ctx)
+	app.startTransactionStream(ctx)
+
 	return app, nil
 }
 
 func (a *Application) setupEcho() {
 	a.echo.HideBanner = true
-	a.echo.Use(middleware.Recover(), middleware.Logger())
+	// Адрес клиента (ограничение частоты, журнал аудита) не должен зависеть от заголовков клиента
+	a.echo.IPExtractor = a.ipExtractor
+	// Ограничение по адресу выполняется до аутентификации, чтобы ограничивать и запросы с неверным ключом.
+	// Authenticate использует признак привилегированного клиента, поэтому выполняется после Privileged,
+	// ограничение по API-ключу использует субъект запроса, поэтому выполняется после Authenticate
+	a.echo.Use(middleware.Recover(), middleware.Logger(), a.rateLimiter.PerAddress(),
+		apimw.Privileged(a.cfg.Admin.Token), apimw.Authenticate(a.accountService), a.rateLimiter.PerKey())
 
-	walletHandler := handlers.NewWalletHandler(a.walletService)
+	authorizer := apimw.NewAuthorizer(a.accessPolicy, a.auditService)
+
+	accountHandler := handlers.NewAccountHandler(a.accountService)
+	walletHandler := handlers.NewWalletHandler(a.walletService, authorizer)
 	transactionHandler := handlers.NewTransactionHandler(a.transactionService)
+	ledgerHandler := handlers.NewLedgerHandler(a.ledgerService)
+	holdHandler := handlers.NewHoldHandler(a.holdService)
+	scheduledTransferHandler := handlers.NewScheduledTransferHandler(a.scheduledTransferService)
+	webhookHandler := handlers.NewWebhookHandler(a.webhookService)
+	streamHandler := handlers.NewStreamHandler(a.transactionStream, a.cfg.Stream.Heartbeat)
+	auditHandler := handlers.NewAuditHandler(a.auditService)
+	pendingTransferHandler := handlers.NewPendingTransferHandler(a.pendingTransferService)
 
-	router.NewRouter(a.echo, walletHandler, transactionHandler)
+	router.NewRouter(a.echo, authorizer, a.rateLimiter, accountHandler, walletHandler, transactionHandler,
+		ledgerHandler, holdHandler, scheduledTransferHandler, webhookHandler, streamHandler, auditHandler,
+		pendingTransferHandler)
 }
 
 func (a *Application) initWallets(ctx context.Context) error {
@@ -82,6 +262,134 @@ func (a *Application) initWallets(ctx context.Context) error {
 	return initializer.InitWallet(ctx, 10, decimal.NewFromFloat(100.0))
 }
 
+func (a *Application) initFeeCollectors(ctx context.Context, feeSchedules repository.FeeScheduleProvider) error {
+	for _, schedule := range feeSchedules.Schedules() {
+		if err := a.walletService.EnsureWallet(ctx, schedule.Collector, schedule.Currency); err != nil {
+			return fmt.Errorf("error preparing %s fee collector: %w", schedule.Currency, err)
+		}
+	}
+	return nil
+}
+
+func (a *Application) verifyLedger(ctx context.Context) {
+	report, err := a.ledgerService.Verify(ctx)
+	if err != nil {
+		log.Printf("[WARN] Ledger verification failed: %v", err)
+		return
+	}
+
+	if !report.Consistent {
+		log.Printf("[WARN] Ledger is inconsistent: %d balance mismatches, %d unbalanced transactions",
+			len(report.BalanceMismatches), len(report.UnbalancedTransactions))
+		return
+	}
+	log.Println("[INFO] Ledger is consistent")
+}
+
+// startRateLimitSweeper запускает фоновую задачу, удаляющую заполненные корзины ограничения частоты.
+func (a *Application) startRateLimitSweeper(ctx context.Context) {
+	a.startWorker(ctx, a.cfg.RateLimit.SweepInterval, func(ctx context.Context) {
+		if _, err := a.rateLimitStore.Sweep(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[WARN] Error sweeping rate limit buckets: %v", err)
+		}
+	})
+}
+
+// startHoldSweeper запускает фоновую задачу, снимающую резерв с истекших холдов.
+func (a *Application) startHoldSweeper(ctx context.Context) {
+	a.startWorker(ctx, a.cfg.Holds.SweepInterval, func(ctx context.Context) {
+		expired, err := a.holdService.ExpireHolds(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[WARN] Error expiring holds: %v", err)
+		}
+		if expired > 0 {
+			log.Printf("[INFO] %d expired holds released", expired)
+		}
+	})
+}
+
+// startScheduler запускает планировщик, выполняющий запланированные переводы.
+// Планировщик можно запускать на нескольких экземплярах приложения одновременно.
+func (a *Application) startScheduler(ctx context.Context) {
+	a.startWorker(ctx, a.cfg.Scheduler.PollInterval, func(ctx context.Context) {
+		runs, err := a.scheduledTransferService.RunDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("[WARN] Error running scheduled transfers: %v", err)
+		}
+		if runs > 0 {
+			log.Printf("[INFO] %d scheduled transfer runs completed", runs)
+		}
+	})
+}
+
+// startOutboxRelay запускает ретранслятор, публикующий события из outbox.
+// Ретранслятор можно запускать на нескольких экземплярах приложения: события
+// одновременно публикует только один из них.
+func (a *Application) startOutboxRelay(ctx context.Context) {
+	if a.outboxRelay == nil {
+		return
+	}
+
+	a.startWorker(ctx, a.cfg.Outbox.PollInterval, func(ctx context.Context) {
+		if _, err := a.outboxRelay.Run(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[WARN] Error publishing outbox events: %v", err)
+		}
+	})
+}
+
+// startWebhookDelivery запускает отправку доставок вебхуков подписчикам.
+// Отправку можно запускать на нескольких экземплярах приложения одновременно.
+func (a *Application) startWebhookDelivery(ctx context.Context) {
+	a.startWorker(ctx, a.cfg.Webhooks.PollInterval, func(ctx context.Context) {
+		if _, err := a.webhookService.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[WARN] Error delivering webhooks: %v", err)
+		}
+	})
+}
+
+// startTransactionStream запускает чтение новых переводов для потока транзакций.
+// Поток закрывается в начале остановки сервера, иначе открытые соединения SSE
+// не дали бы серверу завершиться.
+func (a *Application) startTransactionStream(ctx context.Context) {
+	a.echo.Server.RegisterOnShutdown(a.transactionStream.Close)
+
+	a.startWorker(ctx, a.cfg.Stream.PollInterval, func(ctx context.Context) {
+		if err := a.transactionStream.Poll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[WARN] Error reading transaction stream: %v", err)
+		}
+	})
+}
+
+// startWorker запускает фоновую задачу, выполняющую job с периодом interval.
+// Задача останавливается при закрытии приложения до закрытия подключения к БД.
+func (a *Application) startWorker(ctx context.Context, interval time.Duration, job func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				job(ctx)
+			}
+		}
+	}()
+
+	// Закрывающие функции выполняются по порядку, поэтому задача
+	// должна остановиться раньше закрытия подключения к БД
+	a.closers = append([]func(){func() {
+		cancel()
+		<-done
+	}}, a.closers...)
+}
+
 func (a *Application) Close() {
 	for _, closer := range a.closers {
 		closer()
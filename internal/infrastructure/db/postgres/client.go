@@ -4,9 +4,11 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
-	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/db/postgres/migrations"
 	"gorm.io/gorm"
+	"log"
 )
 
 // PostgresDB представляет соединение с PostgreSQL и реализует методы работы с БД.
@@ -37,18 +39,36 @@ func (p *PostgresDB) Close() error {
 	return conn.Close()
 }
 
-// runMigrations выполняет автоматические миграции для моделей приложения.
-// Создает необходимые таблицы и индексы в базе данных.
-//
-// Мигрируемые модели:
-//   - models.Wallet: таблица кошельков
-//   - models.Transaction: таблица транзакций
+// runMigrations применяет версионированные SQL-миграции (см. пакет migrations).
+// Создает и обновляет таблицы, индексы и ограничения базы данных.
 //
 // Возвращает:
 //   - error: ошибка выполнения миграций
 func (p *PostgresDB) runMigrations() error {
-	return p.db.AutoMigrate(
-		&models.Wallet{},
-		&models.Transaction{},
-	)
+	migrator, err := p.migrator()
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Database migrations applied: %d", applied)
+	return nil
+}
+
+// migrator создает мигратор для текущего подключения.
+//
+// Возвращает:
+//   - *migrations.Migrator: мигратор встроенных SQL-миграций
+//   - error: ошибка получения подключения или разбора миграций
+func (p *PostgresDB) migrator() (*migrations.Migrator, error) {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql DB: %w", err)
+	}
+
+	return migrations.NewMigrator(sqlDB)
 }
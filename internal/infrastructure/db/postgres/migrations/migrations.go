@@ -0,0 +1,317 @@
+// Package migrations реализует версионированные SQL-миграции схемы PostgreSQL.
+// Файлы миграций встраиваются в бинарный файл через embed и именуются по шаблону
+// <версия>_<название>.up.sql / <версия>_<название>.down.sql.
+// Примененные версии хранятся в таблице schema_migrations, а одновременный запуск
+// миграций несколькими репликами исключается advisory-блокировкой PostgreSQL.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+// advisoryLockID - ключ advisory-блокировки, под которой выполняются миграции.
+const advisoryLockID int64 = 0x63617365_6d696772 // "casemigr"
+
+// Migration описывает одну версию схемы.
+type Migration struct {
+	Version int64  // Номер версии (префикс имени файла)
+	Name    string // Название миграции
+	Up      string // SQL применения
+	Down    string // SQL отката
+}
+
+// Status описывает состояние миграции в базе данных.
+type Status struct {
+	Version   int64      // Номер версии
+	Name      string     // Название миграции
+	AppliedAt *time.Time // Время применения (nil, если миграция не применена)
+}
+
+// Migrator применяет и откатывает встроенные миграции.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator создает мигратор для указанного подключения к БД.
+//
+// Параметры:
+//   - db: подключение к БД (*sql.DB)
+//
+// Возвращает:
+//   - *Migrator: мигратор со всеми встроенными миграциями
+//   - error: ошибка разбора файлов миграций
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up применяет все непримененные миграции в порядке возрастания версии.
+// Каждая миграция выполняется в отдельной транзакции вместе с записью в schema_migrations.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - int: количество примененных миграций
+//   - error: ошибка применения (примененные до ошибки миграции сохраняются)
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			err = inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx,
+					"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to apply migration %06d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Down откатывает последние примененные миграции в порядке убывания версии.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - steps: количество откатываемых миграций
+//
+// Возвращает:
+//   - int: количество откаченных миграций
+//   - error: ошибка отката
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			err = inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("failed to roll back migration %06d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Status возвращает состояние всех встроенных миграций.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - []Status: состояние миграций в порядке возрастания версии
+//   - error: ошибка чтения schema_migrations
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		statuses = make([]Status, 0, len(m.migrations))
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock выполняет функцию на выделенном соединении под advisory-блокировкой.
+// Блокировка сессионная, поэтому все запросы выполняются на одном соединении.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", advisoryLockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Разблокируем независимо от отмены ctx, иначе блокировка останется на соединении в пуле
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", advisoryLockID)
+	}()
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT        NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions возвращает примененные версии и время их применения.
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
+
+// inTx выполняет функцию в транзакции на указанном соединении.
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// load читает и группирует файлы миграций по версиям.
+// Каждая версия должна иметь оба файла: up и down.
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		fileName := entry.Name()
+
+		base, direction, ok := cutDirection(fileName)
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name: %s", fileName)
+		}
+
+		versionPart, name, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name: %s", fileName)
+		}
+
+		version, err := strconv.ParseInt(versionPart, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version: %s", fileName)
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join("sql", fileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", fileName, err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("duplicate migration version %d: %s", version, fileName)
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %06d_%s must have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// cutDirection отделяет от имени файла суффикс направления (.up.sql или .down.sql).
+func cutDirection(fileName string) (base, direction string, ok bool) {
+	if base, ok = strings.CutSuffix(fileName, ".up.sql"); ok {
+		return base, "up", true
+	}
+	if base, ok = strings.CutSuffix(fileName, ".down.sql"); ok {
+		return base, "down", true
+	}
+	return "", "", false
+}
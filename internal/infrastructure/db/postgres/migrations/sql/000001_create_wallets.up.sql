@@ -0,0 +1,15 @@
+-- Таблица кошельков. IF NOT EXISTS позволяет принять схему, ранее созданную gorm.AutoMigrate.
+CREATE TABLE IF NOT EXISTS wallets (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    address    TEXT           NOT NULL,
+    balance    NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    status     TEXT           NOT NULL DEFAULT 'active'
+);
+
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_address ON wallets (address);
+CREATE INDEX IF NOT EXISTS idx_wallets_deleted_at ON wallets (deleted_at);
@@ -0,0 +1,19 @@
+-- Таблица транзакций с публичным идентификатором и индексами для истории кошелька.
+CREATE TABLE IF NOT EXISTS transactions (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    public_id  UUID           NOT NULL DEFAULT gen_random_uuid(),
+    "from"     TEXT           NOT NULL,
+    "to"       TEXT           NOT NULL,
+    amount     NUMERIC(20, 8) NOT NULL
+);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS public_id UUID NOT NULL DEFAULT gen_random_uuid();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_public_id ON transactions (public_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at_id ON transactions (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_from_created_at_id ON transactions ("from", created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_to_created_at_id ON transactions ("to", created_at DESC, id DESC);
@@ -0,0 +1,16 @@
+-- Сохраненные результаты запросов по ключу идемпотентности.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id             BIGSERIAL PRIMARY KEY,
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ,
+    deleted_at     TIMESTAMPTZ,
+    key            TEXT   NOT NULL,
+    request_hash   TEXT   NOT NULL,
+    status_code    BIGINT NOT NULL,
+    response_body  JSONB  NOT NULL,
+    transaction_id BIGINT NOT NULL REFERENCES transactions (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_key ON idempotency_keys (key);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_deleted_at ON idempotency_keys (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_transaction_id ON idempotency_keys (transaction_id);
@@ -0,0 +1,31 @@
+-- Журнал проводок двойной записи.
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id             BIGSERIAL PRIMARY KEY,
+    created_at     TIMESTAMPTZ    NOT NULL,
+    transaction_id BIGINT REFERENCES transactions (id),
+    account        TEXT           NOT NULL,
+    type           TEXT           NOT NULL,
+    amount         NUMERIC(20, 8) NOT NULL,
+    balance_after  NUMERIC(20, 8)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries (account);
+
+-- Начальные проводки для кошельков, созданных до появления журнала:
+-- текущий баланс считается выпущенным с системного счета 'system:issuance'.
+INSERT INTO ledger_entries (created_at, account, type, amount, balance_after)
+SELECT now(), w.address, 'credit', w.balance, w.balance
+FROM wallets w
+WHERE w.balance > 0
+  AND NOT EXISTS (SELECT 1 FROM ledger_entries e WHERE e.account = w.address);
+
+INSERT INTO ledger_entries (created_at, account, type, amount)
+SELECT now(), 'system:issuance', 'debit', c.total - d.total
+FROM (SELECT COALESCE(SUM(amount), 0) AS total
+      FROM ledger_entries
+      WHERE transaction_id IS NULL AND type = 'credit' AND account <> 'system:issuance') c,
+     (SELECT COALESCE(SUM(amount), 0) AS total
+      FROM ledger_entries
+      WHERE transaction_id IS NULL AND type = 'debit' AND account = 'system:issuance') d
+WHERE c.total > d.total;
@@ -0,0 +1,11 @@
+ALTER TABLE ledger_entries
+    DROP CONSTRAINT IF EXISTS chk_ledger_entries_type,
+    DROP CONSTRAINT IF EXISTS chk_ledger_entries_amount_positive;
+
+ALTER TABLE transactions
+    DROP CONSTRAINT IF EXISTS chk_transactions_distinct_wallets,
+    DROP CONSTRAINT IF EXISTS chk_transactions_amount_positive;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT IF EXISTS chk_wallets_status,
+    DROP CONSTRAINT IF EXISTS chk_wallets_balance_non_negative;
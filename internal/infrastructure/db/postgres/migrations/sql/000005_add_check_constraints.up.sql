@@ -0,0 +1,12 @@
+-- Ограничения целостности, которые gorm.AutoMigrate создать не мог.
+ALTER TABLE wallets
+    ADD CONSTRAINT chk_wallets_balance_non_negative CHECK (balance >= 0),
+    ADD CONSTRAINT chk_wallets_status CHECK (status IN ('active', 'closed'));
+
+ALTER TABLE transactions
+    ADD CONSTRAINT chk_transactions_amount_positive CHECK (amount > 0),
+    ADD CONSTRAINT chk_transactions_distinct_wallets CHECK ("from" <> "to");
+
+ALTER TABLE ledger_entries
+    ADD CONSTRAINT chk_ledger_entries_amount_positive CHECK (amount > 0),
+    ADD CONSTRAINT chk_ledger_entries_type CHECK (type IN ('debit', 'credit'));
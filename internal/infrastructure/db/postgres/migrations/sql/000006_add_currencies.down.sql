@@ -0,0 +1,6 @@
+UPDATE ledger_entries SET account = 'system:issuance' WHERE account LIKE 'system:issuance:%';
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS currency;
+ALTER TABLE wallets DROP COLUMN IF EXISTS currency;
+
+DROP TABLE IF EXISTS currencies;
@@ -0,0 +1,22 @@
+-- Справочник валют: код ISO 4217 или символ токена, точность и минимальная единица суммы.
+CREATE TABLE currencies (
+    code       TEXT PRIMARY KEY CHECK (code ~ '^[A-Z0-9]{2,10}$'),
+    decimals   INT            NOT NULL CHECK (decimals BETWEEN 0 AND 8),
+    min_unit   NUMERIC(20, 8) NOT NULL CHECK (min_unit > 0),
+    created_at TIMESTAMPTZ    NOT NULL DEFAULT now()
+);
+
+INSERT INTO currencies (code, decimals, min_unit)
+VALUES ('RUB', 2, 0.01),
+       ('USD', 2, 0.01),
+       ('EUR', 2, 0.01),
+       ('JPY', 0, 1),
+       ('BTC', 8, 0.00000001),
+       ('USDT', 6, 0.000001);
+
+-- Существующие кошельки и транзакции считаются рублевыми.
+ALTER TABLE wallets ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB' REFERENCES currencies (code);
+ALTER TABLE transactions ADD COLUMN currency TEXT NOT NULL DEFAULT 'RUB' REFERENCES currencies (code);
+
+-- Системный счет выпуска ведется отдельно по каждой валюте.
+UPDATE ledger_entries SET account = 'system:issuance:RUB' WHERE account = 'system:issuance';
@@ -0,0 +1,5 @@
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS spread,
+    DROP COLUMN IF EXISTS rate,
+    DROP COLUMN IF EXISTS target_currency,
+    DROP COLUMN IF EXISTS target_amount;
@@ -0,0 +1,16 @@
+-- Сумма и валюта зачисления, примененный курс и спред перевода.
+-- Существующие переводы выполнялись в одной валюте: зачислено столько же, сколько списано.
+ALTER TABLE transactions
+    ADD COLUMN target_amount   NUMERIC(20, 8),
+    ADD COLUMN target_currency TEXT REFERENCES currencies (code),
+    ADD COLUMN rate            NUMERIC(30, 16) NOT NULL DEFAULT 1,
+    ADD COLUMN spread          NUMERIC(10, 8)  NOT NULL DEFAULT 0;
+
+UPDATE transactions SET target_amount = amount, target_currency = currency;
+
+ALTER TABLE transactions
+    ALTER COLUMN target_amount SET NOT NULL,
+    ALTER COLUMN target_currency SET NOT NULL,
+    ADD CONSTRAINT chk_transactions_target_amount CHECK (target_amount > 0),
+    ADD CONSTRAINT chk_transactions_rate CHECK (rate > 0),
+    ADD CONSTRAINT chk_transactions_spread CHECK (spread >= 0 AND spread < 1);
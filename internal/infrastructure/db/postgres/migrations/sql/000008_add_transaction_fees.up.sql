@@ -0,0 +1,6 @@
+-- Комиссия за перевод (в валюте отправителя) и кошелек-сборщик, на который она зачислена.
+ALTER TABLE transactions
+    ADD COLUMN fee        NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    ADD COLUMN fee_wallet TEXT,
+    ADD CONSTRAINT chk_transactions_fee CHECK (fee >= 0),
+    ADD CONSTRAINT chk_transactions_fee_wallet CHECK (fee = 0 OR fee_wallet IS NOT NULL);
@@ -0,0 +1,29 @@
+-- Сумма, зарезервированная активными холдами: входит в баланс, но недоступна для переводов.
+ALTER TABLE wallets
+    ADD COLUMN held NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_wallets_held CHECK (held >= 0 AND held <= balance);
+
+CREATE TABLE holds (
+    id              BIGSERIAL PRIMARY KEY,
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ,
+    deleted_at      TIMESTAMPTZ,
+    public_id       UUID           NOT NULL DEFAULT gen_random_uuid(),
+    "from"          TEXT           NOT NULL,
+    "to"            TEXT           NOT NULL,
+    amount          NUMERIC(20, 8) NOT NULL,
+    reserved        NUMERIC(20, 8) NOT NULL,
+    currency        TEXT           NOT NULL REFERENCES currencies (code),
+    captured_amount NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    status          TEXT           NOT NULL DEFAULT 'active',
+    expires_at      TIMESTAMPTZ    NOT NULL,
+    transaction_id  UUID REFERENCES transactions (public_id),
+    CONSTRAINT chk_holds_amount CHECK (amount > 0 AND reserved >= amount),
+    CONSTRAINT chk_holds_captured CHECK (captured_amount >= 0 AND captured_amount <= amount),
+    CONSTRAINT chk_holds_status CHECK (status IN ('active', 'captured', 'voided', 'expired'))
+);
+
+CREATE UNIQUE INDEX idx_holds_public_id ON holds (public_id);
+CREATE INDEX idx_holds_deleted_at ON holds (deleted_at);
+-- Поиск истекших холдов фоновой задачей.
+CREATE INDEX idx_holds_active_expires_at ON holds (expires_at) WHERE status = 'active';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transactions_refund_of;
+
+ALTER TABLE transactions
+    DROP CONSTRAINT IF EXISTS chk_transactions_refund_not_refunded,
+    DROP CONSTRAINT IF EXISTS chk_transactions_refunded_amount,
+    DROP COLUMN IF EXISTS refunded_amount,
+    DROP COLUMN IF EXISTS refund_of;
@@ -0,0 +1,9 @@
+-- Возвраты: refund_of связывает возврат с исходной транзакцией,
+-- refunded_amount накапливает возвращенную отправителю сумму (в валюте currency исходной транзакции).
+ALTER TABLE transactions
+    ADD COLUMN refund_of       UUID REFERENCES transactions (public_id),
+    ADD COLUMN refunded_amount NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    ADD CONSTRAINT chk_transactions_refunded_amount CHECK (refunded_amount >= 0 AND refunded_amount <= amount),
+    ADD CONSTRAINT chk_transactions_refund_not_refunded CHECK (refund_of IS NULL OR refunded_amount = 0);
+
+CREATE INDEX idx_transactions_refund_of ON transactions (refund_of) WHERE refund_of IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_batch_id;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS batch_id;
@@ -0,0 +1,5 @@
+-- Идентификатор пакета, связывающий транзакции одного атомарного пакетного перевода.
+ALTER TABLE transactions
+    ADD COLUMN batch_id UUID;
+
+CREATE INDEX idx_transactions_batch_id ON transactions (batch_id) WHERE batch_id IS NOT NULL;
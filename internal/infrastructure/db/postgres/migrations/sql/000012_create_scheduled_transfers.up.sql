@@ -0,0 +1,49 @@
+CREATE TABLE scheduled_transfers (
+    id               BIGSERIAL PRIMARY KEY,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ,
+    deleted_at       TIMESTAMPTZ,
+    public_id        UUID           NOT NULL DEFAULT gen_random_uuid(),
+    "from"           TEXT           NOT NULL,
+    "to"             TEXT           NOT NULL,
+    amount           NUMERIC(20, 8) NOT NULL,
+    interval_seconds BIGINT         NOT NULL DEFAULT 0,
+    end_at           TIMESTAMPTZ,
+    status           TEXT           NOT NULL DEFAULT 'active',
+    occurrence_at    TIMESTAMPTZ    NOT NULL,
+    next_run_at      TIMESTAMPTZ    NOT NULL,
+    attempts         INTEGER        NOT NULL DEFAULT 0,
+    claim_token      UUID,
+    claimed_until    TIMESTAMPTZ,
+    CONSTRAINT chk_scheduled_transfers_amount CHECK (amount > 0),
+    CONSTRAINT chk_scheduled_transfers_interval CHECK (interval_seconds >= 0),
+    CONSTRAINT chk_scheduled_transfers_attempts CHECK (attempts >= 0),
+    CONSTRAINT chk_scheduled_transfers_status
+        CHECK (status IN ('active', 'paused', 'completed', 'failed', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX idx_scheduled_transfers_public_id ON scheduled_transfers (public_id);
+CREATE INDEX idx_scheduled_transfers_deleted_at ON scheduled_transfers (deleted_at);
+CREATE INDEX idx_scheduled_transfers_from ON scheduled_transfers ("from");
+-- Поиск переводов, срок которых наступил, планировщиком.
+CREATE INDEX idx_scheduled_transfers_due ON scheduled_transfers (next_run_at) WHERE status = 'active';
+
+-- Попытки выполнения запланированных переводов и их результат.
+CREATE TABLE scheduled_transfer_runs (
+    id                    BIGSERIAL PRIMARY KEY,
+    created_at            TIMESTAMPTZ,
+    updated_at            TIMESTAMPTZ,
+    deleted_at            TIMESTAMPTZ,
+    scheduled_transfer_id BIGINT      NOT NULL REFERENCES scheduled_transfers (id),
+    occurrence_at         TIMESTAMPTZ NOT NULL,
+    attempt               INTEGER     NOT NULL,
+    status                TEXT        NOT NULL,
+    transaction_id        UUID REFERENCES transactions (public_id),
+    error                 TEXT,
+    CONSTRAINT chk_scheduled_transfer_runs_status CHECK (status IN ('succeeded', 'failed')),
+    CONSTRAINT chk_scheduled_transfer_runs_result
+        CHECK ((status = 'succeeded') = (transaction_id IS NOT NULL))
+);
+
+CREATE INDEX idx_scheduled_transfer_runs_transfer ON scheduled_transfer_runs (scheduled_transfer_id, created_at);
+CREATE INDEX idx_scheduled_transfer_runs_deleted_at ON scheduled_transfer_runs (deleted_at);
@@ -0,0 +1,22 @@
+-- Транзакционный outbox: события записываются в одной транзакции БД с изменениями,
+-- о которых они сообщают, и публикуются ретранслятором после фиксации.
+-- Порядок публикации - по id; ordering_keys (адреса кошельков) задают, какие события
+-- должны публиковаться строго по порядку относительно друг друга.
+CREATE TABLE outbox_events (
+    id              BIGSERIAL PRIMARY KEY,
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ,
+    deleted_at      TIMESTAMPTZ,
+    event_type      TEXT        NOT NULL,
+    ordering_keys   JSONB       NOT NULL DEFAULT '[]',
+    payload         JSONB       NOT NULL,
+    published_at    TIMESTAMPTZ,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_error      TEXT,
+    CONSTRAINT chk_outbox_events_attempts CHECK (attempts >= 0)
+);
+
+CREATE INDEX idx_outbox_events_deleted_at ON outbox_events (deleted_at);
+-- Выборка неопубликованных событий ретранслятором.
+CREATE INDEX idx_outbox_events_pending ON outbox_events (id) WHERE published_at IS NULL;
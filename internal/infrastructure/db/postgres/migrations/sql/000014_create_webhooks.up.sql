@@ -0,0 +1,49 @@
+-- Подписки на вебхуки: события кошелька доставляются на URL подписчика
+-- с подписью HMAC-SHA256 секретом подписки.
+CREATE TABLE webhook_subscriptions (
+    id          BIGSERIAL PRIMARY KEY,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    deleted_at  TIMESTAMPTZ,
+    public_id   UUID  NOT NULL DEFAULT gen_random_uuid(),
+    wallet      TEXT  NOT NULL,
+    url         TEXT  NOT NULL,
+    event_types JSONB NOT NULL,
+    secret      TEXT  NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_webhook_subscriptions_public_id ON webhook_subscriptions (public_id);
+CREATE INDEX idx_webhook_subscriptions_deleted_at ON webhook_subscriptions (deleted_at);
+CREATE INDEX idx_webhook_subscriptions_wallet ON webhook_subscriptions (wallet) WHERE deleted_at IS NULL;
+
+-- Доставки событий подписчикам и результат последней попытки.
+CREATE TABLE webhook_deliveries (
+    id               BIGSERIAL PRIMARY KEY,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ,
+    deleted_at       TIMESTAMPTZ,
+    public_id        UUID        NOT NULL DEFAULT gen_random_uuid(),
+    subscription_id  BIGINT      NOT NULL REFERENCES webhook_subscriptions (id),
+    event_id         BIGINT      NOT NULL REFERENCES outbox_events (id),
+    event_type       TEXT        NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           TEXT        NOT NULL DEFAULT 'pending',
+    attempts         INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL,
+    last_status_code INTEGER,
+    last_error       TEXT,
+    delivered_at     TIMESTAMPTZ,
+    claim_token      UUID,
+    claimed_until    TIMESTAMPTZ,
+    CONSTRAINT chk_webhook_deliveries_attempts CHECK (attempts >= 0),
+    CONSTRAINT chk_webhook_deliveries_status
+        CHECK (status IN ('pending', 'succeeded', 'failed', 'cancelled'))
+);
+
+CREATE UNIQUE INDEX idx_webhook_deliveries_public_id ON webhook_deliveries (public_id);
+CREATE INDEX idx_webhook_deliveries_deleted_at ON webhook_deliveries (deleted_at);
+-- Событие доставляется подписке один раз, даже если ретранслятор опубликует его повторно.
+CREATE UNIQUE INDEX idx_webhook_deliveries_event ON webhook_deliveries (subscription_id, event_id);
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries (subscription_id, created_at);
+-- Поиск доставок, срок попытки которых наступил.
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_outbox_events_publish_seq;
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS publish_seq;
+
+DROP SEQUENCE IF EXISTS outbox_events_publish_seq;
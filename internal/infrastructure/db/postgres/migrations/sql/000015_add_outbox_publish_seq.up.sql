@@ -0,0 +1,9 @@
+-- Порядковый номер публикации события: присваивается ретранслятором при публикации.
+-- Ретранслятор работает под блокировкой и фиксирует номера вместе с отметками о публикации,
+-- поэтому номера видны читателям в порядке возрастания и поток событий (GET /api/transactions/stream)
+-- может продолжаться с последнего полученного номера без пропусков.
+CREATE SEQUENCE outbox_events_publish_seq;
+
+ALTER TABLE outbox_events ADD COLUMN publish_seq BIGINT;
+
+CREATE UNIQUE INDEX idx_outbox_events_publish_seq ON outbox_events (publish_seq) WHERE publish_seq IS NOT NULL;
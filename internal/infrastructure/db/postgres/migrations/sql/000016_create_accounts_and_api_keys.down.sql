@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_wallets_owner;
+ALTER TABLE wallets DROP COLUMN IF EXISTS owner_id;
+
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS accounts;
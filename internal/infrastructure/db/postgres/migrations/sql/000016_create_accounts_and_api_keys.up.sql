@@ -0,0 +1,40 @@
+-- Учетные записи клиентов API. Кошелек принадлежит учетной записи,
+-- и переводить с него может только владелец.
+CREATE TABLE accounts (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    public_id  UUID NOT NULL DEFAULT gen_random_uuid(),
+    name       TEXT NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_accounts_public_id ON accounts (public_id);
+CREATE INDEX idx_accounts_deleted_at ON accounts (deleted_at);
+
+-- API-ключи учетных записей. Хранится только SHA-256 ключа; prefix - начало ключа,
+-- по которому владелец отличает ключи в списке.
+CREATE TABLE api_keys (
+    id           BIGSERIAL PRIMARY KEY,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    deleted_at   TIMESTAMPTZ,
+    public_id    UUID   NOT NULL DEFAULT gen_random_uuid(),
+    account_id   BIGINT NOT NULL REFERENCES accounts (id),
+    name         TEXT   NOT NULL DEFAULT '',
+    prefix       TEXT   NOT NULL,
+    hash         TEXT   NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX idx_api_keys_public_id ON api_keys (public_id);
+CREATE UNIQUE INDEX idx_api_keys_hash ON api_keys (hash);
+CREATE INDEX idx_api_keys_deleted_at ON api_keys (deleted_at);
+CREATE INDEX idx_api_keys_account ON api_keys (account_id);
+
+-- Владелец кошелька. Кошельки, созданные до появления учетных записей, и служебные
+-- кошельки (сборщики комиссий) владельца не имеют: переводить с них может только администратор.
+ALTER TABLE wallets ADD COLUMN owner_id BIGINT REFERENCES accounts (id);
+
+CREATE INDEX idx_wallets_owner ON wallets (owner_id);
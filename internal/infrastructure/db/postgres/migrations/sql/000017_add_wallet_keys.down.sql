@@ -0,0 +1,11 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS signature;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS nonce;
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallets_nonce;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS nonce;
+
+DROP INDEX IF EXISTS idx_wallets_public_key;
+
+ALTER TABLE wallets DROP COLUMN IF EXISTS public_key;
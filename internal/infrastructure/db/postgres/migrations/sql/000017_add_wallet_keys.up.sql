@@ -0,0 +1,17 @@
+-- Открытый ключ Ed25519 кошелька (hex). Адрес кошелька с ключом - SHA-256 ключа,
+-- переводы с такого кошелька подписываются закрытым ключом. Кошельки, созданные
+-- до появления ключей, и служебные кошельки ключа не имеют.
+ALTER TABLE wallets ADD COLUMN public_key TEXT;
+
+CREATE UNIQUE INDEX idx_wallets_public_key ON wallets (public_key);
+
+-- Номер последнего подписанного перевода с кошелька. Подписанный перевод принимается
+-- только с большим номером, поэтому перехваченный запрос нельзя выполнить повторно.
+ALTER TABLE wallets ADD COLUMN nonce BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE wallets ADD CONSTRAINT chk_wallets_nonce CHECK (nonce >= 0);
+
+-- Номер и подпись подписанного перевода: позволяют проверить перевод открытым ключом отправителя.
+ALTER TABLE transactions ADD COLUMN nonce BIGINT;
+
+ALTER TABLE transactions ADD COLUMN signature TEXT;
@@ -0,0 +1,24 @@
+-- Роль учетной записи (RBAC). Разрешения ролей задаются в конфигурации (секция rbac);
+-- пустая строка - роль по умолчанию (rbac.default_role).
+ALTER TABLE accounts ADD COLUMN role TEXT NOT NULL DEFAULT '';
+
+-- Журнал аудита: попытки операций API, отклоненные политикой ролей.
+CREATE TABLE audit_events (
+    id          BIGSERIAL PRIMARY KEY,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    deleted_at  TIMESTAMPTZ,
+    account_id  UUID,
+    key_id      UUID,
+    role        TEXT NOT NULL,
+    permission  TEXT NOT NULL,
+    method      TEXT NOT NULL,
+    path        TEXT NOT NULL,
+    uri         TEXT NOT NULL,
+    remote_addr TEXT NOT NULL,
+    outcome     TEXT NOT NULL,
+    reason      TEXT NOT NULL
+);
+
+CREATE INDEX idx_audit_events_deleted_at ON audit_events (deleted_at);
+CREATE INDEX idx_audit_events_account_id ON audit_events (account_id);
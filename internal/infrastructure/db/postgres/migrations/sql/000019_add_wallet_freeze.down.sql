@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS wallet_status_changes;
+
+-- Замороженные кошельки становятся активными: старое ограничение не знает их статусов.
+UPDATE wallets SET status = 'active' WHERE status IN ('frozen_in', 'frozen_out', 'frozen');
+
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallets_status;
+
+ALTER TABLE wallets ADD CONSTRAINT chk_wallets_status CHECK (status IN ('active', 'closed'));
@@ -0,0 +1,23 @@
+-- Заморозка кошелька: блокировка зачислений (frozen_in), списаний (frozen_out) или обоих направлений (frozen).
+ALTER TABLE wallets DROP CONSTRAINT IF EXISTS chk_wallets_status;
+
+ALTER TABLE wallets
+    ADD CONSTRAINT chk_wallets_status CHECK (status IN ('active', 'frozen_in', 'frozen_out', 'frozen', 'closed'));
+
+-- История статусов кошелька: кто, когда и почему изменил статус.
+CREATE TABLE wallet_status_changes (
+    id          BIGSERIAL PRIMARY KEY,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    deleted_at  TIMESTAMPTZ,
+    wallet_id   BIGINT NOT NULL REFERENCES wallets (id),
+    from_status TEXT NOT NULL,
+    to_status   TEXT NOT NULL,
+    reason      TEXT NOT NULL,
+    account_id  UUID,
+    key_id      UUID,
+    CONSTRAINT chk_wallet_status_changes_reason CHECK (reason <> '')
+);
+
+CREATE INDEX idx_wallet_status_changes_deleted_at ON wallet_status_changes (deleted_at);
+CREATE INDEX idx_wallet_status_changes_wallet_id ON wallet_status_changes (wallet_id);
@@ -0,0 +1,20 @@
+-- Индивидуальные лимиты расходов кошелька: уровень ('' - уровень по умолчанию из конфигурации)
+-- и значения, заменяющие лимиты уровня (NULL - лимит уровня).
+CREATE TABLE wallet_limits (
+    id         BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+    wallet_id  BIGINT NOT NULL REFERENCES wallets (id),
+    tier       TEXT NOT NULL DEFAULT '',
+    max_single NUMERIC(20, 8),
+    daily      NUMERIC(20, 8),
+    weekly     NUMERIC(20, 8),
+    monthly    NUMERIC(20, 8),
+    CONSTRAINT chk_wallet_limits_non_negative CHECK (
+        max_single >= 0 AND daily >= 0 AND weekly >= 0 AND monthly >= 0
+    )
+);
+
+CREATE INDEX idx_wallet_limits_deleted_at ON wallet_limits (deleted_at);
+CREATE UNIQUE INDEX idx_wallet_limits_wallet_id ON wallet_limits (wallet_id);
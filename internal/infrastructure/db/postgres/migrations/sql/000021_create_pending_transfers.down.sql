@@ -0,0 +1,13 @@
+-- Отложенные выполнения удаляются: старое ограничение не знает их статуса.
+DELETE FROM scheduled_transfer_runs WHERE status = 'parked';
+
+ALTER TABLE scheduled_transfer_runs DROP CONSTRAINT IF EXISTS chk_scheduled_transfer_runs_parked;
+
+ALTER TABLE scheduled_transfer_runs DROP CONSTRAINT IF EXISTS chk_scheduled_transfer_runs_status;
+
+ALTER TABLE scheduled_transfer_runs
+    ADD CONSTRAINT chk_scheduled_transfer_runs_status CHECK (status IN ('succeeded', 'failed'));
+
+ALTER TABLE scheduled_transfer_runs DROP COLUMN IF EXISTS pending_transfer_id;
+
+DROP TABLE IF EXISTS pending_transfers;
@@ -0,0 +1,44 @@
+-- Переводы, отложенные правилами проверки (решение review) до ручного решения.
+-- Хранятся параметры запроса в том виде, в каком они подписаны: задана одна из сумм
+-- amount и target_amount, nonce и signature - только у подписанного перевода.
+CREATE TABLE pending_transfers (
+    id              BIGSERIAL PRIMARY KEY,
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ,
+    deleted_at      TIMESTAMPTZ,
+    public_id       UUID           NOT NULL DEFAULT gen_random_uuid(),
+    status          TEXT           NOT NULL DEFAULT 'pending',
+    "from"          TEXT           NOT NULL,
+    "to"            TEXT           NOT NULL,
+    amount          NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    target_amount   NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    currency        TEXT           NOT NULL REFERENCES currencies (code),
+    target_currency TEXT           NOT NULL REFERENCES currencies (code),
+    debit           NUMERIC(20, 8) NOT NULL,
+    nonce           BIGINT,
+    signature       TEXT,
+    rule            TEXT           NOT NULL,
+    reason          TEXT           NOT NULL,
+    account_id      UUID,
+    key_id          UUID,
+    idempotency_key TEXT,
+    request_hash    TEXT,
+    CONSTRAINT chk_pending_transfers_amount CHECK (
+        amount >= 0 AND target_amount >= 0 AND (amount = 0) <> (target_amount = 0) AND debit > 0
+    ),
+    CONSTRAINT chk_pending_transfers_status CHECK (status IN ('pending')),
+    CONSTRAINT chk_pending_transfers_idempotency CHECK ((idempotency_key IS NULL) = (request_hash IS NULL))
+);
+
+CREATE UNIQUE INDEX idx_pending_transfers_public_id ON pending_transfers (public_id);
+CREATE UNIQUE INDEX idx_pending_transfers_idempotency_key ON pending_transfers (idempotency_key);
+CREATE INDEX idx_pending_transfers_deleted_at ON pending_transfers (deleted_at);
+-- Очередь переводов в статусе от старых к новым.
+CREATE INDEX idx_pending_transfers_status_created_at ON pending_transfers (status, created_at, id);
+
+-- Выполнение запланированного перевода, отложенное правилом проверки.
+ALTER TABLE scheduled_transfer_runs
+    ADD COLUMN pending_transfer_id UUID REFERENCES pending_transfers (public_id),
+    DROP CONSTRAINT chk_scheduled_transfer_runs_status,
+    ADD CONSTRAINT chk_scheduled_transfer_runs_status CHECK (status IN ('succeeded', 'failed', 'parked')),
+    ADD CONSTRAINT chk_scheduled_transfer_runs_parked CHECK ((status = 'parked') = (pending_transfer_id IS NOT NULL));
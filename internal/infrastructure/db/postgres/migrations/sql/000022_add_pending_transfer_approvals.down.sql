@@ -0,0 +1,34 @@
+DROP TABLE IF EXISTS approval_policies;
+
+DROP TABLE IF EXISTS pending_transfer_decisions;
+
+-- Резерв ожидающих переводов снимается: прежняя схема его не учитывает.
+UPDATE wallets w
+SET held = w.held - p.reserved
+FROM (SELECT "from", SUM(reserved) AS reserved
+      FROM pending_transfers
+      WHERE status = 'pending'
+      GROUP BY "from") p
+WHERE w.address = p."from";
+
+-- Выполненные и отклоненные переводы удаляются: старое ограничение не знает их статусов.
+DELETE FROM scheduled_transfer_runs
+WHERE pending_transfer_id IN (SELECT public_id FROM pending_transfers WHERE status <> 'pending');
+
+DELETE FROM pending_transfers WHERE status <> 'pending';
+
+ALTER TABLE pending_transfers DROP CONSTRAINT IF EXISTS chk_pending_transfers_executed;
+
+ALTER TABLE pending_transfers DROP CONSTRAINT IF EXISTS chk_pending_transfers_required_approvals;
+
+ALTER TABLE pending_transfers DROP CONSTRAINT IF EXISTS chk_pending_transfers_reserved;
+
+ALTER TABLE pending_transfers DROP CONSTRAINT IF EXISTS chk_pending_transfers_status;
+
+ALTER TABLE pending_transfers ADD CONSTRAINT chk_pending_transfers_status CHECK (status IN ('pending'));
+
+ALTER TABLE pending_transfers
+    DROP COLUMN IF EXISTS transaction_id,
+    DROP COLUMN IF EXISTS approvers,
+    DROP COLUMN IF EXISTS required_approvals,
+    DROP COLUMN IF EXISTS reserved;
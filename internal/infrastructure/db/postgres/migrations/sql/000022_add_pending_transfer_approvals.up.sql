@@ -0,0 +1,55 @@
+-- Согласование отложенных переводов (N из M): резерв средств, политика на момент запроса,
+-- решения согласующих и транзакция выполненного перевода.
+ALTER TABLE pending_transfers
+    ADD COLUMN reserved           NUMERIC(20, 8) NOT NULL DEFAULT 0,
+    ADD COLUMN required_approvals INTEGER        NOT NULL DEFAULT 1,
+    ADD COLUMN approvers          JSONB          NOT NULL DEFAULT '[]',
+    ADD COLUMN transaction_id     UUID,
+    DROP CONSTRAINT chk_pending_transfers_status,
+    ADD CONSTRAINT chk_pending_transfers_status CHECK (status IN ('pending', 'executed', 'rejected')),
+    ADD CONSTRAINT chk_pending_transfers_reserved CHECK (reserved >= 0),
+    ADD CONSTRAINT chk_pending_transfers_required_approvals CHECK (required_approvals >= 1),
+    ADD CONSTRAINT chk_pending_transfers_executed CHECK ((status = 'executed') = (transaction_id IS NOT NULL));
+
+-- Переводы, отложенные до этой миграции, средств не резервировали (reserved = 0) и выполняются
+-- как обычный перевод. Их номера подписанных переводов считаются израсходованными, как у новых.
+UPDATE wallets w
+SET nonce = p.nonce
+FROM (SELECT "from", MAX(nonce) AS nonce
+      FROM pending_transfers
+      WHERE status = 'pending' AND nonce IS NOT NULL
+      GROUP BY "from") p
+WHERE w.address = p."from" AND w.nonce < p.nonce;
+
+-- Решения согласующих: одно решение учетной записи по переводу.
+CREATE TABLE pending_transfer_decisions (
+    id                  BIGSERIAL PRIMARY KEY,
+    created_at          TIMESTAMPTZ NOT NULL,
+    pending_transfer_id BIGINT      NOT NULL REFERENCES pending_transfers (id),
+    account_id          UUID        NOT NULL,
+    key_id              UUID,
+    approved            BOOLEAN     NOT NULL,
+    comment             TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_pending_transfer_decisions_account ON pending_transfer_decisions (pending_transfer_id, account_id);
+
+-- Политики согласования кошельков: порог суммы (NULL - без порога), число одобрений N
+-- и согласующие учетные записи M (пустой список - любые с разрешением transfers:approve).
+CREATE TABLE approval_policies (
+    id                 BIGSERIAL PRIMARY KEY,
+    created_at         TIMESTAMPTZ,
+    updated_at         TIMESTAMPTZ,
+    deleted_at         TIMESTAMPTZ,
+    wallet_id          BIGINT  NOT NULL REFERENCES wallets (id),
+    threshold          NUMERIC(20, 8),
+    required_approvals INTEGER NOT NULL DEFAULT 1,
+    approvers          JSONB   NOT NULL DEFAULT '[]',
+    CONSTRAINT chk_approval_policies_threshold CHECK (threshold >= 0),
+    CONSTRAINT chk_approval_policies_required_approvals CHECK (
+        required_approvals >= 1 AND (jsonb_array_length(approvers) = 0 OR required_approvals <= jsonb_array_length(approvers))
+    )
+);
+
+CREATE INDEX idx_approval_policies_deleted_at ON approval_policies (deleted_at);
+CREATE UNIQUE INDEX idx_approval_policies_wallet_id ON approval_policies (wallet_id);
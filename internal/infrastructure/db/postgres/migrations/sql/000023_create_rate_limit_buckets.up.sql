@@ -0,0 +1,12 @@
+-- Корзины токенов ограничения частоты запросов (rate_limit.store: postgres).
+-- Таблица нежурналируемая: после сбоя PostgreSQL она очищается, что лишь сбрасывает лимиты.
+-- Заполненная корзина (full_at в прошлом) равносильна отсутствующей и периодически удаляется.
+CREATE UNLOGGED TABLE rate_limit_buckets (
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    checked_at TIMESTAMPTZ NOT NULL,
+    full_at    TIMESTAMPTZ NOT NULL,
+    CONSTRAINT chk_rate_limit_buckets_tokens CHECK (tokens >= 0)
+);
+
+CREATE INDEX idx_rate_limit_buckets_full_at ON rate_limit_buckets (full_at);
@@ -0,0 +1,3 @@
+-- Адрес TCP-соединения запроса. remote_addr - адрес клиента с учетом доверенных прокси
+-- (server.trusted_proxies); peer_addr не зависит от заголовков запроса.
+ALTER TABLE audit_events ADD COLUMN peer_addr TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+-- Глобальная уникальность восстанавливается: из одинаковых ключей разных учетных записей
+-- остается самый ранний.
+DELETE FROM idempotency_keys k
+USING idempotency_keys earlier
+WHERE earlier.key = k.key AND earlier.id < k.id;
+
+DROP INDEX IF EXISTS idx_idempotency_keys_account_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_key ON idempotency_keys (key);
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS account_id;
+
+UPDATE pending_transfers p
+SET idempotency_key = NULL, request_hash = NULL
+FROM pending_transfers earlier
+WHERE earlier.idempotency_key = p.idempotency_key AND earlier.id < p.id;
+
+DROP INDEX IF EXISTS idx_pending_transfers_idempotency_key;
+CREATE UNIQUE INDEX idx_pending_transfers_idempotency_key ON pending_transfers (idempotency_key);
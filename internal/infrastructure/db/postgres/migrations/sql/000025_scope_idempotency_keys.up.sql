@@ -0,0 +1,19 @@
+-- Ключи идемпотентности принадлежат учетной записи вызывающего (NULL - привилегированный
+-- клиент без учетной записи): одинаковые ключи разных учетных записей не конфликтуют.
+ALTER TABLE idempotency_keys ADD COLUMN account_id UUID;
+
+-- Существующие ключи относятся к владельцу кошелька отправителя: только он мог выполнить перевод
+-- (кроме привилегированного клиента).
+UPDATE idempotency_keys k
+SET account_id = a.public_id
+FROM transactions t
+JOIN wallets w ON w.address = t."from"
+JOIN accounts a ON a.id = w.owner_id
+WHERE t.id = k.transaction_id;
+
+DROP INDEX IF EXISTS idx_idempotency_keys_key;
+CREATE UNIQUE INDEX idx_idempotency_keys_account_key ON idempotency_keys ((COALESCE(account_id::text, '')), key);
+
+DROP INDEX IF EXISTS idx_pending_transfers_idempotency_key;
+CREATE UNIQUE INDEX idx_pending_transfers_idempotency_key
+    ON pending_transfers ((COALESCE(account_id::text, '')), idempotency_key);
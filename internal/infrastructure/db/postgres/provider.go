@@ -5,6 +5,7 @@ package postgres
 
 import (
 	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/db/postgres/migrations"
 	"sync"
 )
 
@@ -27,7 +28,7 @@ var (
 //
 // Процесс инициализации:
 //  1. Установка соединения с БД (createConnection)
-//  2. Выполнение миграций (runMigrations)
+//  2. Выполнение миграций (runMigrations), если включен cfg.AutoMigrate
 //  3. Сохранение экземпляра в dbInstance
 //
 // Особенности:
@@ -40,10 +41,35 @@ func ProvideDBClient(cfg *config.DatabaseConfig) (Database, error) {
 	once.Do(func() {
 		var db *PostgresDB
 		db, err = createConnection(cfg)
-		if err == nil {
+		if err == nil && cfg.AutoMigrate {
 			err = db.runMigrations()
 		}
 		dbInstance = db
 	})
 	return dbInstance, err
 }
+
+// ProvideMigrator создает отдельное подключение к БД и мигратор для него.
+// Используется командой migrate, которая управляет схемой без запуска приложения.
+//
+// Параметры:
+//   - cfg: конфигурация подключения к БД
+//
+// Возвращает:
+//   - *migrations.Migrator: мигратор встроенных SQL-миграций
+//   - func() error: функция закрытия подключения
+//   - error: ошибка подключения или разбора миграций
+func ProvideMigrator(cfg *config.DatabaseConfig) (*migrations.Migrator, func() error, error) {
+	db, err := createConnection(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrator, err := db.migrator()
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, err
+	}
+
+	return migrator, db.Close, nil
+}
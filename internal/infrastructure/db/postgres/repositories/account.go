@@ -0,0 +1,222 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+	"time"
+)
+
+// accountRepository реализует интерфейс AccountRepository для PostgreSQL.
+type accountRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewAccountRepository создает новый экземпляр репозитория учетных записей.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.AccountRepository: реализацию интерфейса репозитория
+func NewAccountRepository(db *gorm.DB) repository.AccountRepository {
+	return &accountRepository{db: db}
+}
+
+// CreateAccount сохраняет новую учетную запись вместе с ее первым API-ключом в одной транзакции.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - account: учетная запись
+//   - key: первый API-ключ (AccountID назначается после создания учетной записи)
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *accountRepository) CreateAccount(ctx context.Context, account *models.Account, key *models.APIKey) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(account).Error; err != nil {
+			return fmt.Errorf("error creating account: %w", err)
+		}
+
+		key.AccountID = account.ID
+		if err := tx.Omit("Account").Create(key).Error; err != nil {
+			return fmt.Errorf("error creating api key: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Account возвращает учетную запись по ее публичному идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор учетной записи (UUID)
+//
+// Возвращает:
+//   - *models.Account: найденная учетная запись
+//   - error: er.ErrAccountNotFound или другие ошибки базы данных
+func (r *accountRepository) Account(ctx context.Context, publicID string) (*models.Account, error) {
+	var account models.Account
+
+	err := r.db.WithContext(ctx).First(&account, "public_id = ?", publicID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// UpdateAccountRole назначает учетной записи роль. Роль действует со следующего запроса
+// с любым ключом учетной записи.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - account: учетная запись (Role обновляется)
+//   - role: роль (пустая строка - роль по умолчанию)
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *accountRepository) UpdateAccountRole(ctx context.Context, account *models.Account, role string) error {
+	if err := r.db.WithContext(ctx).Model(account).Update("role", role).Error; err != nil {
+		return fmt.Errorf("error updating account role: %w", err)
+	}
+	return nil
+}
+
+// CreateAPIKey сохраняет новый API-ключ учетной записи.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - key: API-ключ
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *accountRepository) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	if err := r.db.WithContext(ctx).Omit("Account").Create(key).Error; err != nil {
+		return fmt.Errorf("error creating api key: %w", err)
+	}
+	return nil
+}
+
+// APIKey возвращает API-ключ (в том числе отозванный) вместе с учетной записью
+// по публичному идентификатору ключа.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор ключа (UUID)
+//
+// Возвращает:
+//   - *models.APIKey: найденный ключ
+//   - error: er.ErrAPIKeyNotFound или другие ошибки базы данных
+func (r *accountRepository) APIKey(ctx context.Context, publicID string) (*models.APIKey, error) {
+	return r.apiKey(ctx, "public_id = ?", publicID)
+}
+
+// APIKeyByHash возвращает API-ключ (в том числе отозванный) вместе с учетной записью
+// по SHA-256 ключа.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - hash: SHA-256 ключа в hex-формате
+//
+// Возвращает:
+//   - *models.APIKey: найденный ключ
+//   - error: er.ErrAPIKeyNotFound или другие ошибки базы данных
+func (r *accountRepository) APIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	return r.apiKey(ctx, "hash = ?", hash)
+}
+
+// apiKey возвращает API-ключ с учетной записью по условию.
+func (r *accountRepository) apiKey(ctx context.Context, query string, args ...any) (*models.APIKey, error) {
+	var key models.APIKey
+
+	err := r.db.WithContext(ctx).Preload("Account").Where(query, args...).First(&key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	if key.Account == nil {
+		// Учетная запись удалена
+		return nil, er.ErrAPIKeyNotFound
+	}
+
+	return &key, nil
+}
+
+// APIKeys возвращает API-ключи учетной записи (в том числе отозванные) в порядке создания.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - accountID: внутренний идентификатор учетной записи
+//
+// Возвращает:
+//   - []models.APIKey: ключи
+//   - error: ошибка базы данных
+func (r *accountRepository) APIKeys(ctx context.Context, accountID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("created_at, id").
+		Find(&keys).Error
+
+	return keys, err
+}
+
+// RevokeAPIKey отзывает API-ключ. Повторный отзыв не меняет время первого отзыва.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - key: ключ (RevokedAt обновляется)
+//   - now: время отзыва
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *accountRepository) RevokeAPIKey(ctx context.Context, key *models.APIKey, now time.Time) error {
+	if key.RevokedAt != nil {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", key.ID).
+		Update("revoked_at", now).Error; err != nil {
+		return fmt.Errorf("error revoking api key: %w", err)
+	}
+
+	key.RevokedAt = &now
+	return nil
+}
+
+// TouchAPIKey записывает время последнего использования ключа. Чтобы не записывать
+// в БД при каждом запросе, время обновляется не чаще, чем раз в interval.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - key: ключ
+//   - now: время использования
+//   - interval: минимальный период обновления
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *accountRepository) TouchAPIKey(ctx context.Context, key *models.APIKey, now time.Time,
+	interval time.Duration) error {
+	if key.LastUsedAt != nil && now.Sub(*key.LastUsedAt) < interval {
+		return nil
+	}
+
+	return r.db.WithContext(ctx).Model(&models.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", key.ID, now.Add(-interval)).
+		UpdateColumn("last_used_at", now).Error
+}
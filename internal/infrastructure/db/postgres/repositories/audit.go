@@ -0,0 +1,66 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// auditRepository реализует интерфейс AuditRepository для PostgreSQL.
+type auditRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewAuditRepository создает новый экземпляр репозитория журнала аудита.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.AuditRepository: реализацию интерфейса репозитория
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepository{db: db}
+}
+
+// CreateAuditEvent добавляет запись в журнал аудита.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - event: запись журнала
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *auditRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	if err := r.db.WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("error creating audit event: %w", err)
+	}
+	return nil
+}
+
+// AuditEvents возвращает записи журнала аудита от новых к старым.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - beforeID: вернуть записи с идентификатором меньше указанного (0 - с самой новой)
+//   - limit: максимальное число записей
+//
+// Возвращает:
+//   - []models.AuditEvent: записи журнала
+//   - error: ошибка базы данных
+func (r *auditRepository) AuditEvents(ctx context.Context, beforeID uint, limit int) ([]models.AuditEvent, error) {
+	query := r.db.WithContext(ctx).Order("id DESC").Limit(limit)
+	if beforeID != 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	var events []models.AuditEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("error getting audit events: %w", err)
+	}
+
+	return events, nil
+}
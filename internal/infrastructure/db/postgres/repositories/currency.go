@@ -0,0 +1,53 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"errors"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// currencyRepository реализует интерфейс CurrencyRepository для PostgreSQL.
+type currencyRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewCurrencyRepository создает новый экземпляр репозитория валют.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.CurrencyRepository: реализацию интерфейса репозитория
+func NewCurrencyRepository(db *gorm.DB) repository.CurrencyRepository {
+	return &currencyRepository{db: db}
+}
+
+// Currency возвращает валюту по ее коду.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - code: код валюты (например, RUB или USDT)
+//
+// Возвращает:
+//   - *models.Currency: найденная валюта
+//   - error: ошибка при поиске:
+//   - er.ErrCurrencyNotFound: если валюта не существует
+//   - другие ошибки базы данных
+func (r *currencyRepository) Currency(ctx context.Context, code string) (*models.Currency, error) {
+	var currency models.Currency
+
+	err := r.db.WithContext(ctx).First(&currency, "code = ?", code).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrCurrencyNotFound
+		}
+		return nil, err
+	}
+
+	return &currency, nil
+}
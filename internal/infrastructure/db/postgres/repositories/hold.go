@@ -0,0 +1,286 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"time"
+)
+
+// holdRepository реализует интерфейс HoldRepository для PostgreSQL.
+// Списание холда выполняет перевод теми же шагами, что и walletRepository.Transfer.
+//
+// Порядок блокировок: строка холда, затем строки кошельков (в порядке возрастания адреса).
+type holdRepository struct {
+	db      *gorm.DB          // Экземпляр GORM для работы с БД
+	wallets *walletRepository // Шаги перевода при списании холда
+}
+
+// NewHoldRepository создает новый экземпляр репозитория холдов.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//   - limits: источник лимитов расходов (списание холда проверяется по лимитам как перевод)
+//
+// Возвращает:
+//   - repository.HoldRepository: реализацию интерфейса репозитория
+func NewHoldRepository(db *gorm.DB, limits repository.LimitProvider) repository.HoldRepository {
+	return &holdRepository{db: db, wallets: &walletRepository{db: db, limits: limits}}
+}
+
+// CreateHold резервирует средства на кошельке отправителя и сохраняет холд.
+// Кошелек блокируется на время проверки доступного баланса.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - hold: создаваемый холд (From, To, Amount, Reserved, Currency, ExpiresAt)
+//
+// Возвращает:
+//   - error: ошибка при создании:
+//   - er.ErrWalletSenderNotFound: кошелек отправителя не найден
+//   - er.ErrWalletSenderFrozen: списания с кошелька заблокированы
+//   - er.ErrCurrencyMismatch: валюта холда не совпадает с валютой кошелька
+//   - er.ErrNotEnoughMoney: недостаточно доступных средств
+//   - другие ошибки базы данных
+func (r *holdRepository) CreateHold(ctx context.Context, hold *models.Hold) error {
+	return withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Сбрасываем идентификатор, присвоенный в откаченной попытке
+			hold.ID = 0
+
+			var wallet models.Wallet
+			if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+				First(&wallet, "address = ?", hold.From).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return er.ErrWalletSenderNotFound
+				}
+				return fmt.Errorf("error blocking wallet: %w", err)
+			}
+
+			if !wallet.Status.CanSend() {
+				return er.ErrWalletSenderFrozen
+			}
+
+			if wallet.Currency != hold.Currency {
+				return er.ErrCurrencyMismatch
+			}
+
+			if wallet.Available().LessThan(hold.Reserved) {
+				return er.ErrNotEnoughMoney
+			}
+
+			if err := tx.Model(&wallet).
+				Update("held", gorm.Expr("held + ?", hold.Reserved)).Error; err != nil {
+				return fmt.Errorf("error reserving funds: %w", err)
+			}
+
+			if err := tx.Create(hold).Error; err != nil {
+				return fmt.Errorf("error creating hold: %w", err)
+			}
+
+			return nil
+		})
+	})
+}
+
+// Hold возвращает холд по его публичному идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор холда (UUID)
+//
+// Возвращает:
+//   - *models.Hold: найденный холд
+//   - error: er.ErrHoldNotFound или другие ошибки базы данных
+func (r *holdRepository) Hold(ctx context.Context, publicID string) (*models.Hold, error) {
+	var hold models.Hold
+
+	err := r.db.WithContext(ctx).First(&hold, "public_id = ?", publicID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrHoldNotFound
+		}
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// CaptureHold списывает холд: переводит сумму транзакции получателю и снимает весь резерв.
+// Перевод и изменение статуса холда выполняются в одной транзакции БД.
+// При частичном списании остаток резерва возвращается в доступный баланс.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор холда
+//   - transaction: подготовленная транзакция перевода (From и To холда, Amount не больше суммы холда)
+//
+// Возвращает:
+//   - *models.Hold: списанный холд
+//   - error: ошибка при списании:
+//   - er.ErrHoldNotFound: холд не найден
+//   - er.ErrHoldNotActive: холд уже списан, отменен или истек
+//   - er.ErrCaptureExceedsHold: сумма больше суммы холда
+//   - ошибки перевода (см. walletRepository.Transfer)
+func (r *holdRepository) CaptureHold(ctx context.Context, publicID string,
+	transaction *models.Transaction) (*models.Hold, error) {
+	var captured *models.Hold
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			hold, err := r.lockActiveHold(tx, publicID)
+			if err != nil {
+				return err
+			}
+
+			if transaction.From != hold.From || transaction.To != hold.To {
+				return fmt.Errorf("transaction does not match hold %s", publicID)
+			}
+
+			if transaction.Amount.GreaterThan(hold.Amount) {
+				return er.ErrCaptureExceedsHold
+			}
+
+			if err = r.wallets.transfer(tx, transaction, nil, hold.Reserved); err != nil {
+				return err
+			}
+
+			hold.Status = models.HoldStatusCaptured
+			hold.CapturedAmount = transaction.Amount
+			hold.TransactionID = &transaction.PublicID
+
+			if err = tx.Model(hold).Select("status", "captured_amount", "transaction_id").
+				Updates(hold).Error; err != nil {
+				return fmt.Errorf("error updating hold: %w", err)
+			}
+
+			captured = hold
+			return nil
+		})
+	})
+
+	return captured, err
+}
+
+// VoidHold отменяет холд и снимает резерв без перевода.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор холда
+//
+// Возвращает:
+//   - *models.Hold: отмененный холд
+//   - error: er.ErrHoldNotFound, er.ErrHoldNotActive или другие ошибки базы данных
+func (r *holdRepository) VoidHold(ctx context.Context, publicID string) (*models.Hold, error) {
+	var voided *models.Hold
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			hold, err := r.lockActiveHold(tx, publicID)
+			if err != nil {
+				return err
+			}
+
+			if err = r.release(tx, hold, models.HoldStatusVoided); err != nil {
+				return err
+			}
+
+			voided = hold
+			return nil
+		})
+	})
+
+	return voided, err
+}
+
+// ExpireHolds снимает резерв с активных холдов, срок которых истек к моменту now.
+// Строки холдов захватываются с SKIP LOCKED, поэтому несколько экземпляров приложения
+// могут выполнять очистку параллельно, не мешая списаниям и отменам.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - now: текущее время
+//   - limit: максимальное количество холдов за вызов
+//
+// Возвращает:
+//   - int: количество истекших холдов
+//   - error: ошибка базы данных
+func (r *holdRepository) ExpireHolds(ctx context.Context, now time.Time, limit int) (int, error) {
+	var expired int
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var holds []models.Hold
+			if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: "SKIP LOCKED"}).
+				Where("status = ? AND expires_at <= ?", models.HoldStatusActive, now).
+				Order("expires_at").
+				Limit(limit).
+				Find(&holds).Error; err != nil {
+				return fmt.Errorf("error selecting expired holds: %w", err)
+			}
+
+			// Кошельки обновляются в порядке возрастания адреса, как при переводах
+			sort.Slice(holds, func(i, j int) bool { return holds[i].From < holds[j].From })
+
+			for i := range holds {
+				if err := r.release(tx, &holds[i], models.HoldStatusExpired); err != nil {
+					return err
+				}
+			}
+
+			expired = len(holds)
+			return nil
+		})
+	})
+
+	return expired, err
+}
+
+// lockActiveHold блокирует строку холда и проверяет, что холд активен и не истек.
+// Внутренний метод, используется при списании и отмене.
+func (r *holdRepository) lockActiveHold(tx *gorm.DB, publicID string) (*models.Hold, error) {
+	var hold models.Hold
+
+	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		First(&hold, "public_id = ?", publicID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrHoldNotFound
+		}
+		return nil, fmt.Errorf("error blocking hold: %w", err)
+	}
+
+	if hold.Status != models.HoldStatusActive {
+		return nil, fmt.Errorf("%w: hold is %s", er.ErrHoldNotActive, hold.Status)
+	}
+
+	if !hold.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: hold has expired", er.ErrHoldNotActive)
+	}
+
+	return &hold, nil
+}
+
+// release снимает резерв холда с кошелька отправителя и устанавливает итоговый статус.
+// Внутренний метод, используется при отмене и истечении срока.
+func (r *holdRepository) release(tx *gorm.DB, hold *models.Hold, status models.HoldStatus) error {
+	if err := tx.Model(&models.Wallet{}).
+		Where("address = ?", hold.From).
+		Update("held", gorm.Expr("held - ?", hold.Reserved)).Error; err != nil {
+		return fmt.Errorf("error releasing reserved funds: %w", err)
+	}
+
+	hold.Status = status
+	if err := tx.Model(hold).Update("status", status).Error; err != nil {
+		return fmt.Errorf("error updating hold: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"errors"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// idempotencyRepository реализует интерфейс IdempotencyRepository для PostgreSQL.
+type idempotencyRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewIdempotencyRepository создает новый экземпляр репозитория ключей идемпотентности.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.IdempotencyRepository: реализацию интерфейса репозитория
+func NewIdempotencyRepository(db *gorm.DB) repository.IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// IdempotencyKey возвращает сохраненный результат запроса по ключу идемпотентности учетной записи.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - accountID: публичный идентификатор учетной записи вызывающего
+//     (пустая строка - привилегированный клиент без учетной записи)
+//   - key: значение заголовка Idempotency-Key
+//
+// Возвращает:
+//   - *models.IdempotencyKey: сохраненный результат
+//   - error: ошибка при поиске:
+//   - er.ErrIdempotencyKeyNotFound: если ключ еще не использовался
+//   - другие ошибки базы данных
+func (r *idempotencyRepository) IdempotencyKey(ctx context.Context,
+	accountID, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+
+	// Условие совпадает с выражением уникального индекса idx_idempotency_keys_account_key
+	err := r.db.WithContext(ctx).
+		First(&record, "COALESCE(account_id::text, '') = ? AND key = ?", accountID, key).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrIdempotencyKeyNotFound
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
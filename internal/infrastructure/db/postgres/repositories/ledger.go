@@ -0,0 +1,122 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// signedAmountSQL - выражение суммы проводки со знаком: зачисление положительно, списание отрицательно.
+// Ожидает псевдоним e для таблицы ledger_entries.
+const signedAmountSQL = "CASE WHEN e.type = 'credit' THEN e.amount ELSE -e.amount END"
+
+// ledgerRepository реализует интерфейс LedgerRepository для PostgreSQL.
+type ledgerRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewLedgerRepository создает новый экземпляр репозитория журнала проводок.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.LedgerRepository: реализацию интерфейса репозитория
+func NewLedgerRepository(db *gorm.DB) repository.LedgerRepository {
+	return &ledgerRepository{db: db}
+}
+
+// BalanceMismatches возвращает кошельки, у которых кэшированный баланс
+// не совпадает с суммой проводок в журнале. Проверяются и закрытые кошельки.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - []repository.BalanceMismatch: найденные расхождения (пустой срез, если их нет)
+//   - error: ошибка при выполнении запроса
+func (r *ledgerRepository) BalanceMismatches(ctx context.Context) ([]repository.BalanceMismatch, error) {
+	var mismatches []repository.BalanceMismatch
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT w.address, w.balance, COALESCE(l.ledger_balance, 0) AS ledger_balance
+		FROM wallets w
+		LEFT JOIN (
+			SELECT e.account, SUM(` + signedAmountSQL + `) AS ledger_balance
+			FROM ledger_entries e
+			GROUP BY e.account
+		) l ON l.account = w.address
+		WHERE w.balance <> COALESCE(l.ledger_balance, 0)
+		ORDER BY w.address`).
+		Scan(&mismatches).Error
+
+	return mismatches, err
+}
+
+// UnbalancedTransactions возвращает публичные идентификаторы транзакций,
+// проводки которых не сбалансированы (сумма зачислений не равна сумме списаний).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - []string: идентификаторы несбалансированных транзакций
+//   - error: ошибка при выполнении запроса
+func (r *ledgerRepository) UnbalancedTransactions(ctx context.Context) ([]string, error) {
+	var ids []string
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT t.public_id
+		FROM ledger_entries e
+		JOIN transactions t ON t.id = e.transaction_id
+		GROUP BY t.public_id
+		HAVING SUM(` + signedAmountSQL + `) <> 0
+		ORDER BY t.public_id`).
+		Scan(&ids).Error
+
+	return ids, err
+}
+
+// postEntries записывает набор проводок в журнал в рамках транзакции БД.
+// Используется репозиториями, изменяющими балансы кошельков.
+//
+// Параметры:
+//   - tx: транзакция БД
+//   - entries: проводки (должны быть сбалансированы)
+//
+// Возвращает:
+//   - error: ошибка записи проводок
+func postEntries(tx *gorm.DB, entries ...models.LedgerEntry) error {
+	if err := tx.Create(&entries).Error; err != nil {
+		return fmt.Errorf("error posting ledger entries: %w", err)
+	}
+	return nil
+}
+
+// walletEntry формирует проводку по кошельку с балансом после проводки.
+func walletEntry(transactionID *uint, address string, entryType models.LedgerEntryType, amount,
+	balanceAfter decimal.Decimal) models.LedgerEntry {
+	return models.LedgerEntry{
+		TransactionID: transactionID,
+		Account:       address,
+		Type:          entryType,
+		Amount:        amount,
+		BalanceAfter:  &balanceAfter,
+	}
+}
+
+// systemEntry формирует проводку по системному счету (без баланса после проводки).
+func systemEntry(transactionID *uint, account string, entryType models.LedgerEntryType,
+	amount decimal.Decimal) models.LedgerEntry {
+	return models.LedgerEntry{
+		TransactionID: transactionID,
+		Account:       account,
+		Type:          entryType,
+		Amount:        amount,
+	}
+}
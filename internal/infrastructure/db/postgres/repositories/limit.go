@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// WalletLimitStatus возвращает лимиты расходов кошелька и их использование в текущих периодах.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - *models.LimitStatus: уровень, действующие лимиты и расход кошелька
+//   - error: er.ErrWalletNotFound, если кошелек не существует или закрыт, или другие ошибки базы данных
+func (r *walletRepository) WalletLimitStatus(ctx context.Context, address string) (*models.LimitStatus, error) {
+	db := r.db.WithContext(ctx)
+
+	var wallet models.Wallet
+	if err := db.First(&wallet, "address = ?", address).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	return r.limitStatus(db, &wallet, time.Now())
+}
+
+// SetWalletLimit назначает кошельку уровень и индивидуальные лимиты, заменяя прежние настройки.
+// Лимит nil в limit означает лимит уровня. Новые лимиты действуют со следующего перевода.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//   - limit: настройки лимитов (WalletID заполняется здесь)
+//
+// Возвращает:
+//   - *models.LimitStatus: лимиты кошелька после изменения
+//   - error: er.ErrWalletNotFound, если кошелек не существует или закрыт, или другие ошибки базы данных
+func (r *walletRepository) SetWalletLimit(ctx context.Context, address string,
+	limit *models.WalletLimit) (*models.LimitStatus, error) {
+	var status *models.LimitStatus
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var wallet models.Wallet
+		if err := tx.First(&wallet, "address = ?", address).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return er.ErrWalletNotFound
+			}
+			return err
+		}
+
+		limit.WalletID = wallet.ID
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "wallet_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at", "tier", "max_single", "daily", "weekly", "monthly",
+			}),
+		}).Create(limit).Error; err != nil {
+			return fmt.Errorf("error saving wallet limits: %w", err)
+		}
+
+		var err error
+		status, err = r.limitStatus(tx, &wallet, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// checkLimits проверяет, что перевод не превышает лимиты расходов отправителя.
+// Вызывается под блокировкой строки отправителя, поэтому параллельные переводы с кошелька
+// проверяются по очереди, и каждый видит расход, зафиксированный предыдущими
+// (а в пакете - и предыдущими переводами той же транзакции БД).
+// Возвраты лимитами не ограничиваются.
+// Внутренний метод, используется в transferLocked.
+func (r *walletRepository) checkLimits(tx *gorm.DB, sender *models.Wallet, transaction *models.Transaction) error {
+	if transaction.IsRefund() || r.limits == nil {
+		return nil
+	}
+
+	status, err := r.limitStatus(tx, sender, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, headroom := range status.Limits.Headroom(status.Usage, status.Windows) {
+		if transaction.Amount.GreaterThan(headroom.Remaining) {
+			return &er.LimitExceededError{
+				Period:    string(headroom.Period),
+				Limit:     headroom.Limit,
+				Remaining: headroom.Remaining,
+			}
+		}
+	}
+
+	return nil
+}
+
+// limitStatus вычисляет действующие лимиты кошелька (лимиты уровня с индивидуальными значениями)
+// и расход за периоды, содержащие момент at. Расход не считается, если лимиты не заданы.
+// Внутренний метод, используется в checkLimits, WalletLimitStatus и SetWalletLimit.
+func (r *walletRepository) limitStatus(tx *gorm.DB, wallet *models.Wallet, at time.Time) (*models.LimitStatus, error) {
+	status := &models.LimitStatus{Wallet: wallet, Windows: models.NewLimitWindows(at), At: at}
+	if r.limits == nil {
+		return status, nil
+	}
+
+	var walletLimit models.WalletLimit
+	err := tx.Where("wallet_id = ?", wallet.ID).Limit(1).Find(&walletLimit).Error
+	if err != nil {
+		return nil, fmt.Errorf("error getting wallet limits: %w", err)
+	}
+
+	status.Tier = walletLimit.Tier
+	if status.Tier == "" {
+		status.Tier = r.limits.DefaultTier()
+	}
+	status.Limits = r.limits.Limits(status.Tier, wallet.Currency).Override(walletLimit.Limits())
+
+	if status.Limits.Empty() {
+		return status, nil
+	}
+
+	status.Usage, err = spentSince(tx, wallet.Address, status.Windows)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// spentSince возвращает сумму исходящих переводов кошелька (без возвратов) за текущие
+// сутки, неделю и месяц одним запросом по индексу ("from", created_at).
+func spentSince(tx *gorm.DB, address string, windows models.LimitWindows) (models.LimitUsage, error) {
+	var usage struct {
+		Daily   decimal.Decimal
+		Weekly  decimal.Decimal
+		Monthly decimal.Decimal
+	}
+
+	err := tx.Raw(`
+		SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= ?), 0) AS daily,
+		       COALESCE(SUM(amount) FILTER (WHERE created_at >= ?), 0) AS weekly,
+		       COALESCE(SUM(amount) FILTER (WHERE created_at >= ?), 0) AS monthly
+		FROM transactions
+		WHERE "from" = ? AND created_at >= ? AND refund_of IS NULL AND deleted_at IS NULL`,
+		windows.Day, windows.Week, windows.Month, address, windows.Earliest()).
+		Scan(&usage).Error
+	if err != nil {
+		return models.LimitUsage{}, fmt.Errorf("error getting wallet spending: %w", err)
+	}
+
+	return models.LimitUsage{Daily: usage.Daily, Weekly: usage.Weekly, Monthly: usage.Monthly}, nil
+}
@@ -0,0 +1,194 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+	"time"
+)
+
+// relayLockID - ключ advisory-блокировки ретранслятора outbox.
+const relayLockID int64 = 0x63617365_6f757462 // "caseoutb"
+
+// outboxRepository реализует интерфейс OutboxRepository для PostgreSQL.
+//
+// Ретранслятор работает под транзакционной advisory-блокировкой (pg_try_advisory_xact_lock),
+// поэтому события публикует только один экземпляр приложения; отметки о публикации
+// фиксируются вместе со снятием блокировки.
+type outboxRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД (или транзакция под блокировкой)
+}
+
+// NewOutboxRepository создает новый экземпляр репозитория outbox.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.OutboxRepository: реализацию интерфейса репозитория
+func NewOutboxRepository(db *gorm.DB) repository.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// WithRelayLock выполняет fn в транзакции под advisory-блокировкой ретранслятора.
+// Если блокировку удерживает другой экземпляр, fn не вызывается.
+// При ошибке fn транзакция откатывается вместе с отметками о публикации,
+// и события будут опубликованы повторно.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - fn: работа ретранслятора с репозиторием, привязанным к транзакции
+//
+// Возвращает:
+//   - bool: удалось ли захватить блокировку
+//   - error: ошибка fn или базы данных
+func (r *outboxRepository) WithRelayLock(ctx context.Context,
+	fn func(repo repository.OutboxRepository) error) (bool, error) {
+	var acquired bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", relayLockID).Scan(&acquired).Error; err != nil {
+			return fmt.Errorf("failed to acquire relay lock: %w", err)
+		}
+
+		if !acquired {
+			return nil
+		}
+		return fn(&outboxRepository{db: tx})
+	})
+
+	return acquired, err
+}
+
+// Pending возвращает неопубликованные события в порядке публикации (по возрастанию ID),
+// включая события, следующая попытка которых еще не наступила: они задерживают
+// публикацию событий с теми же ключами упорядочивания.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - limit: максимальное количество событий
+//
+// Возвращает:
+//   - []models.OutboxEvent: неопубликованные события
+//   - error: ошибка базы данных
+func (r *outboxRepository) Pending(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.WithContext(ctx).
+		Where("published_at IS NULL").
+		Order("id").
+		Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+// MarkPublished отмечает событие опубликованным и присваивает ему порядковый номер публикации.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: идентификатор события
+//   - publishedAt: время публикации
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *outboxRepository) MarkPublished(ctx context.Context, id uint, publishedAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"published_at":    publishedAt,
+			"publish_seq":     gorm.Expr("nextval('outbox_events_publish_seq')"),
+			"next_attempt_at": nil,
+		}).Error; err != nil {
+		return fmt.Errorf("error marking event %d published: %w", id, err)
+	}
+	return nil
+}
+
+// MarkFailed записывает неудачную попытку публикации и время следующей попытки.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - id: идентификатор события
+//   - lastError: описание ошибки публикации
+//   - nextAttemptAt: время следующей попытки
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *outboxRepository) MarkFailed(ctx context.Context, id uint, lastError string, nextAttemptAt time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error; err != nil {
+		return fmt.Errorf("error recording failed publication of event %d: %w", id, err)
+	}
+	return nil
+}
+
+// Published возвращает опубликованные события с номером публикации больше afterSeq
+// в порядке публикации.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - afterSeq: номер публикации, после которого начинается выборка
+//   - eventType: тип событий
+//   - wallet: адрес кошелька среди ключей упорядочивания события (пустая строка - все события)
+//   - limit: максимальное количество событий
+//
+// Возвращает:
+//   - []models.OutboxEvent: опубликованные события
+//   - error: ошибка базы данных
+func (r *outboxRepository) Published(ctx context.Context, afterSeq int64, eventType, wallet string,
+	limit int) ([]models.OutboxEvent, error) {
+	query := r.db.WithContext(ctx).Where("publish_seq > ? AND event_type = ?", afterSeq, eventType)
+	if wallet != "" {
+		query = query.Where("ordering_keys @> jsonb_build_array(?::text)", wallet)
+	}
+
+	var events []models.OutboxEvent
+	err := query.
+		Order("publish_seq").
+		Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+// LastPublishSeq возвращает номер последнего опубликованного события (0, если событий нет).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - int64: номер публикации
+//   - error: ошибка базы данных
+func (r *outboxRepository) LastPublishSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.db.WithContext(ctx).Model(&models.OutboxEvent{}).
+		Select("COALESCE(MAX(publish_seq), 0)").
+		Scan(&seq).Error
+
+	return seq, err
+}
+
+// writeOutbox записывает событие в outbox в рамках транзакции БД, изменяющей данные.
+// Внутренняя функция, используется репозиториями, публикующими события.
+func writeOutbox(tx *gorm.DB, eventType string, orderingKeys []string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding %s event: %w", eventType, err)
+	}
+
+	event := models.OutboxEvent{EventType: eventType, OrderingKeys: orderingKeys, Payload: data}
+	if err = tx.Create(&event).Error; err != nil {
+		return fmt.Errorf("error writing %s event to outbox: %w", eventType, err)
+	}
+	return nil
+}
@@ -0,0 +1,418 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// pendingTransferRepository реализует интерфейс PendingTransferRepository для PostgreSQL.
+// Выполнение одобренного перевода проходит теми же шагами, что и walletRepository.Transfer.
+//
+// Порядок блокировок: строка отложенного перевода, затем строки кошельков (в порядке возрастания адреса).
+type pendingTransferRepository struct {
+	db      *gorm.DB          // Экземпляр GORM для работы с БД
+	wallets *walletRepository // Шаги перевода при выполнении одобренного перевода
+}
+
+// NewPendingTransferRepository создает новый экземпляр репозитория отложенных переводов.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//   - limits: источник лимитов расходов (одобренный перевод проверяется по лимитам при выполнении)
+//
+// Возвращает:
+//   - repository.PendingTransferRepository: реализацию интерфейса репозитория
+func NewPendingTransferRepository(db *gorm.DB, limits repository.LimitProvider) repository.PendingTransferRepository {
+	return &pendingTransferRepository{db: db, wallets: &walletRepository{db: db, limits: limits}}
+}
+
+// CreatePendingTransfer резервирует расчетную сумму списания на кошельке отправителя
+// и сохраняет отложенный перевод. Номер подписанного перевода сохраняется как номер
+// последнего перевода отправителя, поэтому запрос нельзя повторить, пока перевод ожидает решения.
+// Уникальный индекс по ключу идемпотентности гарантирует, что из конкурентных
+// запросов с одним ключом будет отложен только один перевод.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transfer: отложенный перевод (Reserved заполняется здесь значением Debit)
+//
+// Возвращает:
+//   - error: ошибка при создании:
+//   - er.ErrWalletSenderNotFound: кошелек отправителя не найден
+//   - er.ErrWalletSenderFrozen: списания с кошелька заблокированы
+//   - er.ErrCurrencyMismatch: валюта перевода не совпадает с валютой кошелька
+//   - er.ErrNonceReused: номер подписанного перевода не больше номера последнего перевода
+//   - er.ErrNotEnoughMoney: недостаточно доступных средств
+//   - er.ErrIdempotencyKeyExists: ключ уже сохранен конкурентным запросом
+//   - другие ошибки базы данных
+func (r *pendingTransferRepository) CreatePendingTransfer(ctx context.Context, transfer *models.PendingTransfer) error {
+	return withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Сбрасываем идентификатор, присвоенный в откаченной попытке
+			transfer.ID = 0
+			transfer.Reserved = transfer.Debit
+
+			var wallet models.Wallet
+			if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+				First(&wallet, "address = ?", transfer.From).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return er.ErrWalletSenderNotFound
+				}
+				return fmt.Errorf("error blocking wallet: %w", err)
+			}
+
+			if !wallet.Status.CanSend() {
+				return er.ErrWalletSenderFrozen
+			}
+
+			if wallet.Currency != transfer.Currency {
+				return er.ErrCurrencyMismatch
+			}
+
+			if transfer.Nonce != nil && *transfer.Nonce <= wallet.Nonce {
+				return er.ErrNonceReused
+			}
+
+			if wallet.Available().LessThan(transfer.Reserved) {
+				return er.ErrNotEnoughMoney
+			}
+
+			updates := map[string]any{"held": gorm.Expr("held + ?", transfer.Reserved)}
+			if transfer.Nonce != nil {
+				updates["nonce"] = *transfer.Nonce
+			}
+
+			if err := tx.Model(&wallet).Updates(updates).Error; err != nil {
+				return fmt.Errorf("error reserving funds: %w", err)
+			}
+
+			if err := tx.Create(transfer).Error; err != nil {
+				if isPgError(err, pgUniqueViolation) {
+					return er.ErrIdempotencyKeyExists
+				}
+				return fmt.Errorf("error creating pending transfer: %w", err)
+			}
+
+			return nil
+		})
+	})
+}
+
+// PendingTransfer возвращает отложенный перевод по публичному идентификатору вместе с решениями согласующих.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор перевода (UUID)
+//
+// Возвращает:
+//   - *models.PendingTransfer: отложенный перевод
+//   - error: er.ErrPendingTransferNotFound или другие ошибки базы данных
+func (r *pendingTransferRepository) PendingTransfer(ctx context.Context,
+	publicID string) (*models.PendingTransfer, error) {
+	return r.first(r.db.WithContext(ctx), "public_id = ?", publicID)
+}
+
+// PendingTransferByIdempotencyKey возвращает отложенный перевод по ключу идемпотентности
+// запроса учетной записи.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - accountID: публичный идентификатор учетной записи вызывающего
+//     (пустая строка - привилегированный клиент без учетной записи)
+//   - key: значение заголовка Idempotency-Key
+//
+// Возвращает:
+//   - *models.PendingTransfer: отложенный перевод
+//   - error: er.ErrPendingTransferNotFound или другие ошибки базы данных
+func (r *pendingTransferRepository) PendingTransferByIdempotencyKey(ctx context.Context,
+	accountID, key string) (*models.PendingTransfer, error) {
+	// Условие совпадает с выражением уникального индекса idx_pending_transfers_idempotency_key
+	return r.first(r.db.WithContext(ctx), "COALESCE(account_id::text, '') = ? AND idempotency_key = ?",
+		accountID, key)
+}
+
+// PendingTransfers возвращает отложенные переводы в указанном статусе от старых к новым
+// вместе с решениями согласующих.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - status: статус переводов
+//   - limit: максимальное число переводов
+//
+// Возвращает:
+//   - []models.PendingTransfer: отложенные переводы
+//   - error: ошибка базы данных
+func (r *pendingTransferRepository) PendingTransfers(ctx context.Context, status models.PendingTransferStatus,
+	limit int) ([]models.PendingTransfer, error) {
+	var transfers []models.PendingTransfer
+	err := r.db.WithContext(ctx).
+		Preload("Decisions", orderDecisions).
+		Where("status = ?", status).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&transfers).Error
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// ApprovePendingTransfer сохраняет одобрение отложенного перевода. Если одобрение последнее
+// из требуемых, перевод выполняется в той же транзакции БД: списание снимает резерв перевода,
+// а при ошибке перевода одобрение не сохраняется и перевод остается в ожидании.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор отложенного перевода
+//   - decision: одобрение (AccountID, KeyID, Comment); PendingTransferID заполняется здесь
+//   - transaction: подготовленная транзакция перевода (From и To отложенного перевода, без номера подписи)
+//
+// Возвращает:
+//   - *models.PendingTransfer: перевод после одобрения (статус executed, если перевод выполнен)
+//   - error: ошибка при одобрении:
+//   - er.ErrPendingTransferNotFound: перевод не найден
+//   - er.ErrPendingTransferNotPending: перевод уже выполнен или отклонен
+//   - er.ErrAlreadyDecided: учетная запись уже приняла решение по переводу
+//   - ошибки перевода (см. walletRepository.Transfer)
+func (r *pendingTransferRepository) ApprovePendingTransfer(ctx context.Context, publicID string,
+	decision *models.PendingTransferDecision, transaction *models.Transaction) (*models.PendingTransfer, error) {
+	var approved *models.PendingTransfer
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			transfer, err := r.lockPending(tx, publicID)
+			if err != nil {
+				return err
+			}
+
+			if err = r.decide(tx, transfer, decision); err != nil {
+				return err
+			}
+
+			if transfer.Approvals() < transfer.RequiredApprovals {
+				approved = transfer
+				return nil
+			}
+
+			if transaction.From != transfer.From || transaction.To != transfer.To {
+				return fmt.Errorf("transaction does not match pending transfer %s", publicID)
+			}
+
+			if err = r.wallets.transfer(tx, transaction, nil, transfer.Reserved); err != nil {
+				return err
+			}
+
+			transfer.Status = models.PendingTransferExecuted
+			transfer.TransactionID = &transaction.PublicID
+
+			if err = r.update(tx, transfer, map[string]any{
+				"status":         transfer.Status,
+				"transaction_id": transfer.TransactionID,
+			}); err != nil {
+				return err
+			}
+
+			approved = transfer
+			return nil
+		})
+	})
+
+	return approved, err
+}
+
+// RejectPendingTransfer сохраняет отказ по отложенному переводу, отклоняет перевод
+// и снимает его резерв с кошелька отправителя.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор отложенного перевода
+//   - decision: отказ (AccountID, KeyID, Comment); PendingTransferID заполняется здесь
+//
+// Возвращает:
+//   - *models.PendingTransfer: отклоненный перевод
+//   - error: er.ErrPendingTransferNotFound, er.ErrPendingTransferNotPending, er.ErrAlreadyDecided
+//     или другие ошибки базы данных
+func (r *pendingTransferRepository) RejectPendingTransfer(ctx context.Context, publicID string,
+	decision *models.PendingTransferDecision) (*models.PendingTransfer, error) {
+	var rejected *models.PendingTransfer
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			transfer, err := r.lockPending(tx, publicID)
+			if err != nil {
+				return err
+			}
+
+			if err = r.decide(tx, transfer, decision); err != nil {
+				return err
+			}
+
+			if err = tx.Model(&models.Wallet{}).
+				Where("address = ?", transfer.From).
+				Update("held", gorm.Expr("held - ?", transfer.Reserved)).Error; err != nil {
+				return fmt.Errorf("error releasing reserved funds: %w", err)
+			}
+
+			transfer.Status = models.PendingTransferRejected
+			if err = r.update(tx, transfer, map[string]any{"status": transfer.Status}); err != nil {
+				return err
+			}
+
+			rejected = transfer
+			return nil
+		})
+	})
+
+	return rejected, err
+}
+
+// ApprovalPolicy возвращает политику согласования кошелька.
+// Кошелек без сохраненной политики получает политику по умолчанию (models.DefaultApprovalPolicy).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - *models.ApprovalPolicy: политика согласования
+//   - error: er.ErrWalletNotFound, если кошелек не существует или закрыт, или другие ошибки базы данных
+func (r *pendingTransferRepository) ApprovalPolicy(ctx context.Context, address string) (*models.ApprovalPolicy, error) {
+	db := r.db.WithContext(ctx)
+
+	var wallet models.Wallet
+	if err := db.First(&wallet, "address = ?", address).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	var policies []models.ApprovalPolicy
+	if err := db.Where("wallet_id = ?", wallet.ID).Limit(1).Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("error getting approval policy: %w", err)
+	}
+
+	if len(policies) == 0 {
+		policy := models.DefaultApprovalPolicy()
+		policy.WalletID = wallet.ID
+		return policy, nil
+	}
+
+	return &policies[0], nil
+}
+
+// SetApprovalPolicy сохраняет политику согласования кошелька, заменяя прежнюю.
+// Новая политика действует со следующего перевода; отложенные переводы согласуются
+// по политике на момент запроса.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//   - policy: политика согласования (WalletID заполняется здесь)
+//
+// Возвращает:
+//   - error: er.ErrWalletNotFound, если кошелек не существует или закрыт, или другие ошибки базы данных
+func (r *pendingTransferRepository) SetApprovalPolicy(ctx context.Context, address string,
+	policy *models.ApprovalPolicy) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var wallet models.Wallet
+		if err := tx.First(&wallet, "address = ?", address).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return er.ErrWalletNotFound
+			}
+			return err
+		}
+
+		policy.WalletID = wallet.ID
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "wallet_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"updated_at", "threshold", "required_approvals", "approvers"}),
+		}).Create(policy).Error; err != nil {
+			return fmt.Errorf("error saving approval policy: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// lockPending блокирует строку отложенного перевода и проверяет, что перевод ожидает решения.
+// Внутренний метод, используется при одобрении и отклонении.
+func (r *pendingTransferRepository) lockPending(tx *gorm.DB, publicID string) (*models.PendingTransfer, error) {
+	transfer, err := r.first(tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}),
+		"public_id = ?", publicID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != models.PendingTransferPending {
+		return nil, fmt.Errorf("%w: transfer is %s", er.ErrPendingTransferNotPending, transfer.Status)
+	}
+
+	return transfer, nil
+}
+
+// decide сохраняет решение согласующего и добавляет его к решениям перевода.
+// Внутренний метод, используется при одобрении и отклонении.
+func (r *pendingTransferRepository) decide(tx *gorm.DB, transfer *models.PendingTransfer,
+	decision *models.PendingTransferDecision) error {
+	// Сбрасываем идентификатор, присвоенный в откаченной попытке
+	decision.ID = 0
+	decision.PendingTransferID = transfer.ID
+
+	if err := tx.Create(decision).Error; err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return er.ErrAlreadyDecided
+		}
+		return fmt.Errorf("error saving pending transfer decision: %w", err)
+	}
+
+	transfer.Decisions = append(transfer.Decisions, *decision)
+	return nil
+}
+
+// update изменяет столбцы строки отложенного перевода. Решения согласующих не сохраняются
+// повторно, поэтому изменение выполняется по идентификатору, а не по модели с решениями.
+// Внутренний метод, используется при одобрении и отклонении.
+func (r *pendingTransferRepository) update(tx *gorm.DB, transfer *models.PendingTransfer,
+	columns map[string]any) error {
+	if err := tx.Model(&models.PendingTransfer{}).Where("id = ?", transfer.ID).
+		Updates(columns).Error; err != nil {
+		return fmt.Errorf("error updating pending transfer: %w", err)
+	}
+	return nil
+}
+
+// first возвращает отложенный перевод по условию вместе с решениями согласующих.
+func (r *pendingTransferRepository) first(db *gorm.DB, query string, args ...any) (*models.PendingTransfer, error) {
+	var transfer models.PendingTransfer
+	if err := db.Where(query, args...).First(&transfer).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrPendingTransferNotFound
+		}
+		return nil, err
+	}
+
+	// Решения загружаются отдельным запросом: FOR UPDATE не применяется к предзагрузке
+	if err := db.Session(&gorm.Session{NewDB: true}).
+		Scopes(orderDecisions).
+		Where("pending_transfer_id = ?", transfer.ID).
+		Find(&transfer.Decisions).Error; err != nil {
+		return nil, fmt.Errorf("error getting pending transfer decisions: %w", err)
+	}
+
+	return &transfer, nil
+}
+
+// orderDecisions упорядочивает решения согласующих по времени.
+func orderDecisions(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
@@ -0,0 +1,21 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Коды ошибок PostgreSQL (SQLSTATE), которые обрабатываются репозиториями.
+const (
+	pgUniqueViolation      = "23505"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// isPgError проверяет, что ошибка является ошибкой PostgreSQL с указанным кодом SQLSTATE.
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -0,0 +1,86 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// rateLimitStore реализует интерфейс RateLimitStore для PostgreSQL.
+// Корзины разделяются всеми экземплярами приложения, подключенными к одной БД.
+type rateLimitStore struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewRateLimitStore создает новый экземпляр хранилища корзин токенов в БД.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.RateLimitStore: реализацию интерфейса хранилища
+func NewRateLimitStore(db *gorm.DB) repository.RateLimitStore {
+	return &rateLimitStore{db: db}
+}
+
+// Take атомарно забирает токен из корзины ключа. Корзина блокируется (SELECT ... FOR UPDATE)
+// на время пересчета, поэтому одновременные запросы разных экземпляров не расходуют один токен дважды.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - key: ключ корзины
+//   - limit: ограничение частоты
+//
+// Возвращает:
+//   - models.RateLimitDecision: решение и остаток корзины
+//   - error: ошибка базы данных
+func (s *rateLimitStore) Take(ctx context.Context, key string, limit models.RateLimit) (models.RateLimitDecision, error) {
+	var decision models.RateLimitDecision
+
+	err := withRetry(ctx, func() error {
+		return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			now := time.Now()
+
+			// Новая корзина создается заполненной; если ее одновременно создал другой запрос,
+			// вставка пропускается, а блокировка ниже дождется его транзакции
+			fresh := models.RateLimitBucket{Key: key, Tokens: float64(limit.Burst), CheckedAt: now, FullAt: now}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&fresh).Error; err != nil {
+				return err
+			}
+
+			var bucket models.RateLimitBucket
+			if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+				First(&bucket, "key = ?", key).Error; err != nil {
+				return err
+			}
+
+			decision = limit.Take(&bucket, now)
+
+			return tx.Model(&models.RateLimitBucket{}).Where("key = ?", key).Updates(map[string]any{
+				"tokens":     bucket.Tokens,
+				"checked_at": bucket.CheckedAt,
+				"full_at":    bucket.FullAt,
+			}).Error
+		})
+	})
+
+	return decision, err
+}
+
+// Sweep удаляет заполненные корзины.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - int64: число удаленных корзин
+//   - error: ошибка базы данных
+func (s *rateLimitStore) Sweep(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Where("full_at <= ?", time.Now()).Delete(&models.RateLimitBucket{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,59 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"math/rand/v2"
+	"time"
+)
+
+// Параметры повтора транзакций БД, прерванных из-за конфликта блокировок.
+const (
+	maxTxAttempts  = 5                      // Максимальное количество попыток (включая первую)
+	baseRetryDelay = 10 * time.Millisecond  // Задержка перед первым повтором
+	maxRetryDelay  = 200 * time.Millisecond // Верхняя граница задержки
+)
+
+// withRetry выполняет транзакцию БД и повторяет ее при взаимоблокировке (40P01)
+// или ошибке сериализации (40001). PostgreSQL откатывает такую транзакцию целиком,
+// поэтому повтор безопасен. Задержка растет экспоненциально со случайным разбросом,
+// чтобы конкурирующие транзакции не повторялись синхронно.
+//
+// Параметры:
+//   - ctx: контекст выполнения (отмена прерывает ожидание между попытками)
+//   - fn: функция, выполняющая транзакцию БД целиком
+//
+// Возвращает:
+//   - error: ошибка последней попытки, ошибка контекста или, если конфликт не разрешился
+//     за maxTxAttempts попыток, er.ErrConcurrentUpdate вместе с ошибкой последней попытки
+func withRetry(ctx context.Context, fn func() error) error {
+	delay := baseRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if attempt == maxTxAttempts {
+			return fmt.Errorf("%w: %w", er.ErrConcurrentUpdate, err)
+		}
+
+		// Случайная задержка в диапазоне [delay/2, delay)
+		jittered := delay/2 + rand.N(delay/2)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(jittered):
+		}
+
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
+// isRetryable сообщает, можно ли повторить транзакцию, завершившуюся ошибкой.
+func isRetryable(err error) bool {
+	return isPgError(err, pgDeadlockDetected) || isPgError(err, pgSerializationFailure)
+}
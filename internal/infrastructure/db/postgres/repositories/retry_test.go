@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"testing"
+)
+
+// TestWithRetryRetriesLockConflicts проверяет, что взаимоблокировка и ошибка сериализации повторяются.
+func TestWithRetryRetriesLockConflicts(t *testing.T) {
+	for _, code := range []string{pgDeadlockDetected, pgSerializationFailure} {
+		t.Run(code, func(t *testing.T) {
+			attempts := 0
+			err := withRetry(context.Background(), func() error {
+				attempts++
+				if attempts < 3 {
+					return fmt.Errorf("error transferring: %w", &pgconn.PgError{Code: code})
+				}
+				return nil
+			})
+
+			if err != nil {
+				t.Fatalf("withRetry() error = %v, want nil", err)
+			}
+			if attempts != 3 {
+				t.Fatalf("attempts = %d, want 3", attempts)
+			}
+		})
+	}
+}
+
+// TestWithRetryAttemptBound проверяет, что число попыток ограничено maxTxAttempts
+// и возвращается ErrConcurrentUpdate вместе с ошибкой последней попытки.
+func TestWithRetryAttemptBound(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), func() error {
+		attempts++
+		return &pgconn.PgError{Code: pgDeadlockDetected}
+	})
+
+	if !errors.Is(err, er.ErrConcurrentUpdate) || !isPgError(err, pgDeadlockDetected) {
+		t.Fatalf("withRetry() error = %v, want %v with the deadlock error", err, er.ErrConcurrentUpdate)
+	}
+	if attempts != maxTxAttempts {
+		t.Fatalf("attempts = %d, want %d", attempts, maxTxAttempts)
+	}
+}
+
+// TestWithRetryOtherErrors проверяет, что прочие ошибки не повторяются.
+func TestWithRetryOtherErrors(t *testing.T) {
+	for name, failure := range map[string]error{
+		"unique violation": &pgconn.PgError{Code: pgUniqueViolation},
+		"plain error":      errors.New("not enough money"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			err := withRetry(context.Background(), func() error {
+				attempts++
+				return failure
+			})
+
+			if !errors.Is(err, failure) {
+				t.Fatalf("withRetry() error = %v, want %v", err, failure)
+			}
+			if attempts != 1 {
+				t.Fatalf("attempts = %d, want 1", attempts)
+			}
+		})
+	}
+}
+
+// TestWithRetryContextCanceled проверяет, что отмена контекста прерывает ожидание повтора.
+func TestWithRetryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := withRetry(ctx, func() error {
+		attempts++
+		cancel()
+		return &pgconn.PgError{Code: pgSerializationFailure}
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("withRetry() error = %v, want context.Canceled", err)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1", attempts)
+	}
+}
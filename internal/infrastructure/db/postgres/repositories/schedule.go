@@ -0,0 +1,262 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// scheduledTransferRepository реализует интерфейс ScheduledTransferRepository для PostgreSQL.
+//
+// Несколько экземпляров планировщика могут работать параллельно: перевод захватывается
+// арендой (claim_token, claimed_until), строки выбираются с SKIP LOCKED, поэтому один
+// перевод одновременно выполняет только один экземпляр.
+type scheduledTransferRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewScheduledTransferRepository создает новый экземпляр репозитория запланированных переводов.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.ScheduledTransferRepository: реализацию интерфейса репозитория
+func NewScheduledTransferRepository(db *gorm.DB) repository.ScheduledTransferRepository {
+	return &scheduledTransferRepository{db: db}
+}
+
+// CreateScheduledTransfer сохраняет новый запланированный перевод.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transfer: запланированный перевод
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *scheduledTransferRepository) CreateScheduledTransfer(ctx context.Context,
+	transfer *models.ScheduledTransfer) error {
+	if err := r.db.WithContext(ctx).Create(transfer).Error; err != nil {
+		return fmt.Errorf("error creating scheduled transfer: %w", err)
+	}
+	return nil
+}
+
+// ScheduledTransfer возвращает запланированный перевод по его публичному идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор перевода (UUID)
+//
+// Возвращает:
+//   - *models.ScheduledTransfer: найденный перевод
+//   - error: er.ErrScheduledTransferNotFound или другие ошибки базы данных
+func (r *scheduledTransferRepository) ScheduledTransfer(ctx context.Context,
+	publicID string) (*models.ScheduledTransfer, error) {
+	var transfer models.ScheduledTransfer
+
+	err := r.db.WithContext(ctx).First(&transfer, "public_id = ?", publicID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrScheduledTransferNotFound
+		}
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// ScheduledTransfers возвращает запланированные переводы кошелька-отправителя
+// в порядке создания.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька-отправителя
+//
+// Возвращает:
+//   - []models.ScheduledTransfer: запланированные переводы
+//   - error: ошибка базы данных
+func (r *scheduledTransferRepository) ScheduledTransfers(ctx context.Context,
+	address string) ([]models.ScheduledTransfer, error) {
+	var transfers []models.ScheduledTransfer
+	err := r.db.WithContext(ctx).
+		Where(`"from" = ?`, address).
+		Order("created_at, id").
+		Find(&transfers).Error
+
+	return transfers, err
+}
+
+// UpdateScheduledTransfer сохраняет изменения параметров запланированного перевода:
+// сумму, период, окончание, статус и расписание (текущее выполнение, время следующей
+// попытки и число попыток, которые сбрасываются при возобновлении).
+// Аренда не изменяется: если перевод выполняется, результат попытки будет записан.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transfer: перевод с измененными параметрами
+//
+// Возвращает:
+//   - error: er.ErrScheduledTransferFinished, если перевод уже завершен или отменен,
+//     или другие ошибки базы данных
+func (r *scheduledTransferRepository) UpdateScheduledTransfer(ctx context.Context,
+	transfer *models.ScheduledTransfer) error {
+	result := r.db.WithContext(ctx).Model(transfer).
+		Where("status IN ?", []models.ScheduledTransferStatus{
+			models.ScheduledTransferActive, models.ScheduledTransferPaused}).
+		Select("amount", "interval_seconds", "end_at", "status", "occurrence_at", "next_run_at", "attempts").
+		Updates(transfer)
+	if result.Error != nil {
+		return fmt.Errorf("error updating scheduled transfer: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return er.ErrScheduledTransferFinished
+	}
+	return nil
+}
+
+// Runs возвращает последние попытки выполнения запланированного перевода (от новых к старым).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - scheduledTransferID: внутренний идентификатор запланированного перевода
+//   - limit: максимальное количество попыток
+//
+// Возвращает:
+//   - []models.ScheduledTransferRun: попытки выполнения
+//   - error: ошибка базы данных
+func (r *scheduledTransferRepository) Runs(ctx context.Context, scheduledTransferID uint,
+	limit int) ([]models.ScheduledTransferRun, error) {
+	var runs []models.ScheduledTransferRun
+	err := r.db.WithContext(ctx).
+		Where("scheduled_transfer_id = ?", scheduledTransferID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&runs).Error
+
+	return runs, err
+}
+
+// ClaimDue захватывает активные переводы, срок попытки которых наступил, на срок lease.
+// Строки выбираются с FOR UPDATE SKIP LOCKED, а переводы с действующей арендой
+// пропускаются, поэтому экземпляры планировщика не захватывают один перевод дважды.
+// Если экземпляр не завершит выполнение до окончания аренды, перевод захватит другой.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - now: текущее время
+//   - lease: срок аренды
+//   - limit: максимальное количество переводов за вызов
+//
+// Возвращает:
+//   - []models.ScheduledTransfer: захваченные переводы (с заполненным ClaimToken)
+//   - error: ошибка базы данных
+func (r *scheduledTransferRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]models.ScheduledTransfer, error) {
+	var transfers []models.ScheduledTransfer
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			transfers = nil
+			if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: "SKIP LOCKED"}).
+				Where("status = ? AND next_run_at <= ?", models.ScheduledTransferActive, now).
+				Where("claimed_until IS NULL OR claimed_until < ?", now).
+				Order("next_run_at").
+				Limit(limit).
+				Find(&transfers).Error; err != nil {
+				return fmt.Errorf("error selecting due scheduled transfers: %w", err)
+			}
+
+			if len(transfers) == 0 {
+				return nil
+			}
+
+			token := uuid.NewString()
+			claimedUntil := now.Add(lease)
+
+			ids := make([]uint, 0, len(transfers))
+			for i := range transfers {
+				ids = append(ids, transfers[i].ID)
+				transfers[i].ClaimToken = &token
+				transfers[i].ClaimedUntil = &claimedUntil
+			}
+
+			if err := tx.Model(&models.ScheduledTransfer{}).
+				Where("id IN ?", ids).
+				Updates(map[string]any{"claim_token": token, "claimed_until": claimedUntil}).Error; err != nil {
+				return fmt.Errorf("error claiming scheduled transfers: %w", err)
+			}
+
+			return nil
+		})
+	})
+
+	return transfers, err
+}
+
+// CompleteRun записывает попытку выполнения и сохраняет состояние перевода после нее
+// (следующее выполнение, число попыток, статус), снимая аренду.
+// Состояние сохраняется, только если аренда все еще принадлежит вызывающему;
+// статус меняется, только если перевод не был приостановлен или отменен во время попытки.
+// Попытка записывается в любом случае.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transfer: захваченный перевод с новым состоянием
+//   - run: результат попытки
+//
+// Возвращает:
+//   - error: er.ErrScheduledTransferClaimLost, если аренда истекла и перевод захвачен заново,
+//     или другие ошибки базы данных
+func (r *scheduledTransferRepository) CompleteRun(ctx context.Context, transfer *models.ScheduledTransfer,
+	run *models.ScheduledTransferRun) error {
+	var claimed bool
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			// Сбрасываем идентификатор, присвоенный в откаченной попытке
+			run.ID = 0
+			run.ScheduledTransferID = transfer.ID
+
+			if err := tx.Create(run).Error; err != nil {
+				return fmt.Errorf("error recording scheduled transfer run: %w", err)
+			}
+
+			result := tx.Model(&models.ScheduledTransfer{}).
+				Where("id = ? AND claim_token = ?", transfer.ID, transfer.ClaimToken).
+				Updates(map[string]any{
+					"occurrence_at": transfer.OccurrenceAt,
+					"next_run_at":   transfer.NextRunAt,
+					"attempts":      transfer.Attempts,
+					"status": gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END",
+						models.ScheduledTransferActive, transfer.Status),
+					"claim_token":   nil,
+					"claimed_until": nil,
+				})
+			if result.Error != nil {
+				return fmt.Errorf("error updating scheduled transfer: %w", result.Error)
+			}
+
+			claimed = result.RowsAffected > 0
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return er.ErrScheduledTransferClaimLost
+	}
+	return nil
+}
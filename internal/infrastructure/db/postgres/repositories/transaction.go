@@ -4,15 +4,26 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
 	"github.com/normalniydada/case_infotecs/internal/domain/models"
 	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 // transactionRepository реализует интерфейс TransactionRepository для работы с транзакциями в PostgreSQL.
 // Использует GORM для взаимодействия с базой данных.
+// Возврат выполняет перевод теми же шагами, что и walletRepository.Transfer;
+// лимиты расходов к возвратам не применяются.
+//
+// Порядок блокировок при возврате: строка исходной транзакции, затем строки кошельков.
 type transactionRepository struct {
-	db *gorm.DB // Экземпляр GORM для работы с БД
+	db      *gorm.DB          // Экземпляр GORM для работы с БД
+	wallets *walletRepository // Шаги перевода при возврате
 }
 
 // NewTransactionRepository создает новый экземпляр репозитория транзакций.
@@ -23,7 +34,7 @@ type transactionRepository struct {
 // Возвращает:
 //   - repository.TransactionRepository: реализацию интерфейса репозитория
 func NewTransactionRepository(db *gorm.DB) repository.TransactionRepository {
-	return &transactionRepository{db: db}
+	return &transactionRepository{db: db, wallets: &walletRepository{db: db}}
 }
 
 // LastNTransactions возвращает последние N транзакций из базы данных,
@@ -54,3 +65,189 @@ func (r *transactionRepository) LastNTransactions(ctx context.Context, n int) ([
 
 	return transactions, err
 }
+
+// Transaction возвращает транзакцию по ее публичному идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор транзакции (UUID)
+//
+// Возвращает:
+//   - *models.Transaction: найденная транзакция
+//   - error: ошибка при поиске:
+//   - er.ErrTransactionIDNotFound: если транзакция не существует
+//   - другие ошибки базы данных
+func (r *transactionRepository) Transaction(ctx context.Context, publicID string) (*models.Transaction, error) {
+	var transaction models.Transaction
+
+	err := r.db.WithContext(ctx).First(&transaction, "public_id = ?", publicID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrTransactionIDNotFound
+		}
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// WalletTransactions возвращает страницу истории транзакций кошелька.
+// Выборка упорядочена по (created_at, id) от новых к старым и использует
+// keyset-пагинацию: следующая страница начинается строго после filter.After.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - filter: параметры выборки (направление, диапазоны дат и сумм, курсор, размер страницы)
+//
+// Возвращает:
+//   - []models.Transaction: транзакции страницы
+//   - error: ошибка при выполнении запроса
+//
+// Особенности:
+//   - Использует индексы (from, created_at, id) и (to, created_at, id)
+func (r *transactionRepository) WalletTransactions(ctx context.Context,
+	filter repository.TransactionFilter) ([]models.Transaction, error) {
+	query := r.db.WithContext(ctx).Model(&models.Transaction{})
+
+	switch filter.Direction {
+	case repository.DirectionIncoming:
+		query = query.Where(`"to" = ?`, filter.Address)
+	case repository.DirectionOutgoing:
+		query = query.Where(`"from" = ?`, filter.Address)
+	default:
+		query = query.Where(`("from" = ? OR "to" = ?)`, filter.Address, filter.Address)
+	}
+
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.After != nil {
+		query = query.Where("(created_at, id) < (?, ?)", filter.After.CreatedAt, filter.After.ID)
+	}
+
+	var transactions []models.Transaction
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(filter.Limit).
+		Find(&transactions).Error
+
+	return transactions, err
+}
+
+// Refunds возвращает возвраты исходной транзакции в порядке их создания.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор исходной транзакции
+//
+// Возвращает:
+//   - []models.Transaction: транзакции-возвраты (пустой список, если возвратов не было)
+//   - error: ошибка при выполнении запроса
+func (r *transactionRepository) Refunds(ctx context.Context, publicID string) ([]models.Transaction, error) {
+	var refunds []models.Transaction
+	err := r.db.WithContext(ctx).
+		Where("refund_of = ?", publicID).
+		Order("created_at, id").
+		Find(&refunds).Error
+
+	return refunds, err
+}
+
+// OutgoingSince возвращает число и сумму исходящих переводов кошелька (без возвратов),
+// созданных начиная с since. Сумма - в валюте кошелька, без комиссий.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька отправителя
+//   - since: начало периода (включительно)
+//
+// Возвращает:
+//   - int64: число переводов
+//   - decimal.Decimal: сумма переводов
+//   - error: ошибка при выполнении запроса
+func (r *transactionRepository) OutgoingSince(ctx context.Context, address string,
+	since time.Time) (int64, decimal.Decimal, error) {
+	var outgoing struct {
+		Count  int64
+		Amount decimal.Decimal
+	}
+
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) AS count, COALESCE(SUM(amount), 0) AS amount
+		FROM transactions
+		WHERE "from" = ? AND created_at >= ? AND refund_of IS NULL AND deleted_at IS NULL`,
+		address, since).
+		Scan(&outgoing).Error
+	if err != nil {
+		return 0, decimal.Zero, fmt.Errorf("error getting outgoing transfers: %w", err)
+	}
+
+	return outgoing.Count, outgoing.Amount, nil
+}
+
+// Refund выполняет возврат: переводит средства от получателя исходной транзакции
+// ее отправителю и увеличивает возвращенную сумму исходной транзакции.
+// Строка исходной транзакции блокируется, поэтому конкурентные возвраты одной
+// транзакции выполняются по очереди и не превышают ее сумму в сумме.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - refund: подготовленная транзакция возврата (RefundOf - PublicID исходной транзакции,
+//     TargetAmount - возвращаемая сумма в валюте отправителя исходной транзакции)
+//
+// Возвращает:
+//   - error: ошибка при возврате:
+//   - er.ErrTransactionIDNotFound: исходная транзакция не найдена
+//   - er.ErrRefundOfRefund: исходная транзакция сама является возвратом
+//   - er.ErrRefundExceedsOriginal: возврат превысит сумму исходной транзакции
+//   - er.ErrRefundNotEnoughMoney: у получателя недостаточно средств
+//   - ошибки перевода (см. walletRepository.Transfer)
+func (r *transactionRepository) Refund(ctx context.Context, refund *models.Transaction) error {
+	return withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var original models.Transaction
+			if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+				First(&original, "public_id = ?", *refund.RefundOf).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return er.ErrTransactionIDNotFound
+				}
+				return fmt.Errorf("error blocking transaction: %w", err)
+			}
+
+			if original.IsRefund() {
+				return er.ErrRefundOfRefund
+			}
+
+			if refund.From != original.To || refund.To != original.From {
+				return fmt.Errorf("refund does not match transaction %s", original.PublicID)
+			}
+
+			if refund.TargetAmount.GreaterThan(original.Refundable()) {
+				return er.ErrRefundExceedsOriginal
+			}
+
+			if err := r.wallets.transfer(tx, refund, nil, decimal.Zero); err != nil {
+				if errors.Is(err, er.ErrNotEnoughMoney) {
+					return er.ErrRefundNotEnoughMoney
+				}
+				return err
+			}
+
+			if err := tx.Model(&original).
+				Update("refunded_amount", gorm.Expr("refunded_amount + ?", refund.TargetAmount)).Error; err != nil {
+				return fmt.Errorf("error updating refunded amount: %w", err)
+			}
+
+			return nil
+		})
+	})
+}
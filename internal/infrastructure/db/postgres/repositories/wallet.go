@@ -17,22 +17,26 @@ import (
 // walletRepository реализует интерфейс WalletRepository для работы с кошельками в PostgreSQL.
 // Обеспечивает безопасное выполнение операций с блокировками и транзакциями.
 type walletRepository struct {
-	db *gorm.DB // Экземпляр GORM для работы с БД
+	db     *gorm.DB                 // Экземпляр GORM для работы с БД
+	limits repository.LimitProvider // Лимиты расходов по уровням (nil - лимиты не проверяются)
 }
 
 // NewWalletRepository создает новый экземпляр репозитория кошельков.
 //
 // Параметры:
 //   - db: подключение к БД (*gorm.DB)
+//   - limits: источник лимитов расходов по уровням кошельков
 //
 // Возвращает:
 //   - repository.WalletRepository: реализацию интерфейса репозитория
-func NewWalletRepository(db *gorm.DB) repository.WalletRepository {
-	return &walletRepository{db: db}
+func NewWalletRepository(db *gorm.DB, limits repository.LimitProvider) repository.WalletRepository {
+	return &walletRepository{db: db, limits: limits}
 }
 
 // CreateWallet создает новый кошелек в базе данных.
 // Выполняется в транзакции с проверкой уникальности адреса.
+// Ненулевой начальный баланс отражается в журнале проводок
+// как перевод с системного счета выпуска валюты кошелька (models.IssuanceAccount).
 //
 // Параметры:
 //   - ctx: контекст выполнения
@@ -61,10 +65,20 @@ func (r *walletRepository) CreateWallet(ctx context.Context, wallet *models.Wall
 		}
 
 		if err = tx.Create(wallet).Error; err != nil {
+			// Адрес закрытого кошелька или открытый ключ уже заняты
+			if isPgError(err, pgUniqueViolation) {
+				return er.ErrWalletExists
+			}
 			return fmt.Errorf("error creating wallet: %w", err)
 		}
 
-		return nil
+		if !wallet.Balance.IsPositive() {
+			return nil
+		}
+		return postEntries(tx,
+			systemEntry(nil, models.IssuanceAccount(wallet.Currency), models.LedgerEntryDebit, wallet.Balance),
+			walletEntry(nil, wallet.Address, models.LedgerEntryCredit, wallet.Balance, wallet.Balance),
+		)
 	})
 
 	if err != nil {
@@ -102,92 +116,500 @@ func (r *walletRepository) Wallet(ctx context.Context, address string) (*models.
 	return &wallet, nil
 }
 
-// Transfer выполняет перевод средств между кошельками.
-// Операция выполняется атомарно в транзакции.
+// WalletIncludingClosed возвращает кошелек по его адресу, включая закрытые кошельки.
 //
 // Параметры:
 //   - ctx: контекст выполнения
-//   - from: адрес кошелька-отправителя
-//   - to: адрес кошелька-получателя
-//   - amount: сумма перевода
+//   - address: адрес кошелька
 //
 // Возвращает:
-//   - error: ошибка при переводе:
-//   - er.ErrWalletSenderNotFound: отправитель не найден
-//   - er.ErrWalletReceiverNotFound: получатель не найден
-//   - er.ErrNotEnoughMoney: недостаточно средств
+//   - *models.Wallet: найденный кошелек
+//   - error: ошибка при поиске:
+//   - er.ErrWalletNotFound: если кошелек никогда не существовал
+//   - другие ошибки базы данных
+func (r *walletRepository) WalletIncludingClosed(ctx context.Context, address string) (*models.Wallet, error) {
+	var wallet models.Wallet
+
+	err := r.db.WithContext(ctx).Unscoped().First(&wallet, "address = ?", address).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrWalletNotFound
+		}
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// CloseWallet закрывает кошелек: меняет статус на closed и выполняет мягкое удаление.
+// Операция выполняется в транзакции с блокировкой строки кошелька,
+// поэтому параллельный перевод не может пополнить кошелек в момент закрытия.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//
+// Возвращает:
+//   - error: ошибка при закрытии:
+//   - er.ErrWalletNotFound: если кошелек не существует или уже закрыт
+//   - er.ErrWalletNotEmpty: если баланс кошелька не равен нулю
+//   - er.ErrWalletFrozen: если кошелек заморожен
 //   - другие ошибки базы данных
-func (r *walletRepository) Transfer(ctx context.Context, from, to string, amount decimal.Decimal) error {
+func (r *walletRepository) CloseWallet(ctx context.Context, address string) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		sender, receiver, err := r.lockAndValidateWallets(tx, from, to, amount)
-		if err != nil {
-			return err
+		var wallet models.Wallet
+
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			First(&wallet, "address = ?", address).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return er.ErrWalletNotFound
+			}
+			return fmt.Errorf("error blocking wallet: %w", err)
+		}
+
+		if wallet.Status.Frozen() {
+			return er.ErrWalletFrozen
+		}
+
+		if !wallet.Balance.IsZero() {
+			return er.ErrWalletNotEmpty
+		}
+
+		if err := tx.Model(&wallet).Update("status", models.WalletStatusClosed).Error; err != nil {
+			return fmt.Errorf("error updating wallet status: %w", err)
 		}
 
-		if err = r.updateBalance(tx, sender, receiver, amount); err != nil {
-			return err
+		if err := tx.Delete(&wallet).Error; err != nil {
+			return fmt.Errorf("error closing wallet: %w", err)
 		}
 
-		return r.createTransaction(tx, from, to, amount)
+		return nil
 	})
 }
 
-// lockAndValidateWallets блокирует и проверяет кошельки для перевода.
-// Внутренний метод, используется в Transfer.
-func (r *walletRepository) lockAndValidateWallets(tx *gorm.DB, from, to string, amount decimal.Decimal) (*models.Wallet,
-	*models.Wallet, error) {
-	var sender, receiver models.Wallet
+// UpdateWalletStatus изменяет статус кошелька и сохраняет изменение в истории статусов.
+// Строка кошелька блокируется, поэтому изменение статуса упорядочено с переводами:
+// перевод, начатый после заморозки, видит новый статус.
+// Если кошелек уже имеет статус change.ToStatus, ничего не изменяется и история не пополняется.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - address: адрес кошелька
+//   - change: изменение статуса (ToStatus, Reason и субъект); WalletID и FromStatus заполняются здесь
+//
+// Возвращает:
+//   - *models.Wallet: кошелек с новым статусом
+//   - error: er.ErrWalletNotFound, если кошелек не существует или закрыт, или другие ошибки базы данных
+func (r *walletRepository) UpdateWalletStatus(ctx context.Context, address string,
+	change *models.WalletStatusChange) (*models.Wallet, error) {
+	var wallet models.Wallet
 
-	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
-		First(&sender, "address = ?", from).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, er.ErrWalletSenderNotFound
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			First(&wallet, "address = ?", address).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return er.ErrWalletNotFound
+			}
+			return fmt.Errorf("error blocking wallet: %w", err)
+		}
+
+		if wallet.Status == change.ToStatus {
+			return nil
+		}
+
+		change.WalletID = wallet.ID
+		change.FromStatus = wallet.Status
+
+		if err := tx.Model(&wallet).Update("status", change.ToStatus).Error; err != nil {
+			return fmt.Errorf("error updating wallet status: %w", err)
 		}
-		return nil, nil, fmt.Errorf("error blocking sender's wallet: %w", err)
+
+		if err := tx.Create(change).Error; err != nil {
+			return fmt.Errorf("error saving wallet status change: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// WalletStatusChanges возвращает историю статусов кошелька от новых изменений к старым.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - walletID: внутренний идентификатор кошелька
+//
+// Возвращает:
+//   - []models.WalletStatusChange: изменения статуса
+//   - error: ошибка базы данных
+func (r *walletRepository) WalletStatusChanges(ctx context.Context, walletID uint) ([]models.WalletStatusChange, error) {
+	var changes []models.WalletStatusChange
+
+	if err := r.db.WithContext(ctx).
+		Where("wallet_id = ?", walletID).
+		Order("id DESC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("error getting wallet status changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// Transfer выполняет перевод средств между кошельками.
+// Операция выполняется атомарно в транзакции: изменение кэшированных балансов,
+// запись транзакции и сбалансированных проводок (списание у отправителя,
+// зачисление получателю, а при конвертации - проводки по обменным счетам,
+// при комиссии - зачисление сборщику) фиксируются вместе.
+// При взаимоблокировке или ошибке сериализации транзакция повторяется (см. withRetry).
+// После успешного выполнения переданная транзакция содержит заполненные
+// служебные поля (ID, CreatedAt, UpdatedAt), присвоенные при сохранении.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transaction: создаваемая транзакция (From, To, суммы и валюты, PublicID)
+//   - idempotencyKey: результат запроса для сохранения вместе с транзакцией (может быть nil)
+//
+// Возвращает:
+//   - error: ошибка при переводе:
+//   - er.ErrWalletSenderNotFound: отправитель не найден
+//   - er.ErrWalletReceiverNotFound: получатель не найден
+//   - er.ErrWalletSenderFrozen, er.ErrWalletReceiverFrozen: перевод заблокирован статусом кошелька
+//   - er.ErrCurrencyMismatch: валюта кошелька не совпадает с валютой перевода
+//   - er.ErrFeeCollectorNotFound: кошелек-сборщик комиссии не найден
+//   - er.ErrNotEnoughMoney: недостаточно средств (с учетом комиссии)
+//   - *er.LimitExceededError: перевод превышает лимит расходов отправителя
+//   - er.ErrNonceReused: номер подписанного перевода не больше номера последнего перевода отправителя
+//   - er.ErrIdempotencyKeyExists: ключ уже сохранен конкурентным запросом
+//   - другие ошибки базы данных
+func (r *walletRepository) Transfer(ctx context.Context, transaction *models.Transaction,
+	idempotencyKey *models.IdempotencyKey) error {
+	return withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return r.transfer(tx, transaction, idempotencyKey, decimal.Zero)
+		})
+	})
+}
+
+// transfer выполняет шаги перевода внутри транзакции БД.
+// heldRelease - сумма резерва отправителя, снимаемая вместе с переводом
+// (при списании холда); для обычного перевода - ноль.
+// Внутренний метод, используется в Transfer и при списании холда.
+func (r *walletRepository) transfer(tx *gorm.DB, transaction *models.Transaction,
+	idempotencyKey *models.IdempotencyKey, heldRelease decimal.Decimal) error {
+	// Сбрасываем идентификаторы, присвоенные в откаченной попытке
+	transaction.ID = 0
+	if idempotencyKey != nil {
+		idempotencyKey.ID = 0
+	}
+
+	locked, err := r.lockWallets(tx, transferAddresses(transaction))
+	if err != nil {
+		return err
+	}
+
+	if err = r.transferLocked(tx, locked, transaction, heldRelease); err != nil {
+		return err
 	}
 
+	if idempotencyKey == nil {
+		return nil
+	}
+	return r.saveIdempotencyKey(tx, idempotencyKey, transaction)
+}
+
+// TransferBatch атомарно выполняет пакет переводов: либо фиксируются все переводы,
+// либо ни один. Кошельки всех переводов блокируются одним запросом в порядке
+// возрастания адреса до выполнения первого перевода, поэтому пакеты и одиночные
+// переводы с общими кошельками не образуют взаимоблокировку.
+// Переводы выполняются по порядку; каждый следующий видит балансы после предыдущих.
+// Ключ идемпотентности связывается с первой транзакцией пакета.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - transactions: переводы пакета (с общим BatchID)
+//   - idempotencyKey: результат запроса для сохранения вместе с пакетом (может быть nil)
+//
+// Возвращает:
+//   - error: *er.BatchLegError с номером перевода и ошибкой перевода (см. Transfer),
+//     er.ErrIdempotencyKeyExists или другие ошибки базы данных
+func (r *walletRepository) TransferBatch(ctx context.Context, transactions []*models.Transaction,
+	idempotencyKey *models.IdempotencyKey) error {
+	return withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var addresses []string
+			for _, transaction := range transactions {
+				// Сбрасываем идентификаторы, присвоенные в откаченной попытке
+				transaction.ID = 0
+				addresses = append(addresses, transferAddresses(transaction)...)
+			}
+			if idempotencyKey != nil {
+				idempotencyKey.ID = 0
+			}
+
+			locked, err := r.lockWallets(tx, addresses)
+			if err != nil {
+				return err
+			}
+
+			for i, transaction := range transactions {
+				if err = r.transferLocked(tx, locked, transaction, decimal.Zero); err != nil {
+					return &er.BatchLegError{Leg: i, Err: err}
+				}
+			}
+
+			if idempotencyKey == nil {
+				return nil
+			}
+			return r.saveIdempotencyKey(tx, idempotencyKey, transactions[0])
+		})
+	})
+}
+
+// transferWallets содержит заблокированные строки кошельков, участвующих в переводе.
+type transferWallets struct {
+	sender    *models.Wallet
+	receiver  *models.Wallet
+	collector *models.Wallet // Кошелек-сборщик комиссии (nil, если комиссии нет)
+}
+
+// transferAddresses возвращает адреса кошельков, участвующих в переводе.
+func transferAddresses(transaction *models.Transaction) []string {
+	addresses := []string{transaction.From, transaction.To}
+	if transaction.FeeWallet != nil {
+		addresses = append(addresses, *transaction.FeeWallet)
+	}
+	return addresses
+}
+
+// lockWallets блокирует строки кошельков одним запросом SELECT ... FOR UPDATE
+// в порядке возрастания адреса, поэтому встречные переводы A->B и B->A
+// и переводы, зачисляющие комиссию на общий кошелек, захватывают блокировки в одном порядке
+// и не образуют взаимоблокировку. Несуществующие и закрытые кошельки в результат не попадают.
+// Внутренний метод, используется в Transfer и TransferBatch.
+func (r *walletRepository) lockWallets(tx *gorm.DB, addresses []string) (map[string]*models.Wallet, error) {
+	var wallets []models.Wallet
 	if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
-		First(&receiver, "address = ?", to).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil, er.ErrWalletReceiverNotFound
-		}
-		return nil, nil, fmt.Errorf("error blocking receiver's wallet: %w", err)
+		Where("address IN ?", addresses).
+		Order("address").
+		Find(&wallets).Error; err != nil {
+		return nil, fmt.Errorf("error blocking wallets: %w", err)
+	}
+
+	locked := make(map[string]*models.Wallet, len(wallets))
+	for i := range wallets {
+		locked[wallets[i].Address] = &wallets[i]
+	}
+	return locked, nil
+}
+
+// transferLocked выполняет перевод между уже заблокированными кошельками:
+// проверку (в том числе лимитов расходов отправителя), обновление балансов, запись транзакции, проводок и события TransferCompleted в outbox.
+// Значения заблокированных строк обновляются в памяти, чтобы следующий перевод
+// в той же транзакции БД видел актуальные балансы.
+// Внутренний метод, используется в Transfer и TransferBatch.
+func (r *walletRepository) transferLocked(tx *gorm.DB, locked map[string]*models.Wallet,
+	transaction *models.Transaction, heldRelease decimal.Decimal) error {
+	wallets, err := validateTransfer(locked, transaction, heldRelease)
+	if err != nil {
+		return err
+	}
+
+	if err = r.checkLimits(tx, wallets.sender, transaction); err != nil {
+		return err
+	}
+
+	if err = r.updateBalance(tx, wallets, transaction, heldRelease); err != nil {
+		return err
 	}
 
-	if sender.Balance.LessThan(amount) {
-		return nil, nil, er.ErrNotEnoughMoney
+	if err = r.createTransaction(tx, transaction); err != nil {
+		return err
 	}
 
-	return &sender, &receiver, nil
+	if err = r.postTransfer(tx, wallets, transaction); err != nil {
+		return err
+	}
+
+	if err = writeOutbox(tx, models.EventTransferCompleted, transferAddresses(transaction),
+		models.NewTransferCompleted(transaction)); err != nil {
+		return err
+	}
+
+	wallets.sender.Balance = wallets.sender.Balance.Sub(transaction.Debited())
+	wallets.sender.Held = wallets.sender.Held.Sub(heldRelease)
+	if transaction.Nonce != nil {
+		wallets.sender.Nonce = *transaction.Nonce
+	}
+	wallets.receiver.Balance = wallets.receiver.Balance.Add(transaction.TargetAmount)
+	if wallets.collector != nil {
+		wallets.collector.Balance = wallets.collector.Balance.Add(transaction.Fee)
+	}
+
+	return nil
 }
 
-// updateBalance обновляет балансы кошельков после перевода.
+// validateTransfer выбирает из заблокированных строк кошельки перевода и проверяет их.
+// Валюты кошельков повторно сверяются с валютами списания и зачисления под блокировкой.
+// Достаточность средств проверяется по доступному балансу (за вычетом холдов),
+// к которому добавляется снимаемый резерв heldRelease.
+// Статус кошельков проверяется под блокировкой: отправитель должен разрешать списания,
+// получатель - зачисления. Статус сборщика комиссии не проверяется.
+// Номер подписанного перевода должен быть больше номера последнего перевода с кошелька отправителя.
+// Внутренний метод, используется в transferLocked.
+func validateTransfer(locked map[string]*models.Wallet, transaction *models.Transaction,
+	heldRelease decimal.Decimal) (*transferWallets, error) {
+	wallets := transferWallets{sender: locked[transaction.From], receiver: locked[transaction.To]}
+	if transaction.FeeWallet != nil {
+		wallets.collector = locked[*transaction.FeeWallet]
+	}
+
+	if wallets.sender == nil {
+		return nil, er.ErrWalletSenderNotFound
+	}
+
+	if wallets.receiver == nil {
+		return nil, er.ErrWalletReceiverNotFound
+	}
+
+	if !wallets.sender.Status.CanSend() {
+		return nil, er.ErrWalletSenderFrozen
+	}
+
+	if !wallets.receiver.Status.CanReceive() {
+		return nil, er.ErrWalletReceiverFrozen
+	}
+
+	if transaction.Nonce != nil && *transaction.Nonce <= wallets.sender.Nonce {
+		return nil, er.ErrNonceReused
+	}
+
+	if wallets.sender.Currency != transaction.Currency || wallets.receiver.Currency != transaction.TargetCurrency {
+		return nil, er.ErrCurrencyMismatch
+	}
+
+	if transaction.FeeWallet != nil &&
+		(wallets.collector == nil || wallets.collector.Currency != transaction.Currency) {
+		return nil, er.ErrFeeCollectorNotFound
+	}
+
+	if wallets.sender.Available().Add(heldRelease).LessThan(transaction.Debited()) {
+		return nil, er.ErrNotEnoughMoney
+	}
+
+	return &wallets, nil
+}
+
+// updateBalance обновляет балансы кошельков после перевода:
+// списывает Amount и комиссию у отправителя (снимая резерв heldRelease одним UPDATE,
+// чтобы не нарушить ограничение held <= balance), зачисляет TargetAmount получателю
+// и комиссию сборщику. Номер подписанного перевода сохраняется как номер последнего перевода отправителя.
 // Внутренний метод, используется в Transfer.
-func (r *walletRepository) updateBalance(tx *gorm.DB, sender, receiver *models.Wallet, amount decimal.Decimal) error {
-	if err := tx.Model(sender).
-		Update("balance", gorm.Expr("balance - ?", amount)).Error; err != nil {
+func (r *walletRepository) updateBalance(tx *gorm.DB, wallets *transferWallets, transaction *models.Transaction,
+	heldRelease decimal.Decimal) error {
+	updates := map[string]any{
+		"balance": gorm.Expr("balance - ?", transaction.Debited()),
+		"held":    gorm.Expr("held - ?", heldRelease),
+	}
+	if transaction.Nonce != nil {
+		updates["nonce"] = *transaction.Nonce
+	}
+
+	if err := tx.Model(wallets.sender).Updates(updates).Error; err != nil {
 		return fmt.Errorf("error while writing off funds: %w", err)
 	}
 
-	if err := tx.Model(receiver).
-		Update("balance", gorm.Expr("balance + ?", amount)).Error; err != nil {
+	if err := tx.Model(wallets.receiver).
+		Update("balance", gorm.Expr("balance + ?", transaction.TargetAmount)).Error; err != nil {
 		return fmt.Errorf("error while crediting funds: %w", err)
 	}
 
+	if wallets.collector == nil {
+		return nil
+	}
+
+	if err := tx.Model(wallets.collector).
+		Update("balance", gorm.Expr("balance + ?", transaction.Fee)).Error; err != nil {
+		return fmt.Errorf("error while crediting fee: %w", err)
+	}
+
 	return nil
 }
 
 // createTransaction создает запись о транзакции.
 // Внутренний метод, используется в Transfer.
-func (r *walletRepository) createTransaction(tx *gorm.DB, from, to string, amount decimal.Decimal) error {
-	transaction := models.Transaction{
-		From:   from,
-		To:     to,
-		Amount: amount,
+func (r *walletRepository) createTransaction(tx *gorm.DB, transaction *models.Transaction) error {
+	if err := tx.Create(transaction).Error; err != nil {
+		return fmt.Errorf("error creating transaction: %w", err)
 	}
 
-	if err := tx.Create(&transaction).Error; err != nil {
-		return fmt.Errorf("error creating transaction: %w", err)
+	return nil
+}
+
+// postTransfer записывает проводки перевода в журнал.
+// Балансы после проводок вычисляются от значений заблокированных строк кошельков.
+// При конвертации сумма списания зачисляется на обменный счет валюты отправителя,
+// а сумма зачисления списывается с обменного счета валюты получателя.
+// Комиссия записывается отдельной парой проводок: списание у отправителя, зачисление сборщику.
+// Внутренний метод, используется в Transfer.
+func (r *walletRepository) postTransfer(tx *gorm.DB, wallets *transferWallets, transaction *models.Transaction) error {
+	// Текущие балансы: получатель может совпадать со сборщиком комиссии
+	balances := map[string]decimal.Decimal{
+		wallets.sender.Address:   wallets.sender.Balance,
+		wallets.receiver.Address: wallets.receiver.Balance,
+	}
+	if wallets.collector != nil {
+		balances[wallets.collector.Address] = wallets.collector.Balance
+	}
+
+	entry := func(address string, entryType models.LedgerEntryType, amount decimal.Decimal) models.LedgerEntry {
+		if entryType == models.LedgerEntryDebit {
+			balances[address] = balances[address].Sub(amount)
+		} else {
+			balances[address] = balances[address].Add(amount)
+		}
+		return walletEntry(&transaction.ID, address, entryType, amount, balances[address])
+	}
+
+	entries := []models.LedgerEntry{entry(transaction.From, models.LedgerEntryDebit, transaction.Amount)}
+
+	if transaction.IsExchange() {
+		entries = append(entries,
+			systemEntry(&transaction.ID, models.ExchangeAccount(transaction.Currency), models.LedgerEntryCredit,
+				transaction.Amount),
+			systemEntry(&transaction.ID, models.ExchangeAccount(transaction.TargetCurrency), models.LedgerEntryDebit,
+				transaction.TargetAmount),
+		)
+	}
+
+	entries = append(entries, entry(transaction.To, models.LedgerEntryCredit, transaction.TargetAmount))
+
+	if wallets.collector != nil {
+		entries = append(entries,
+			entry(transaction.From, models.LedgerEntryDebit, transaction.Fee),
+			entry(wallets.collector.Address, models.LedgerEntryCredit, transaction.Fee),
+		)
+	}
+
+	return postEntries(tx, entries...)
+}
+
+// saveIdempotencyKey сохраняет результат запроса рядом с созданной транзакцией.
+// Уникальный индекс по ключу гарантирует, что из конкурентных запросов
+// с одним ключом зафиксируется только один перевод.
+// Внутренний метод, используется в Transfer.
+func (r *walletRepository) saveIdempotencyKey(tx *gorm.DB, idempotencyKey *models.IdempotencyKey,
+	transaction *models.Transaction) error {
+	idempotencyKey.TransactionID = transaction.ID
+
+	if err := tx.Create(idempotencyKey).Error; err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return er.ErrIdempotencyKeyExists
+		}
+		return fmt.Errorf("error saving idempotency key: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/db/postgres/migrations"
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"sync"
+	"testing"
+)
+
+// testDSNEnv - переменная окружения со строкой подключения к тестовой БД PostgreSQL.
+// Без нее тесты, которым нужна БД, пропускаются.
+const testDSNEnv = "TEST_DATABASE_DSN"
+
+// testDB подключается к тестовой БД и применяет миграции.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv(testDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDSNEnv)
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect to DB: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(32)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if _, err = migrator.Up(context.Background()); err != nil {
+		t.Fatalf("failed to apply migrations: %v", err)
+	}
+
+	return db
+}
+
+// testTransfer создает перевод между кошельками в рублях без комиссии.
+func testTransfer(from, to string, amount decimal.Decimal) *models.Transaction {
+	return &models.Transaction{
+		From:           from,
+		To:             to,
+		Amount:         amount,
+		Currency:       "RUB",
+		TargetAmount:   amount,
+		TargetCurrency: "RUB",
+		Rate:           decimal.NewFromInt(1),
+		Spread:         decimal.Zero,
+		Fee:            decimal.Zero,
+	}
+}
+
+// TestTransferOpposingStorm выполняет параллельные встречные переводы A -> B и B -> A
+// и проверяет, что ни один перевод не завершился ошибкой (в том числе взаимоблокировкой
+// или ошибкой сериализации) и что сумма балансов сохранилась.
+func TestTransferOpposingStorm(t *testing.T) {
+	db := testDB(t)
+	repo := NewWalletRepository(db, nil)
+	ctx := context.Background()
+
+	const (
+		transfersPerSide = 100
+		initialBalance   = 1000
+	)
+
+	a := &models.Wallet{Address: "storm-a-" + uuid.NewString(), Balance: decimal.NewFromInt(initialBalance), Currency: "RUB"}
+	b := &models.Wallet{Address: "storm-b-" + uuid.NewString(), Balance: decimal.NewFromInt(initialBalance), Currency: "RUB"}
+	for _, wallet := range []*models.Wallet{a, b} {
+		if err := repo.CreateWallet(ctx, wallet); err != nil {
+			t.Fatalf("CreateWallet(%s) error = %v", wallet.Address, err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*transfersPerSide)
+	for i := 0; i < transfersPerSide; i++ {
+		for _, pair := range [][2]string{{a.Address, b.Address}, {b.Address, a.Address}} {
+			wg.Add(1)
+			go func(from, to string) {
+				defer wg.Done()
+				errs <- repo.Transfer(ctx, testTransfer(from, to, decimal.NewFromInt(1)), nil)
+			}(pair[0], pair[1])
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err == nil {
+			continue
+		}
+		if isRetryable(err) {
+			t.Errorf("lock conflict surfaced to caller: %v", err)
+		} else {
+			t.Errorf("Transfer() error = %v", err)
+		}
+	}
+
+	total := decimal.Zero
+	for _, address := range []string{a.Address, b.Address} {
+		wallet, err := repo.Wallet(ctx, address)
+		if err != nil {
+			t.Fatalf("Wallet(%s) error = %v", address, err)
+		}
+		total = total.Add(wallet.Balance)
+	}
+
+	if want := decimal.NewFromInt(2 * initialBalance); !total.Equal(want) {
+		t.Fatalf("total balance = %s, want %s", total, want)
+	}
+}
+
+// TestIdempotencyKeyScopedByAccount проверяет, что одинаковые ключи идемпотентности разных
+// учетных записей не конфликтуют, а повтор ключа той же учетной записью отклоняется.
+func TestIdempotencyKeyScopedByAccount(t *testing.T) {
+	db := testDB(t)
+	repo := NewWalletRepository(db, nil)
+	keys := NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	a := &models.Wallet{Address: "idem-a-" + uuid.NewString(), Balance: decimal.NewFromInt(100), Currency: "RUB"}
+	b := &models.Wallet{Address: "idem-b-" + uuid.NewString(), Balance: decimal.NewFromInt(100), Currency: "RUB"}
+	for _, wallet := range []*models.Wallet{a, b} {
+		if err := repo.CreateWallet(ctx, wallet); err != nil {
+			t.Fatalf("CreateWallet(%s) error = %v", wallet.Address, err)
+		}
+	}
+
+	key := "order-" + uuid.NewString()
+	first, second := uuid.NewString(), uuid.NewString()
+	record := func(account, hash string) *models.IdempotencyKey {
+		return &models.IdempotencyKey{AccountID: &account, Key: key, RequestHash: hash,
+			StatusCode: 200, ResponseBody: []byte(`{}`)}
+	}
+
+	if err := repo.Transfer(ctx, testTransfer(a.Address, b.Address, decimal.NewFromInt(1)),
+		record(first, "first")); err != nil {
+		t.Fatalf("Transfer() by the first account error = %v", err)
+	}
+	if err := repo.Transfer(ctx, testTransfer(b.Address, a.Address, decimal.NewFromInt(1)),
+		record(second, "second")); err != nil {
+		t.Fatalf("Transfer() by the second account with the same key error = %v", err)
+	}
+	if err := repo.Transfer(ctx, testTransfer(a.Address, b.Address, decimal.NewFromInt(1)),
+		record(first, "first")); !errors.Is(err, er.ErrIdempotencyKeyExists) {
+		t.Fatalf("Transfer() reusing the key error = %v, want %v", err, er.ErrIdempotencyKeyExists)
+	}
+
+	for account, want := range map[string]string{first: "first", second: "second"} {
+		stored, err := keys.IdempotencyKey(ctx, account, key)
+		if err != nil {
+			t.Fatalf("IdempotencyKey(%s) error = %v", account, err)
+		}
+		if stored.RequestHash != want {
+			t.Fatalf("IdempotencyKey(%s) request hash = %q, want %q", account, stored.RequestHash, want)
+		}
+	}
+
+	if _, err := keys.IdempotencyKey(ctx, "", key); !errors.Is(err, er.ErrIdempotencyKeyNotFound) {
+		t.Fatalf("IdempotencyKey() without account error = %v, want %v", err, er.ErrIdempotencyKeyNotFound)
+	}
+}
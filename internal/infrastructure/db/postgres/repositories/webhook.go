@@ -0,0 +1,333 @@
+// Package repositories содержит реализации репозиториев для работы с хранилищами данных.
+// Включает конкретные реализации интерфейсов доменного слоя.
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// webhookRepository реализует интерфейс WebhookRepository для PostgreSQL.
+//
+// Доставки захватываются арендой (claim_token, claimed_until) с SKIP LOCKED, как
+// запланированные переводы, поэтому отправлять вебхуки могут несколько экземпляров приложения.
+type webhookRepository struct {
+	db *gorm.DB // Экземпляр GORM для работы с БД
+}
+
+// NewWebhookRepository создает новый экземпляр репозитория вебхуков.
+//
+// Параметры:
+//   - db: подключение к БД (*gorm.DB)
+//
+// Возвращает:
+//   - repository.WebhookRepository: реализацию интерфейса репозитория
+func NewWebhookRepository(db *gorm.DB) repository.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// CreateSubscription сохраняет новую подписку на вебхук.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - subscription: подписка
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if err := r.db.WithContext(ctx).Create(subscription).Error; err != nil {
+		return fmt.Errorf("error creating webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// Subscription возвращает действующую подписку по ее публичному идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - publicID: публичный идентификатор подписки (UUID)
+//
+// Возвращает:
+//   - *models.WebhookSubscription: найденная подписка
+//   - error: er.ErrWebhookNotFound (в том числе для удаленной подписки) или другие ошибки базы данных
+func (r *webhookRepository) Subscription(ctx context.Context, publicID string) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+
+	err := r.db.WithContext(ctx).First(&subscription, "public_id = ?", publicID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// Subscriptions возвращает действующие подписки кошельков в порядке создания.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - wallets: адреса кошельков
+//
+// Возвращает:
+//   - []models.WebhookSubscription: подписки
+//   - error: ошибка базы данных
+func (r *webhookRepository) Subscriptions(ctx context.Context, wallets ...string) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	err := r.db.WithContext(ctx).
+		Where("wallet IN ?", wallets).
+		Order("created_at, id").
+		Find(&subscriptions).Error
+
+	return subscriptions, err
+}
+
+// DeleteSubscription удаляет подписку (мягкое удаление) и отменяет ее неотправленные доставки.
+// Доставка, отправляемая в момент удаления, завершается, но ее результат не записывается.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - subscription: подписка
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, subscription *models.WebhookSubscription) error {
+	return withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Delete(subscription).Error; err != nil {
+				return fmt.Errorf("error deleting webhook subscription: %w", err)
+			}
+
+			if err := tx.Model(&models.WebhookDelivery{}).
+				Where("subscription_id = ? AND status = ?", subscription.ID, models.WebhookDeliveryPending).
+				Updates(map[string]any{
+					"status":        models.WebhookDeliveryCancelled,
+					"claim_token":   nil,
+					"claimed_until": nil,
+				}).Error; err != nil {
+				return fmt.Errorf("error cancelling webhook deliveries: %w", err)
+			}
+
+			return nil
+		})
+	})
+}
+
+// CreateDeliveries сохраняет доставки событий. Доставки события, уже созданные для подписки
+// (при повторной публикации события ретранслятором), пропускаются.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - deliveries: доставки
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("error creating webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// Deliveries возвращает последние доставки подписки (от новых к старым).
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - subscriptionID: внутренний идентификатор подписки
+//   - status: фильтр по статусу (nil - все доставки)
+//   - limit: максимальное количество доставок
+//
+// Возвращает:
+//   - []models.WebhookDelivery: доставки
+//   - error: ошибка базы данных
+func (r *webhookRepository) Deliveries(ctx context.Context, subscriptionID uint, status *models.WebhookDeliveryStatus,
+	limit int) ([]models.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+
+	return deliveries, err
+}
+
+// Delivery возвращает доставку подписки по ее публичному идентификатору.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - subscriptionID: внутренний идентификатор подписки
+//   - publicID: публичный идентификатор доставки (UUID)
+//
+// Возвращает:
+//   - *models.WebhookDelivery: найденная доставка
+//   - error: er.ErrWebhookDeliveryNotFound или другие ошибки базы данных
+func (r *webhookRepository) Delivery(ctx context.Context, subscriptionID uint,
+	publicID string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+
+	err := r.db.WithContext(ctx).
+		First(&delivery, "subscription_id = ? AND public_id = ?", subscriptionID, publicID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, er.ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// Redeliver назначает повторную доставку на время now со сбросом числа попыток.
+// Если доставка отправляется в этот момент, результат текущей отправки не записывается.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - delivery: доставка (поля обновляются)
+//   - now: время повторной доставки
+//
+// Возвращает:
+//   - error: ошибка базы данных
+func (r *webhookRepository) Redeliver(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) error {
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = now
+	delivery.ClaimToken = nil
+	delivery.ClaimedUntil = nil
+
+	if err := r.db.WithContext(ctx).Model(delivery).
+		Updates(map[string]any{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"claim_token":     nil,
+			"claimed_until":   nil,
+		}).Error; err != nil {
+		return fmt.Errorf("error scheduling webhook redelivery: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue захватывает ожидающие доставки, срок попытки которых наступил, на срок lease
+// (см. ScheduledTransferRepository.ClaimDue). Доставки возвращаются вместе с подписками,
+// включая удаленные (DeletedAt заполнено), чтобы отправитель мог отменить их доставку.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - now: текущее время
+//   - lease: срок аренды
+//   - limit: максимальное количество доставок за вызов
+//
+// Возвращает:
+//   - []models.WebhookDelivery: захваченные доставки (с заполненными ClaimToken и Subscription)
+//   - error: ошибка базы данных
+func (r *webhookRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration,
+	limit int) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+
+	err := withRetry(ctx, func() error {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			deliveries = nil
+			if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: "SKIP LOCKED"}).
+				Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, now).
+				Where("claimed_until IS NULL OR claimed_until < ?", now).
+				Order("next_attempt_at").
+				Limit(limit).
+				Find(&deliveries).Error; err != nil {
+				return fmt.Errorf("error selecting due webhook deliveries: %w", err)
+			}
+
+			if len(deliveries) == 0 {
+				return nil
+			}
+
+			token := uuid.NewString()
+			claimedUntil := now.Add(lease)
+
+			ids := make([]uint, 0, len(deliveries))
+			subscriptionIDs := make([]uint, 0, len(deliveries))
+			for i := range deliveries {
+				ids = append(ids, deliveries[i].ID)
+				subscriptionIDs = append(subscriptionIDs, deliveries[i].SubscriptionID)
+				deliveries[i].ClaimToken = &token
+				deliveries[i].ClaimedUntil = &claimedUntil
+			}
+
+			if err := tx.Model(&models.WebhookDelivery{}).
+				Where("id IN ?", ids).
+				Updates(map[string]any{"claim_token": token, "claimed_until": claimedUntil}).Error; err != nil {
+				return fmt.Errorf("error claiming webhook deliveries: %w", err)
+			}
+
+			var subscriptions []models.WebhookSubscription
+			if err := tx.Unscoped().Where("id IN ?", subscriptionIDs).Find(&subscriptions).Error; err != nil {
+				return fmt.Errorf("error loading webhook subscriptions: %w", err)
+			}
+
+			byID := make(map[uint]*models.WebhookSubscription, len(subscriptions))
+			for i := range subscriptions {
+				byID[subscriptions[i].ID] = &subscriptions[i]
+			}
+			for i := range deliveries {
+				deliveries[i].Subscription = byID[deliveries[i].SubscriptionID]
+			}
+
+			return nil
+		})
+	})
+
+	return deliveries, err
+}
+
+// CompleteDelivery сохраняет результат попытки доставки (статус, число попыток, время
+// следующей попытки, код ответа, ошибку) и снимает аренду. Результат сохраняется,
+// только если аренда все еще принадлежит вызывающему.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//   - delivery: захваченная доставка с результатом попытки
+//
+// Возвращает:
+//   - error: er.ErrWebhookDeliveryClaimLost, если аренда истекла, доставка запрошена
+//     повторно или подписка удалена, или другие ошибки базы данных
+func (r *webhookRepository) CompleteDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result := r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).
+		Where("id = ? AND claim_token = ?", delivery.ID, delivery.ClaimToken).
+		Updates(map[string]any{
+			"status":           delivery.Status,
+			"attempts":         delivery.Attempts,
+			"next_attempt_at":  delivery.NextAttemptAt,
+			"last_status_code": delivery.LastStatusCode,
+			"last_error":       delivery.LastError,
+			"delivered_at":     delivery.DeliveredAt,
+			"claim_token":      nil,
+			"claimed_until":    nil,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("error updating webhook delivery: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return er.ErrWebhookDeliveryClaimLost
+	}
+	return nil
+}
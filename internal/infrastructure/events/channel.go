@@ -0,0 +1,54 @@
+package events
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"log"
+)
+
+// ChannelPublisher - приемник, передающий события подписчику внутри процесса через канал.
+// Если буфер канала заполнен, публикация ожидает чтения, задерживая ретранслятор.
+type ChannelPublisher struct {
+	events chan Envelope
+}
+
+// NewChannelPublisher создает приемник с буфером на buffer событий.
+//
+// Параметры:
+//   - buffer: размер буфера канала
+//
+// Возвращает:
+//   - *ChannelPublisher: приемник событий
+func NewChannelPublisher(buffer int) *ChannelPublisher {
+	return &ChannelPublisher{events: make(chan Envelope, buffer)}
+}
+
+// Events возвращает канал опубликованных событий.
+func (p *ChannelPublisher) Events() <-chan Envelope {
+	return p.events
+}
+
+// Publish передает событие в канал.
+//
+// Возможные ошибки:
+//   - ошибка контекста, если событие не было прочитано до его отмены
+func (p *ChannelPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	select {
+	case p.events <- NewEnvelope(event):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close закрывает канал событий. Вызывается после остановки ретранслятора.
+func (p *ChannelPublisher) Close() {
+	close(p.events)
+}
+
+// logEvents пишет в лог события, прочитанные из канала, до его закрытия.
+func logEvents(events <-chan Envelope) {
+	for event := range events {
+		log.Printf("[INFO] Event %d (%s) published", event.ID, event.Type)
+	}
+}
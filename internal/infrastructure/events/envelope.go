@@ -0,0 +1,28 @@
+// Package events содержит ретранслятор транзакционного outbox и приемники событий
+// (реализации repository.EventPublisher).
+package events
+
+import (
+	"encoding/json"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"time"
+)
+
+// Envelope - внешнее представление события outbox, передаваемое приемникам.
+// ID монотонно возрастает и позволяет получателю отбрасывать повторные доставки.
+type Envelope struct {
+	ID        uint            `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// NewEnvelope формирует внешнее представление события.
+func NewEnvelope(event *models.OutboxEvent) Envelope {
+	return Envelope{
+		ID:        event.ID,
+		Type:      event.EventType,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	}
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"os"
+	"sync"
+)
+
+// FilePublisher - приемник, дописывающий события в файл в формате JSON Lines
+// (одно событие на строку). Каждая запись сбрасывается на диск до подтверждения публикации.
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFilePublisher открывает (или создает) файл событий для дозаписи.
+//
+// Параметры:
+//   - path: путь к файлу
+//
+// Возвращает:
+//   - *FilePublisher: приемник событий
+//   - error: ошибка открытия файла
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening events file: %w", err)
+	}
+	return &FilePublisher{file: file}, nil
+}
+
+// Publish дописывает событие в файл.
+//
+// Возможные ошибки:
+//   - ошибка записи или сброса файла на диск
+func (p *FilePublisher) Publish(_ context.Context, event *models.OutboxEvent) error {
+	line, err := json.Marshal(NewEnvelope(event))
+	if err != nil {
+		return fmt.Errorf("error encoding event %d: %w", event.ID, err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err = p.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing event %d: %w", event.ID, err)
+	}
+	return p.file.Sync()
+}
+
+// Close закрывает файл событий.
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
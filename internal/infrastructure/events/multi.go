@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+)
+
+// MultiPublisher публикует событие во все приемники по очереди.
+// Публикация успешна, только если событие приняли все приемники; иначе событие
+// будет опубликовано повторно во все приемники, включая уже принявшие его.
+type MultiPublisher []repository.EventPublisher
+
+// Publish публикует событие во все приемники.
+//
+// Возможные ошибки:
+//   - объединенные ошибки приемников, не принявших событие
+func (m MultiPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	var errs []error
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
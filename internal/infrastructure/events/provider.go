@@ -0,0 +1,62 @@
+package events
+
+import (
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"log"
+)
+
+// ProvidePublisher создает приемник событий по конфигурации.
+//
+// Параметры:
+//   - cfg: конфигурация outbox
+//   - extra: дополнительные приемники (например, подписки на вебхуки)
+//
+// Возвращает:
+//   - repository.EventPublisher: приемник, публикующий во все настроенные приемники,
+//     или nil, если ни один приемник не настроен (события остаются в outbox)
+//   - func(): функция освобождения ресурсов приемников
+//   - error: ошибка открытия файла событий
+func ProvidePublisher(cfg *config.OutboxConfig, extra ...repository.EventPublisher) (
+	repository.EventPublisher, func(), error) {
+	publishers := MultiPublisher(extra)
+	var closers []func()
+	closer := func() {
+		for _, fn := range closers {
+			fn()
+		}
+	}
+
+	if cfg.File != "" {
+		file, err := NewFilePublisher(cfg.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		publishers = append(publishers, file)
+		closers = append(closers, func() {
+			if err := file.Close(); err != nil {
+				log.Printf("[WARN] Error closing events file: %v", err)
+			}
+		})
+	}
+
+	if cfg.ChannelBuffer > 0 {
+		channel := NewChannelPublisher(cfg.ChannelBuffer)
+		go logEvents(channel.Events())
+		publishers = append(publishers, channel)
+		closers = append(closers, channel.Close)
+	}
+
+	if cfg.WebhookURL != "" {
+		publishers = append(publishers, NewWebhookPublisher(cfg.WebhookURL, cfg.WebhookTimeout))
+	}
+
+	switch len(publishers) {
+	case 0:
+		return nil, closer, nil
+	case 1:
+		return publishers[0], closer, nil
+	default:
+		return publishers, closer, nil
+	}
+}
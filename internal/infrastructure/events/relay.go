@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"time"
+)
+
+// Relay - ретранслятор транзакционного outbox: публикует неопубликованные события
+// в приемник в порядке их записи.
+//
+// Гарантии доставки:
+//   - at-least-once: событие отмечается опубликованным только после успешной публикации,
+//     а при сбое до фиксации отметки будет опубликовано повторно;
+//   - порядок по ключу: если публикация события отложена после ошибки, последующие события
+//     с общим ключом упорядочивания (адресом кошелька) ждут его, остальные публикуются.
+//
+// Одновременно события публикует только один экземпляр приложения
+// (см. repository.OutboxRepository.WithRelayLock).
+type Relay struct {
+	repo         repository.OutboxRepository
+	publisher    repository.EventPublisher
+	batchSize    int
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+}
+
+// NewRelay создает ретранслятор outbox.
+//
+// Параметры:
+//   - repo: репозиторий outbox
+//   - publisher: приемник событий
+//   - batchSize: максимальное число событий, просматриваемых за один проход
+//   - retryBackoff: задержка перед первой повторной публикацией (далее удваивается)
+//   - maxBackoff: максимальная задержка перед повторной публикацией
+//
+// Возвращает:
+//   - *Relay: ретранслятор
+func NewRelay(repo repository.OutboxRepository, publisher repository.EventPublisher, batchSize int,
+	retryBackoff, maxBackoff time.Duration) *Relay {
+	return &Relay{
+		repo:         repo,
+		publisher:    publisher,
+		batchSize:    batchSize,
+		retryBackoff: retryBackoff,
+		maxBackoff:   maxBackoff,
+	}
+}
+
+// Run выполняет один проход ретранслятора.
+// Если события публикует другой экземпляр приложения, проход пропускается.
+//
+// Параметры:
+//   - ctx: контекст выполнения
+//
+// Возвращает:
+//   - int: количество опубликованных событий
+//   - error: ошибка базы данных или отмена контекста (отметки прохода при этом
+//     откатываются, и события публикуются повторно)
+func (r *Relay) Run(ctx context.Context) (int, error) {
+	var published int
+
+	_, err := r.repo.WithRelayLock(ctx, func(repo repository.OutboxRepository) error {
+		published = 0
+
+		events, err := repo.Pending(ctx, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		blocked := make(map[string]bool)
+
+		for i := range events {
+			event := &events[i]
+
+			// Отложенное событие задерживает все свои ключи: иначе событие, связанное
+			// с ним через другой кошелек, обогнало бы его
+			if isBlocked(blocked, event.OrderingKeys) ||
+				(event.NextAttemptAt != nil && event.NextAttemptAt.After(now)) {
+				block(blocked, event.OrderingKeys)
+				continue
+			}
+
+			if err = r.publisher.Publish(ctx, event); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				block(blocked, event.OrderingKeys)
+				if err = repo.MarkFailed(ctx, event.ID, err.Error(), now.Add(r.backoff(event))); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err = repo.MarkPublished(ctx, event.ID, time.Now()); err != nil {
+				return err
+			}
+			published++
+		}
+
+		return nil
+	})
+
+	return published, err
+}
+
+// backoff возвращает задержку перед следующей попыткой публикации события.
+func (r *Relay) backoff(event *models.OutboxEvent) time.Duration {
+	delay := r.retryBackoff
+	for i := 0; i < event.Attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, r.maxBackoff)
+}
+
+// isBlocked проверяет, задержана ли публикация событий хотя бы по одному из ключей.
+func isBlocked(blocked map[string]bool, keys []string) bool {
+	for _, key := range keys {
+		if blocked[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// block задерживает публикацию последующих событий с ключами keys до следующего прохода.
+func block(blocked map[string]bool, keys []string) {
+	for _, key := range keys {
+		blocked[key] = true
+	}
+}
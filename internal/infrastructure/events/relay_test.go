@@ -0,0 +1,118 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"testing"
+	"time"
+)
+
+// memoryOutbox хранит события outbox в памяти. Неиспользуемые методы не реализованы.
+type memoryOutbox struct {
+	repository.OutboxRepository
+	events    []models.OutboxEvent
+	published []uint
+}
+
+func (o *memoryOutbox) WithRelayLock(_ context.Context, fn func(repo repository.OutboxRepository) error) (bool, error) {
+	return true, fn(o)
+}
+
+func (o *memoryOutbox) Pending(_ context.Context, limit int) ([]models.OutboxEvent, error) {
+	var pending []models.OutboxEvent
+	for _, event := range o.events {
+		if event.PublishedAt == nil && len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+func (o *memoryOutbox) MarkPublished(_ context.Context, id uint, publishedAt time.Time) error {
+	for i := range o.events {
+		if o.events[i].ID == id {
+			o.events[i].PublishedAt = &publishedAt
+		}
+	}
+	o.published = append(o.published, id)
+	return nil
+}
+
+// newMemoryOutbox создает outbox с событиями TransferCompleted с номерами 1..n.
+func newMemoryOutbox(n int) *memoryOutbox {
+	outbox := &memoryOutbox{}
+	for i := 1; i <= n; i++ {
+		event := models.OutboxEvent{EventType: models.EventTransferCompleted, OrderingKeys: []string{"wallet"},
+			Payload: []byte(`{}`)}
+		event.ID = uint(i)
+		outbox.events = append(outbox.events, event)
+	}
+	return outbox
+}
+
+// TestRelayChannelPublisher проверяет, что ретранслятор передает события в канал
+// в порядке записи и отмечает опубликованными только принятые каналом события.
+func TestRelayChannelPublisher(t *testing.T) {
+	outbox := newMemoryOutbox(3)
+	channel := NewChannelPublisher(3)
+
+	published, err := NewRelay(outbox, channel, 10, time.Second, time.Minute).Run(context.Background())
+	if err != nil || published != 3 {
+		t.Fatalf("Run() = %d, %v, want 3 events published", published, err)
+	}
+
+	for want := uint(1); want <= 3; want++ {
+		if event := <-channel.Events(); event.ID != want || event.Type != models.EventTransferCompleted {
+			t.Fatalf("received event %d (%s), want %d (%s)", event.ID, event.Type, want,
+				models.EventTransferCompleted)
+		}
+	}
+
+	if published, err = NewRelay(outbox, channel, 10, time.Second, time.Minute).Run(context.Background()); err != nil ||
+		published != 0 {
+		t.Fatalf("second Run() = %d, %v, want nothing to publish", published, err)
+	}
+}
+
+// TestRelayChannelPublisherFull проверяет, что при заполненном буфере канала проход
+// прерывается отменой контекста, а непрочитанное событие остается неопубликованным.
+func TestRelayChannelPublisherFull(t *testing.T) {
+	outbox := newMemoryOutbox(2)
+	channel := NewChannelPublisher(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := NewRelay(outbox, channel, 10, time.Second, time.Minute).Run(ctx); !errors.Is(err,
+		context.DeadlineExceeded) {
+		t.Fatalf("Run() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if outbox.events[1].PublishedAt != nil {
+		t.Fatal("event not accepted by the channel is marked published")
+	}
+}
+
+// TestProvidePublisherChannel проверяет, что канал включается настройкой channel_buffer.
+func TestProvidePublisherChannel(t *testing.T) {
+	publisher, closePublisher, err := ProvidePublisher(&config.OutboxConfig{})
+	if err != nil || publisher != nil {
+		t.Fatalf("ProvidePublisher() = %v, %v, want no publisher", publisher, err)
+	}
+	closePublisher()
+
+	publisher, closePublisher, err = ProvidePublisher(&config.OutboxConfig{ChannelBuffer: 1})
+	if err != nil {
+		t.Fatalf("ProvidePublisher() error = %v", err)
+	}
+	defer closePublisher()
+
+	if _, ok := publisher.(*ChannelPublisher); !ok {
+		t.Fatalf("ProvidePublisher() = %T, want *ChannelPublisher", publisher)
+	}
+	if err = publisher.Publish(context.Background(), &newMemoryOutbox(1).events[0]); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+}
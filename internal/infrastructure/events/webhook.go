@@ -0,0 +1,137 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// HTTPSender отправляет POST-запросы с JSON-телом (реализует repository.WebhookSender).
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender создает отправитель HTTP-запросов.
+//
+// Параметры:
+//   - timeout: таймаут одного запроса
+//
+// Возвращает:
+//   - *HTTPSender: отправитель
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{Timeout: timeout}}
+}
+
+// NewPublicHTTPSender создает отправитель, который подключается только к публичным адресам
+// (см. models.PublicWebhookAddress). Адрес проверяется при каждом подключении, в том числе
+// после перенаправлений, поэтому смена DNS-записи после создания подписки не позволяет
+// обратиться к внутренним сервисам. Прокси из окружения не используется.
+//
+// Параметры:
+//   - timeout: таймаут одного запроса
+//
+// Возвращает:
+//   - *HTTPSender: отправитель
+func NewPublicHTTPSender(timeout time.Duration) *HTTPSender {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !models.PublicWebhookAddress(ip) {
+				return fmt.Errorf("webhook target address %s is not public", host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{client: &http.Client{Timeout: timeout, Transport: transport}}
+}
+
+// Send отправляет тело body POST-запросом на url с заголовками headers.
+//
+// Возвращает:
+//   - int: код ответа получателя
+//   - error: ошибка сети или таймаут (ответ не получен)
+func (s *HTTPSender) Send(ctx context.Context, url string, body []byte, headers map[string]string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("error creating webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+// WebhookPublisher - приемник, отправляющий события POST-запросом с JSON-телом на заданный URL.
+// Публикация считается успешной при любом ответе 2xx.
+//
+// Заголовки запроса:
+//
+//	X-Event-ID   - идентификатор события (для отбрасывания повторных доставок)
+//	X-Event-Type - тип события
+type WebhookPublisher struct {
+	url    string
+	sender *HTTPSender
+}
+
+// NewWebhookPublisher создает приемник-вебхук.
+//
+// Параметры:
+//   - url: адрес получателя
+//   - timeout: таймаут одного запроса
+//
+// Возвращает:
+//   - *WebhookPublisher: приемник событий
+func NewWebhookPublisher(url string, timeout time.Duration) *WebhookPublisher {
+	return &WebhookPublisher{url: url, sender: NewHTTPSender(timeout)}
+}
+
+// Publish отправляет событие получателю.
+//
+// Возможные ошибки:
+//   - ошибка сети или таймаут
+//   - ответ получателя с кодом вне диапазона 2xx
+func (p *WebhookPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	body, err := json.Marshal(NewEnvelope(event))
+	if err != nil {
+		return fmt.Errorf("error encoding event %d: %w", event.ID, err)
+	}
+
+	status, err := p.sender.Send(ctx, p.url, body, map[string]string{
+		"X-Event-ID":   strconv.FormatUint(uint64(event.ID), 10),
+		"X-Event-Type": event.EventType,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status > 299 {
+		return fmt.Errorf("webhook responded with status %d", status)
+	}
+	return nil
+}
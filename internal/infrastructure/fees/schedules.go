@@ -0,0 +1,168 @@
+// Package fees содержит реализацию источника тарифов комиссии (repository.FeeScheduleProvider)
+// на основе конфигурации приложения.
+package fees
+
+import (
+	"fmt"
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/shopspring/decimal"
+	"sort"
+	"strings"
+)
+
+// staticSchedules - тарифы, загруженные из конфигурации при старте.
+// После загрузки не изменяются, поэтому синхронизация не нужна.
+type staticSchedules struct {
+	byCurrency map[string]*models.FeeSchedule
+}
+
+// ProvideFeeSchedules разбирает и проверяет тарифы комиссии из конфигурации.
+//
+// Параметры:
+//   - cfg: тарифы по кодам валют (config.Config.Fees)
+//
+// Возвращает:
+//   - repository.FeeScheduleProvider: источник тарифов
+//   - error: ошибка, если тариф задан некорректно
+func ProvideFeeSchedules(cfg map[string]config.FeeConfig) (repository.FeeScheduleProvider, error) {
+	schedules := &staticSchedules{byCurrency: make(map[string]*models.FeeSchedule, len(cfg))}
+
+	for currency, feeCfg := range cfg {
+		// viper приводит ключи к нижнему регистру
+		currency = strings.ToUpper(currency)
+
+		schedule, err := parseSchedule(currency, feeCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee schedule %s: %w", currency, err)
+		}
+		schedules.byCurrency[currency] = schedule
+	}
+
+	return schedules, nil
+}
+
+// Schedule возвращает тариф для валюты или nil, если тариф не настроен.
+func (s *staticSchedules) Schedule(currency string) *models.FeeSchedule {
+	return s.byCurrency[currency]
+}
+
+// Schedules возвращает все настроенные тарифы в порядке кодов валют.
+func (s *staticSchedules) Schedules() []*models.FeeSchedule {
+	result := make([]*models.FeeSchedule, 0, len(s.byCurrency))
+	for _, schedule := range s.byCurrency {
+		result = append(result, schedule)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Currency < result[j].Currency })
+	return result
+}
+
+// parseSchedule разбирает тариф одной валюты.
+func parseSchedule(currency string, cfg config.FeeConfig) (*models.FeeSchedule, error) {
+	if cfg.Collector == "" {
+		return nil, fmt.Errorf("collector wallet is required")
+	}
+
+	schedule := &models.FeeSchedule{Currency: currency, Collector: cfg.Collector}
+
+	var err error
+	if schedule.Flat, err = parseAmount("flat", cfg.Flat); err != nil {
+		return nil, err
+	}
+	if schedule.Percent, err = parsePercent(cfg.Percent); err != nil {
+		return nil, err
+	}
+	if schedule.Min, err = parseOptional("min", cfg.Min); err != nil {
+		return nil, err
+	}
+	if schedule.Max, err = parseOptional("max", cfg.Max); err != nil {
+		return nil, err
+	}
+	if schedule.Min != nil && schedule.Max != nil && schedule.Min.GreaterThan(*schedule.Max) {
+		return nil, fmt.Errorf("min must not exceed max")
+	}
+
+	for i, tierCfg := range cfg.Tiers {
+		tier, err := parseTier(tierCfg)
+		if err != nil {
+			return nil, fmt.Errorf("tier %d: %w", i+1, err)
+		}
+
+		if i > 0 {
+			prev := schedule.Tiers[i-1].UpTo
+			if prev == nil {
+				return nil, fmt.Errorf("tier %d follows an unbounded tier", i+1)
+			}
+			if tier.UpTo != nil && !tier.UpTo.GreaterThan(*prev) {
+				return nil, fmt.Errorf("tier %d: up_to must be greater than the previous tier", i+1)
+			}
+		}
+
+		schedule.Tiers = append(schedule.Tiers, tier)
+	}
+
+	return schedule, nil
+}
+
+// parseTier разбирает ступень тарифа.
+func parseTier(cfg config.FeeTierConfig) (models.FeeTier, error) {
+	var tier models.FeeTier
+	var err error
+
+	if tier.UpTo, err = parseOptional("up_to", cfg.UpTo); err != nil {
+		return tier, err
+	}
+	if tier.Flat, err = parseAmount("flat", cfg.Flat); err != nil {
+		return tier, err
+	}
+	if tier.Percent, err = parsePercent(cfg.Percent); err != nil {
+		return tier, err
+	}
+
+	return tier, nil
+}
+
+// parseAmount разбирает неотрицательную сумму (пустая строка - ноль).
+func parseAmount(name, value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
+	}
+
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if amount.IsNegative() {
+		return decimal.Zero, fmt.Errorf("%s must not be negative", name)
+	}
+
+	return amount, nil
+}
+
+// parseOptional разбирает необязательную неотрицательную сумму (пустая строка - nil).
+func parseOptional(name, value string) (*decimal.Decimal, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	amount, err := parseAmount(name, value)
+	if err != nil {
+		return nil, err
+	}
+	return &amount, nil
+}
+
+// parsePercent разбирает процент комиссии в диапазоне [0, 100].
+func parsePercent(value string) (decimal.Decimal, error) {
+	percent, err := parseAmount("percent", value)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if percent.GreaterThan(decimal.NewFromInt(100)) {
+		return decimal.Zero, fmt.Errorf("percent must not exceed 100")
+	}
+
+	return percent, nil
+}
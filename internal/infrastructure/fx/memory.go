@@ -0,0 +1,61 @@
+package fx
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/shopspring/decimal"
+	"sync"
+)
+
+// MemoryRateProvider - поставщик курсов, хранящий курсы в памяти процесса.
+// Курсы задаются через SetRate и могут меняться во время работы,
+// поэтому поставщик подходит для тестов и локального запуска.
+type MemoryRateProvider struct {
+	mu    sync.RWMutex
+	table *rateTable
+}
+
+// NewMemoryRateProvider создает пустой поставщик курсов в памяти.
+//
+// Параметры:
+//   - spread: спред по умолчанию для пар без собственного спреда
+//
+// Возвращает:
+//   - *MemoryRateProvider: поставщик без курсов
+func NewMemoryRateProvider(spread decimal.Decimal) *MemoryRateProvider {
+	return &MemoryRateProvider{table: newRateTable(spread)}
+}
+
+// SetRate задает курс base -> quote (обратный курс вычисляется автоматически).
+//
+// Параметры:
+//   - base: валюта, которую продают
+//   - quote: валюта, которую покупают
+//   - rate: средний курс (единиц quote за единицу base)
+//   - spread: спред пары (nil - спред по умолчанию)
+//
+// Возвращает:
+//   - error: ошибка, если курс или спред невалидны
+func (p *MemoryRateProvider) SetRate(base, quote string, rate decimal.Decimal, spread *decimal.Decimal) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.table.set(base, quote, rate, spread)
+}
+
+// Rate возвращает курс обмена base на quote.
+//
+// Параметры:
+//   - ctx: контекст выполнения (не используется)
+//   - base: валюта отправителя
+//   - quote: валюта получателя
+//
+// Возвращает:
+//   - *models.FXRate: курс и спред
+//   - error: er.ErrFXRateNotFound, если курс не задан
+func (p *MemoryRateProvider) Rate(_ context.Context, base, quote string) (*models.FXRate, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.table.lookup(base, quote)
+}
@@ -0,0 +1,29 @@
+package fx
+
+import (
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/shopspring/decimal"
+	"log"
+)
+
+// ProvideRateProvider создает поставщик курсов по конфигурации.
+//
+// Параметры:
+//   - cfg: конфигурация курсов обмена
+//
+// Возвращает:
+//   - repository.FXRateProvider: поставщик курсов из файла cfg.RatesFile
+//     или пустой поставщик в памяти, если файл не задан
+//   - error: ошибка загрузки файла курсов
+//
+// Без файла курсов переводы между кошельками в разных валютах
+// завершаются ошибкой er.ErrFXRateNotFound.
+func ProvideRateProvider(cfg *config.FXConfig) (repository.FXRateProvider, error) {
+	if cfg.RatesFile == "" {
+		log.Println("[WARN] FX rates file is not configured, cross-currency transfers are disabled")
+		return NewMemoryRateProvider(decimal.Zero), nil
+	}
+
+	return NewStaticRateProvider(cfg.RatesFile)
+}
@@ -0,0 +1,81 @@
+// Package fx содержит реализации поставщика курсов обмена валют (repository.FXRateProvider).
+package fx
+
+import (
+	"fmt"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/shopspring/decimal"
+	"strings"
+)
+
+// inversePrecision - количество знаков после запятой при вычислении обратного курса.
+const inversePrecision = 16
+
+// pair задает направление обмена: base -> quote.
+type pair struct {
+	base  string
+	quote string
+}
+
+// rateTable хранит курсы по парам валют и разрешает обратные пары.
+// Не синхронизирована: потокобезопасность обеспечивают использующие ее поставщики.
+type rateTable struct {
+	rates  map[pair]models.FXRate
+	spread decimal.Decimal // Спред по умолчанию для пар без собственного спреда
+}
+
+// newRateTable создает пустую таблицу курсов со спредом по умолчанию.
+func newRateTable(spread decimal.Decimal) *rateTable {
+	return &rateTable{rates: make(map[pair]models.FXRate), spread: spread}
+}
+
+// set сохраняет курс base -> quote. Если spread равен nil, используется спред по умолчанию.
+func (t *rateTable) set(base, quote string, rate decimal.Decimal, spread *decimal.Decimal) error {
+	if base == quote {
+		return fmt.Errorf("invalid currency pair %s/%s", base, quote)
+	}
+
+	if !rate.IsPositive() {
+		return fmt.Errorf("rate for %s/%s must be positive", base, quote)
+	}
+
+	s := t.spread
+	if spread != nil {
+		s = *spread
+	}
+	if s.IsNegative() || s.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return fmt.Errorf("spread for %s/%s must be in [0, 1)", base, quote)
+	}
+
+	t.rates[pair{base: base, quote: quote}] = models.FXRate{Base: base, Quote: quote, Rate: rate, Spread: s}
+	return nil
+}
+
+// lookup возвращает курс base -> quote. Если задан только обратный курс quote -> base,
+// возвращается обратная величина с тем же спредом.
+func (t *rateTable) lookup(base, quote string) (*models.FXRate, error) {
+	if rate, ok := t.rates[pair{base: base, quote: quote}]; ok {
+		return &rate, nil
+	}
+
+	if rate, ok := t.rates[pair{base: quote, quote: base}]; ok {
+		return &models.FXRate{
+			Base:   base,
+			Quote:  quote,
+			Rate:   decimal.NewFromInt(1).DivRound(rate.Rate, inversePrecision),
+			Spread: rate.Spread,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s", er.ErrFXRateNotFound, base, quote)
+}
+
+// parsePair разбирает пару валют в формате "BASE/QUOTE".
+func parsePair(s string) (string, string, error) {
+	base, quote, ok := strings.Cut(strings.ToUpper(strings.TrimSpace(s)), "/")
+	if !ok || base == "" || quote == "" {
+		return "", "", fmt.Errorf("invalid currency pair %q: expected BASE/QUOTE", s)
+	}
+	return base, quote, nil
+}
@@ -0,0 +1,119 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+	"os"
+)
+
+// ratesFile описывает формат файла курсов:
+//
+//	spread: "0.005"          # спред по умолчанию
+//	rates:
+//	  USD/RUB: "90.25"       # средний курс
+//	  EUR/USD:
+//	    rate: "1.08"
+//	    spread: "0.002"      # собственный спред пары
+//
+// Суммы задаются строками, чтобы избежать потери точности при разборе float.
+type ratesFile struct {
+	Spread string               `yaml:"spread"`
+	Rates  map[string]yaml.Node `yaml:"rates"`
+}
+
+// rateEntry - развернутая форма записи о курсе с собственным спредом.
+type rateEntry struct {
+	Rate   string  `yaml:"rate"`
+	Spread *string `yaml:"spread"`
+}
+
+// staticRateProvider - поставщик курсов, загружаемых один раз из файла.
+// После загрузки таблица не изменяется, поэтому синхронизация не нужна.
+type staticRateProvider struct {
+	table *rateTable
+}
+
+// NewStaticRateProvider загружает курсы из YAML-файла.
+//
+// Параметры:
+//   - path: путь к файлу курсов
+//
+// Возвращает:
+//   - repository.FXRateProvider: поставщик курсов из файла
+//   - error: ошибка чтения или разбора файла
+func NewStaticRateProvider(path string) (repository.FXRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rates file: %w", err)
+	}
+
+	var file ratesFile
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing rates file: %w", err)
+	}
+
+	spread := decimal.Zero
+	if file.Spread != "" {
+		if spread, err = decimal.NewFromString(file.Spread); err != nil {
+			return nil, fmt.Errorf("invalid default spread: %w", err)
+		}
+	}
+
+	table := newRateTable(spread)
+	for name, node := range file.Rates {
+		if err = loadRate(table, name, &node); err != nil {
+			return nil, err
+		}
+	}
+
+	return &staticRateProvider{table: table}, nil
+}
+
+// loadRate разбирает запись о курсе (строку с курсом или объект rate/spread) и добавляет ее в таблицу.
+func loadRate(table *rateTable, name string, node *yaml.Node) error {
+	base, quote, err := parsePair(name)
+	if err != nil {
+		return err
+	}
+
+	var entry rateEntry
+	if node.Kind == yaml.ScalarNode {
+		entry.Rate = node.Value
+	} else if err = node.Decode(&entry); err != nil {
+		return fmt.Errorf("invalid rate %s: %w", name, err)
+	}
+
+	rate, err := decimal.NewFromString(entry.Rate)
+	if err != nil {
+		return fmt.Errorf("invalid rate %s: %w", name, err)
+	}
+
+	var spread *decimal.Decimal
+	if entry.Spread != nil {
+		s, err := decimal.NewFromString(*entry.Spread)
+		if err != nil {
+			return fmt.Errorf("invalid spread %s: %w", name, err)
+		}
+		spread = &s
+	}
+
+	return table.set(base, quote, rate, spread)
+}
+
+// Rate возвращает курс обмена base на quote.
+//
+// Параметры:
+//   - ctx: контекст выполнения (не используется)
+//   - base: валюта отправителя
+//   - quote: валюта получателя
+//
+// Возвращает:
+//   - *models.FXRate: курс и спред
+//   - error: er.ErrFXRateNotFound, если курса нет в файле
+func (p *staticRateProvider) Rate(_ context.Context, base, quote string) (*models.FXRate, error) {
+	return p.table.lookup(base, quote)
+}
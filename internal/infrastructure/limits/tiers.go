@@ -0,0 +1,116 @@
+// Package limits содержит реализацию источника лимитов расходов (repository.LimitProvider)
+// на основе конфигурации приложения.
+package limits
+
+import (
+	"fmt"
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/shopspring/decimal"
+	"strings"
+)
+
+// staticTiers - уровни лимитов, загруженные из конфигурации при старте.
+// После загрузки не изменяются, поэтому синхронизация не нужна.
+type staticTiers struct {
+	tiers       map[string]map[string]models.SpendingLimits
+	defaultTier string
+}
+
+// ProvideLimits разбирает и проверяет уровни лимитов расходов из конфигурации.
+//
+// Параметры:
+//   - cfg: уровни лимитов (config.Config.Limits)
+//
+// Возвращает:
+//   - repository.LimitProvider: источник лимитов
+//   - error: ошибка, если лимит задан некорректно или уровень по умолчанию не описан
+func ProvideLimits(cfg config.LimitsConfig) (repository.LimitProvider, error) {
+	provider := &staticTiers{
+		tiers:       make(map[string]map[string]models.SpendingLimits, len(cfg.Tiers)),
+		defaultTier: normalize(cfg.DefaultTier),
+	}
+
+	for tier, byCurrency := range cfg.Tiers {
+		// viper приводит ключи к нижнему регистру
+		tier = normalize(tier)
+
+		provider.tiers[tier] = make(map[string]models.SpendingLimits, len(byCurrency))
+		for currency, limitCfg := range byCurrency {
+			currency = strings.ToUpper(currency)
+
+			limits, err := parseLimits(limitCfg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid spending limits %s/%s: %w", tier, currency, err)
+			}
+			provider.tiers[tier][currency] = limits
+		}
+	}
+
+	// Без описанных уровней лимиты не применяются, и уровень по умолчанию не обязателен
+	if len(provider.tiers) > 0 && !provider.HasTier(provider.defaultTier) {
+		return nil, fmt.Errorf("default_tier %q is not defined in limits.tiers", provider.defaultTier)
+	}
+
+	return provider, nil
+}
+
+// DefaultTier возвращает уровень кошельков, которым уровень не назначен.
+func (p *staticTiers) DefaultTier() string {
+	return p.defaultTier
+}
+
+// HasTier сообщает, описан ли уровень в конфигурации.
+func (p *staticTiers) HasTier(tier string) bool {
+	_, ok := p.tiers[tier]
+	return ok
+}
+
+// Limits возвращает лимиты уровня для валюты (пустые, если они не настроены).
+func (p *staticTiers) Limits(tier, currency string) models.SpendingLimits {
+	return p.tiers[tier][currency]
+}
+
+// parseLimits разбирает лимиты уровня в одной валюте.
+func parseLimits(cfg config.SpendingLimitConfig) (models.SpendingLimits, error) {
+	var limits models.SpendingLimits
+	var err error
+
+	if limits.MaxSingle, err = parseLimit("max_single", cfg.MaxSingle); err != nil {
+		return limits, err
+	}
+	if limits.Daily, err = parseLimit("daily", cfg.Daily); err != nil {
+		return limits, err
+	}
+	if limits.Weekly, err = parseLimit("weekly", cfg.Weekly); err != nil {
+		return limits, err
+	}
+	if limits.Monthly, err = parseLimit("monthly", cfg.Monthly); err != nil {
+		return limits, err
+	}
+
+	return limits, nil
+}
+
+// parseLimit разбирает необязательный неотрицательный лимит (пустая строка - nil).
+func parseLimit(name, value string) (*decimal.Decimal, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	limit, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if limit.IsNegative() {
+		return nil, fmt.Errorf("%s must not be negative", name)
+	}
+
+	return &limit, nil
+}
+
+// normalize приводит имя уровня к виду, в котором оно хранится.
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
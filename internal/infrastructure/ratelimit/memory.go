@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"sync"
+	"time"
+)
+
+// memoryStore - хранилище корзин токенов в памяти процесса.
+// Корзины не разделяются между экземплярами приложения, поэтому хранилище
+// подходит для запуска в одном экземпляре.
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*models.RateLimitBucket
+}
+
+// NewMemoryStore создает пустое хранилище корзин токенов в памяти.
+//
+// Возвращает:
+//   - repository.RateLimitStore: хранилище в памяти
+func NewMemoryStore() repository.RateLimitStore {
+	return &memoryStore{buckets: make(map[string]*models.RateLimitBucket)}
+}
+
+// Take забирает токен из корзины ключа.
+func (s *memoryStore) Take(_ context.Context, key string, limit models.RateLimit) (models.RateLimitDecision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &models.RateLimitBucket{Key: key}
+		s.buckets[key] = bucket
+	}
+
+	return limit.Take(bucket, time.Now()), nil
+}
+
+// Sweep удаляет заполненные корзины.
+func (s *memoryStore) Sweep(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var swept int64
+	for key, bucket := range s.buckets {
+		if !bucket.FullAt.After(now) {
+			delete(s.buckets, key)
+			swept++
+		}
+	}
+
+	return swept, nil
+}
@@ -0,0 +1,67 @@
+// Package ratelimit содержит хранилища корзин токенов ограничения частоты запросов
+// (repository.RateLimitStore) и разбор ограничений из конфигурации.
+package ratelimit
+
+import (
+	"fmt"
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/db/postgres/repositories"
+	"gorm.io/gorm"
+	"strings"
+)
+
+const (
+	StoreMemory   = "memory"   // Корзины в памяти процесса (один экземпляр приложения)
+	StorePostgres = "postgres" // Корзины в БД, общие для всех экземпляров
+)
+
+// ProvideStore создает хранилище корзин токенов по конфигурации.
+//
+// Параметры:
+//   - cfg: конфигурация ограничения частоты запросов
+//   - db: подключение к БД для хранилища postgres
+//
+// Возвращает:
+//   - repository.RateLimitStore: хранилище в памяти или в БД
+//   - error: ошибка, если хранилище неизвестно
+func ProvideStore(cfg config.RateLimitConfig, db *gorm.DB) (repository.RateLimitStore, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Store)) {
+	case StoreMemory, "":
+		return NewMemoryStore(), nil
+	case StorePostgres:
+		return repositories.NewRateLimitStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q (expected %s or %s)", cfg.Store, StoreMemory, StorePostgres)
+	}
+}
+
+// ParseLimit проверяет ограничение из конфигурации. Емкость корзины по умолчанию
+// равна числу запросов за период; нулевое число запросов отключает ограничение.
+//
+// Параметры:
+//   - name: имя ограничения для сообщения об ошибке
+//   - cfg: ограничение из конфигурации
+//
+// Возвращает:
+//   - models.RateLimit: ограничение частоты
+//   - error: ошибка, если значения отрицательны или не задан период
+func ParseLimit(name string, cfg config.RateLimitRule) (models.RateLimit, error) {
+	if cfg.Requests < 0 || cfg.Burst < 0 || cfg.Period < 0 {
+		return models.RateLimit{}, fmt.Errorf("rate limit %s: values must not be negative", name)
+	}
+	if cfg.Requests == 0 {
+		return models.RateLimit{}, nil
+	}
+	if cfg.Period == 0 {
+		return models.RateLimit{}, fmt.Errorf("rate limit %s: period is required", name)
+	}
+
+	limit := models.RateLimit{Requests: cfg.Requests, Period: cfg.Period, Burst: cfg.Burst}
+	if limit.Burst == 0 {
+		limit.Burst = limit.Requests
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,117 @@
+// Package rbac содержит реализацию политики доступа (repository.AccessPolicy)
+// на основе конфигурации приложения.
+package rbac
+
+import (
+	"fmt"
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"strings"
+)
+
+// staticPolicy - политика, загруженная из конфигурации при старте.
+// После загрузки не изменяется, поэтому синхронизация не нужна.
+type staticPolicy struct {
+	roles          map[string]map[models.Permission]bool
+	anonymousRole  string
+	defaultRole    string
+	adminTokenRole string
+}
+
+// ProvideAccessPolicy разбирает и проверяет политику доступа из конфигурации.
+//
+// Параметры:
+//   - cfg: роли и их разрешения (config.Config.RBAC)
+//
+// Возвращает:
+//   - repository.AccessPolicy: политика доступа
+//   - error: ошибка, если роль ссылается на неизвестное разрешение
+//     или роль по умолчанию, анонимная роль либо роль администратора не описана
+func ProvideAccessPolicy(cfg config.RBACConfig) (repository.AccessPolicy, error) {
+	policy := &staticPolicy{
+		roles:          make(map[string]map[models.Permission]bool, len(cfg.Roles)),
+		anonymousRole:  normalize(cfg.AnonymousRole),
+		defaultRole:    normalize(cfg.DefaultRole),
+		adminTokenRole: normalize(cfg.AdminTokenRole),
+	}
+
+	for role, permissions := range cfg.Roles {
+		// viper приводит ключи к нижнему регистру
+		role = normalize(role)
+
+		allowed, err := parsePermissions(permissions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role %s: %w", role, err)
+		}
+		policy.roles[role] = allowed
+	}
+
+	for name, role := range map[string]string{
+		"default_role":     policy.defaultRole,
+		"admin_token_role": policy.adminTokenRole,
+	} {
+		if !policy.HasRole(role) {
+			return nil, fmt.Errorf("%s %q is not defined in rbac.roles", name, role)
+		}
+	}
+
+	if policy.anonymousRole != "" && !policy.HasRole(policy.anonymousRole) {
+		return nil, fmt.Errorf("anonymous_role %q is not defined in rbac.roles", policy.anonymousRole)
+	}
+
+	return policy, nil
+}
+
+// Role возвращает роль субъекта операции: анонимную роль для запроса без API-ключа,
+// роль администратора для привилегированного клиента, иначе роль учетной записи
+// или роль по умолчанию.
+func (p *staticPolicy) Role(principal *models.Principal) string {
+	switch {
+	case principal == nil:
+		return p.anonymousRole
+	case principal.Privileged:
+		return p.adminTokenRole
+	case principal.Role != "":
+		return principal.Role
+	default:
+		return p.defaultRole
+	}
+}
+
+// Allows сообщает, есть ли у роли разрешение.
+func (p *staticPolicy) Allows(role string, permission models.Permission) bool {
+	allowed := p.roles[role]
+	return allowed[models.PermissionAll] || allowed[permission]
+}
+
+// HasRole сообщает, описана ли роль в конфигурации.
+func (p *staticPolicy) HasRole(role string) bool {
+	_, ok := p.roles[role]
+	return ok
+}
+
+// parsePermissions разбирает разрешения одной роли.
+func parsePermissions(permissions []string) (map[models.Permission]bool, error) {
+	known := make(map[models.Permission]bool, len(models.Permissions)+1)
+	known[models.PermissionAll] = true
+	for _, permission := range models.Permissions {
+		known[permission] = true
+	}
+
+	allowed := make(map[models.Permission]bool, len(permissions))
+	for _, name := range permissions {
+		permission := models.Permission(normalize(name))
+		if !known[permission] {
+			return nil, fmt.Errorf("unknown permission %q", name)
+		}
+		allowed[permission] = true
+	}
+
+	return allowed, nil
+}
+
+// normalize приводит имя роли или разрешения к виду, в котором оно хранится в политике.
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,275 @@
+package risk
+
+import (
+	"fmt"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/shopspring/decimal"
+	"strings"
+	"time"
+)
+
+// conditionFactory разбирает значение условия из файла правил.
+type conditionFactory func(value any) (models.RiskCondition, error)
+
+// conditions - известные виды условий в порядке проверки: условия, требующие
+// запросов к БД, проверяются последними, чтобы не выполнять их без необходимости.
+// Новый вид условия подключается добавлением записи.
+var conditions = []struct {
+	key     string
+	factory conditionFactory
+}{
+	{"currency", newCurrencyCondition},
+	{"amount_gt", newAmountCondition},
+	{"sender_age_lt", newAgeCondition("sender", func(facts *models.RiskFacts) *models.Wallet { return facts.Sender })},
+	{"receiver_age_lt", newAgeCondition("receiver", func(facts *models.RiskFacts) *models.Wallet { return facts.Receiver })},
+	{"sender_transfers_gt", newTransferCountCondition},
+	{"sender_volume_gt", newTransferVolumeCondition},
+}
+
+// parseConditions разбирает условия правила. Правило без условий и неизвестные условия не допускаются.
+func parseConditions(when map[string]any) ([]models.RiskCondition, error) {
+	if len(when) == 0 {
+		return nil, fmt.Errorf("at least one condition is required")
+	}
+
+	values := make(map[string]any, len(when))
+	for key, value := range when {
+		values[strings.ToLower(key)] = value
+	}
+
+	parsed := make([]models.RiskCondition, 0, len(values))
+	for _, c := range conditions {
+		value, ok := values[c.key]
+		if !ok {
+			continue
+		}
+		delete(values, c.key)
+
+		condition, err := c.factory(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", c.key, err)
+		}
+		parsed = append(parsed, condition)
+	}
+
+	for key := range values {
+		return nil, fmt.Errorf("unknown condition %q", key)
+	}
+
+	return parsed, nil
+}
+
+// currencyCondition выполняется, если валюта отправителя входит в список.
+type currencyCondition map[string]bool
+
+// newCurrencyCondition разбирает код валюты или список кодов.
+func newCurrencyCondition(value any) (models.RiskCondition, error) {
+	var codes []any
+	if list, ok := value.([]any); ok {
+		codes = list
+	} else {
+		codes = []any{value}
+	}
+
+	condition := make(currencyCondition, len(codes))
+	for _, code := range codes {
+		s, err := scalar(code)
+		if err != nil || strings.TrimSpace(s) == "" {
+			return nil, fmt.Errorf("currency code must be a string or a list of strings")
+		}
+		condition[strings.ToUpper(strings.TrimSpace(s))] = true
+	}
+
+	if len(condition) == 0 {
+		return nil, fmt.Errorf("at least one currency is required")
+	}
+	return condition, nil
+}
+
+// Match сообщает, входит ли валюта отправителя в список.
+func (c currencyCondition) Match(facts *models.RiskFacts) (bool, error) {
+	return c[facts.Transaction.Currency], nil
+}
+
+// amountCondition выполняется, если сумма списания (без комиссии) больше порога.
+type amountCondition struct {
+	threshold decimal.Decimal
+}
+
+// newAmountCondition разбирает порог суммы списания.
+func newAmountCondition(value any) (models.RiskCondition, error) {
+	threshold, err := parseAmount(value)
+	if err != nil {
+		return nil, err
+	}
+	return amountCondition{threshold: threshold}, nil
+}
+
+// Match сообщает, больше ли сумма списания порога.
+func (c amountCondition) Match(facts *models.RiskFacts) (bool, error) {
+	return facts.Transaction.Amount.GreaterThan(c.threshold), nil
+}
+
+// ageCondition выполняется, если кошелек создан меньше maxAge назад.
+type ageCondition struct {
+	wallet func(facts *models.RiskFacts) *models.Wallet
+	maxAge time.Duration
+}
+
+// newAgeCondition возвращает разбор условия на возраст кошелька отправителя или получателя.
+func newAgeCondition(side string, wallet func(facts *models.RiskFacts) *models.Wallet) conditionFactory {
+	return func(value any) (models.RiskCondition, error) {
+		maxAge, err := parseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s wallet age: %w", side, err)
+		}
+		return ageCondition{wallet: wallet, maxAge: maxAge}, nil
+	}
+}
+
+// Match сообщает, создан ли кошелек меньше maxAge назад.
+func (c ageCondition) Match(facts *models.RiskFacts) (bool, error) {
+	return facts.Now.Sub(c.wallet(facts).CreatedAt) < c.maxAge, nil
+}
+
+// transferCountCondition выполняется, если число переводов отправителя за окно
+// вместе с проверяемым больше порога.
+type transferCountCondition struct {
+	count  int64
+	window time.Duration
+}
+
+// newTransferCountCondition разбирает порог числа переводов {count, window}.
+func newTransferCountCondition(value any) (models.RiskCondition, error) {
+	params, err := parseParams(value, "count", "window")
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := parseDuration(params["window"])
+	if err != nil {
+		return nil, fmt.Errorf("window: %w", err)
+	}
+
+	count, err := parseAmount(params["count"])
+	if err != nil || !count.IsInteger() {
+		return nil, fmt.Errorf("count must be a non-negative integer")
+	}
+
+	return transferCountCondition{count: count.IntPart(), window: window}, nil
+}
+
+// Match сообщает, превысит ли проверяемый перевод порог числа переводов за окно.
+func (c transferCountCondition) Match(facts *models.RiskFacts) (bool, error) {
+	count, _, err := facts.Outgoing(facts.Now.Add(-c.window))
+	if err != nil {
+		return false, err
+	}
+	return count+1 > c.count, nil
+}
+
+// transferVolumeCondition выполняется, если сумма переводов отправителя за окно
+// вместе с проверяемым больше порога.
+type transferVolumeCondition struct {
+	amount decimal.Decimal
+	window time.Duration
+}
+
+// newTransferVolumeCondition разбирает порог суммы переводов {amount, window}.
+func newTransferVolumeCondition(value any) (models.RiskCondition, error) {
+	params, err := parseParams(value, "amount", "window")
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := parseDuration(params["window"])
+	if err != nil {
+		return nil, fmt.Errorf("window: %w", err)
+	}
+
+	amount, err := parseAmount(params["amount"])
+	if err != nil {
+		return nil, fmt.Errorf("amount: %w", err)
+	}
+
+	return transferVolumeCondition{amount: amount, window: window}, nil
+}
+
+// Match сообщает, превысит ли проверяемый перевод порог суммы переводов за окно.
+func (c transferVolumeCondition) Match(facts *models.RiskFacts) (bool, error) {
+	_, volume, err := facts.Outgoing(facts.Now.Add(-c.window))
+	if err != nil {
+		return false, err
+	}
+	return volume.Add(facts.Transaction.Amount).GreaterThan(c.amount), nil
+}
+
+// parseParams проверяет, что значение условия - объект ровно с указанными полями.
+func parseParams(value any, keys ...string) (map[string]any, error) {
+	raw, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("must be an object with fields %s", strings.Join(keys, ", "))
+	}
+
+	params := make(map[string]any, len(raw))
+	for key, v := range raw {
+		params[strings.ToLower(key)] = v
+	}
+
+	for _, key := range keys {
+		if _, ok := params[key]; !ok {
+			return nil, fmt.Errorf("field %s is required", key)
+		}
+	}
+	if len(params) != len(keys) {
+		return nil, fmt.Errorf("only fields %s are allowed", strings.Join(keys, ", "))
+	}
+
+	return params, nil
+}
+
+// parseAmount разбирает неотрицательную сумму (строкой или числом).
+func parseAmount(value any) (decimal.Decimal, error) {
+	s, err := scalar(value)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	amount, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount %q", s)
+	}
+	if amount.IsNegative() {
+		return decimal.Zero, fmt.Errorf("amount must not be negative")
+	}
+	return amount, nil
+}
+
+// parseDuration разбирает положительную длительность в формате time.ParseDuration ("24h", "1m").
+func parseDuration(value any) (time.Duration, error) {
+	s, err := scalar(value)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
+
+// scalar возвращает строковое представление скалярного значения из YAML.
+func scalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("must be a string or a number")
+	}
+}
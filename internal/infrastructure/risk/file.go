@@ -0,0 +1,156 @@
+package risk
+
+import (
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/spf13/viper"
+	"log"
+	"strings"
+	"sync"
+)
+
+// ruleConfig описывает правило в файле правил:
+//
+//	rules:
+//	  - name: large-transfer-to-new-wallet
+//	    outcome: deny               # allow, deny или review
+//	    reason: "..."               # причина решения (по умолчанию - имя правила)
+//	    when:                       # условия (все должны выполняться)
+//	      amount_gt: "50000"
+//	      receiver_age_lt: "24h"
+type ruleConfig struct {
+	Name    string         `mapstructure:"name"`
+	Outcome string         `mapstructure:"outcome"`
+	Reason  string         `mapstructure:"reason"`
+	When    map[string]any `mapstructure:"when"`
+}
+
+// fileRuleSource - источник правил из YAML-файла. Файл отслеживается viper и
+// перечитывается при изменении; правила заменяются целиком, поэтому проверка
+// перевода всегда использует согласованный набор правил.
+type fileRuleSource struct {
+	path  string
+	mu    sync.RWMutex
+	rules []models.RiskRule
+}
+
+// NewFileRuleSource загружает правила из YAML-файла и начинает отслеживать его изменения.
+// Если измененный файл содержит ошибку, продолжают действовать прежние правила.
+//
+// Параметры:
+//   - path: путь к файлу правил
+//
+// Возвращает:
+//   - repository.RiskRuleSource: источник правил из файла
+//   - error: ошибка чтения файла или невалидное правило
+func NewFileRuleSource(path string) (repository.RiskRuleSource, error) {
+	rules, err := loadRules(path)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &fileRuleSource{path: path, rules: rules}
+
+	watcher := viper.New()
+	watcher.SetConfigFile(path)
+	watcher.OnConfigChange(func(fsnotify.Event) {
+		source.reload()
+	})
+	watcher.WatchConfig()
+
+	return source, nil
+}
+
+// Rules возвращает действующие правила в порядке проверки.
+func (s *fileRuleSource) Rules() []models.RiskRule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rules
+}
+
+// reload перечитывает файл правил. Правила заменяются, только если файл разобран без ошибок.
+func (s *fileRuleSource) reload() {
+	rules, err := loadRules(s.path)
+	if err != nil {
+		log.Printf("[ERROR] Error reloading risk rules, previous rules are kept: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.rules = rules
+	s.mu.Unlock()
+
+	log.Printf("[INFO] Risk rules reloaded: %d rules", len(rules))
+}
+
+// loadRules читает и разбирает файл правил.
+func loadRules(path string) ([]models.RiskRule, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("error reading risk rules file: %w", err)
+	}
+
+	// Файл без секции rules (например, пустой во время записи редактором) не отключает правила;
+	// чтобы отключить их, файл должен содержать rules: []
+	if !v.IsSet("rules") {
+		return nil, fmt.Errorf("risk rules file has no rules section")
+	}
+
+	var configs []ruleConfig
+	if err := v.UnmarshalKey("rules", &configs); err != nil {
+		return nil, fmt.Errorf("error parsing risk rules file: %w", err)
+	}
+
+	rules := make([]models.RiskRule, 0, len(configs))
+	names := make(map[string]bool, len(configs))
+	for i := range configs {
+		rule, err := parseRule(&configs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid risk rule #%d: %w", i+1, err)
+		}
+
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate risk rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// parseRule проверяет правило и разбирает его условия.
+func parseRule(cfg *ruleConfig) (models.RiskRule, error) {
+	rule := models.RiskRule{
+		Name:    strings.TrimSpace(cfg.Name),
+		Outcome: models.RiskOutcome(strings.ToLower(strings.TrimSpace(cfg.Outcome))),
+		Reason:  strings.TrimSpace(cfg.Reason),
+	}
+
+	if rule.Name == "" {
+		return rule, fmt.Errorf("name is required")
+	}
+
+	switch rule.Outcome {
+	case models.RiskOutcomeAllow, models.RiskOutcomeDeny, models.RiskOutcomeReview:
+	default:
+		return rule, fmt.Errorf("rule %q: unknown outcome %q", rule.Name, cfg.Outcome)
+	}
+
+	if rule.Reason == "" {
+		rule.Reason = "rule " + rule.Name
+	}
+
+	conditions, err := parseConditions(cfg.When)
+	if err != nil {
+		return rule, fmt.Errorf("rule %q: %w", rule.Name, err)
+	}
+	rule.Conditions = conditions
+
+	return rule, nil
+}
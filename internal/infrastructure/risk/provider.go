@@ -0,0 +1,42 @@
+// Package risk содержит реализацию источника правил проверки переводов
+// (repository.RiskRuleSource) на основе YAML-файла, перечитываемого при изменении.
+package risk
+
+import (
+	"github.com/normalniydada/case_infotecs/config"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"log"
+)
+
+// noRules - источник без правил: все переводы разрешены.
+type noRules struct{}
+
+// Rules возвращает пустой список правил.
+func (noRules) Rules() []models.RiskRule {
+	return nil
+}
+
+// ProvideRules создает источник правил проверки переводов по конфигурации.
+//
+// Параметры:
+//   - cfg: конфигурация правил
+//
+// Возвращает:
+//   - repository.RiskRuleSource: правила из файла cfg.RulesFile, перечитываемого при изменении,
+//     или пустой источник, если файл не задан
+//   - error: ошибка чтения или разбора файла правил при запуске
+func ProvideRules(cfg config.RiskConfig) (repository.RiskRuleSource, error) {
+	if cfg.RulesFile == "" {
+		log.Println("[WARN] Risk rules file is not configured, transfers are not checked")
+		return noRules{}, nil
+	}
+
+	source, err := NewFileRuleSource(cfg.RulesFile)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[INFO] %d risk rules loaded", len(source.Rules()))
+
+	return source, nil
+}
@@ -2,12 +2,162 @@
 // Определяет структуры данных для входящих/исходящих запросов.
 package dto
 
-import "github.com/shopspring/decimal"
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
 
 // TransactionRequest представляет структуру запроса на выполнение перевода между кошельками.
 // Используется для десериализации входящих HTTP-запросов в API.
+// Задается ровно одна из сумм: Amount (списание в валюте отправителя)
+// или TargetAmount (зачисление в валюте получателя).
+// Перевод с кошелька, имеющего открытый ключ, подписывается: Signature - подпись Ed25519
+// канонического представления перевода (см. пакет walletsign) в hex-формате,
+// Nonce - номер перевода, больший номера последнего перевода с кошелька.
 type TransactionRequest struct {
-	From   string          `json:"from"`
-	To     string          `json:"to"`
+	From         string          `json:"from"`
+	To           string          `json:"to"`
+	Amount       decimal.Decimal `json:"amount"`
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	Nonce        int64           `json:"nonce"`
+	Signature    string          `json:"signature"`
+}
+
+// BatchTransferRequest представляет структуру запроса на пакетный перевод.
+// Каждый перевод (leg) задается так же, как в TransactionRequest; пакет выполняется атомарно.
+type BatchTransferRequest struct {
+	Legs []TransactionRequest `json:"legs"`
+}
+
+// HoldRequest представляет структуру запроса на резервирование средств (холд).
+// TTLSeconds необязателен: по умолчанию используется срок холда из конфигурации.
+type HoldRequest struct {
+	From       string          `json:"from"`
+	To         string          `json:"to"`
+	Amount     decimal.Decimal `json:"amount"`
+	TTLSeconds int             `json:"ttl_seconds"`
+}
+
+// CaptureHoldRequest представляет структуру запроса на списание холда.
+// Amount необязателен: по умолчанию списывается вся сумма холда.
+type CaptureHoldRequest struct {
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// RefundRequest представляет структуру запроса на возврат транзакции.
+// Amount задается в валюте отправителя исходной транзакции и необязателен:
+// по умолчанию возвращается весь еще не возвращенный остаток.
+type RefundRequest struct {
 	Amount decimal.Decimal `json:"amount"`
 }
+
+// CreateWalletRequest представляет структуру запроса на создание кошелька.
+// Начальный баланс необязателен и требует разрешения wallets:mint.
+// Валюта необязательна (по умолчанию RUB).
+// Владельцем кошелька становится учетная запись API-ключа; привилегированный клиент
+// может указать владельца в Owner (пусто - кошелек без владельца).
+// PublicKey - открытый ключ Ed25519 кошелька в hex-формате; адрес кошелька вычисляется из него.
+// Ключ обязателен для учетной записи, привилегированный клиент может создать кошелек без ключа.
+type CreateWalletRequest struct {
+	Balance   decimal.Decimal `json:"balance"`
+	Currency  string          `json:"currency"`
+	Owner     string          `json:"owner"`
+	PublicKey string          `json:"public_key"`
+}
+
+// WalletStatusRequest представляет структуру запроса на изменение статуса кошелька.
+// Status - active, frozen_in, frozen_out или frozen; Reason (причина) обязательна.
+type WalletStatusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// WalletLimitsRequest представляет структуру запроса на изменение лимитов расходов кошелька.
+// Tier - уровень лимитов (пустая строка - уровень по умолчанию). Заданные лимиты заменяют
+// лимиты уровня, незаданные (null) берутся из уровня. Запрос заменяет прежние настройки целиком.
+type WalletLimitsRequest struct {
+	Tier      string           `json:"tier"`
+	MaxSingle *decimal.Decimal `json:"max_single"`
+	Daily     *decimal.Decimal `json:"daily"`
+	Weekly    *decimal.Decimal `json:"weekly"`
+	Monthly   *decimal.Decimal `json:"monthly"`
+}
+
+// ApprovalPolicyRequest представляет структуру запроса на изменение политики согласования кошелька.
+// Threshold - порог суммы списания (null - без порога), RequiredApprovals - число одобрений
+// (0 - одно), Approvers - публичные идентификаторы согласующих учетных записей (пустой список - любые
+// с разрешением transfers:approve). Запрос заменяет прежнюю политику целиком.
+type ApprovalPolicyRequest struct {
+	Threshold         *decimal.Decimal `json:"threshold"`
+	RequiredApprovals int              `json:"required_approvals"`
+	Approvers         []string         `json:"approvers"`
+}
+
+// PendingTransferDecisionRequest представляет структуру запроса на одобрение или отклонение
+// отложенного перевода. Comment - необязательный комментарий согласующего.
+type PendingTransferDecisionRequest struct {
+	Comment string `json:"comment"`
+}
+
+// TransactionHistoryRequest представляет параметры запроса истории транзакций кошелька.
+// Все параметры передаются в query-строке и необязательны.
+type TransactionHistoryRequest struct {
+	Direction   string `query:"direction"`    // incoming, outgoing или both (по умолчанию)
+	CreatedFrom string `query:"created_from"` // Нижняя граница даты в формате RFC3339 (включительно)
+	CreatedTo   string `query:"created_to"`   // Верхняя граница даты в формате RFC3339 (не включительно)
+	MinAmount   string `query:"min_amount"`   // Минимальная сумма (включительно)
+	MaxAmount   string `query:"max_amount"`   // Максимальная сумма (включительно)
+	Cursor      string `query:"cursor"`       // Значение next_cursor предыдущей страницы
+	Limit       string `query:"limit"`        // Размер страницы (по умолчанию 20, максимум 100)
+}
+
+// ScheduledTransferRequest представляет структуру запроса на создание запланированного перевода.
+// StartAt - время первого выполнения (по умолчанию - сразу). IntervalSeconds - период
+// регулярного перевода (0 - разовый перевод). EndAt - необязательное время, после которого
+// регулярный перевод больше не выполняется.
+type ScheduledTransferRequest struct {
+	From            string          `json:"from"`
+	To              string          `json:"to"`
+	Amount          decimal.Decimal `json:"amount"`
+	StartAt         *time.Time      `json:"start_at"`
+	IntervalSeconds int64           `json:"interval_seconds"`
+	EndAt           *time.Time      `json:"end_at"`
+}
+
+// UpdateScheduledTransferRequest представляет структуру запроса на изменение запланированного перевода.
+// Изменяются только переданные поля. Status принимает значения active и paused.
+type UpdateScheduledTransferRequest struct {
+	Amount          *decimal.Decimal `json:"amount"`
+	IntervalSeconds *int64           `json:"interval_seconds"`
+	EndAt           *time.Time       `json:"end_at"`
+	Status          *string          `json:"status"`
+}
+
+// WebhookRequest представляет структуру запроса на подписку на события кошелька.
+// Events - типы событий: transfer.incoming (зачисление) и/или transfer.outgoing (списание).
+type WebhookRequest struct {
+	Wallet string   `json:"wallet"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// AccountRequest представляет структуру запроса на создание учетной записи.
+// Role - роль учетной записи (пустая строка - роль по умолчанию).
+type AccountRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// UpdateAccountRequest представляет структуру запроса на изменение учетной записи.
+// Role - новая роль (пустая строка - роль по умолчанию).
+type UpdateAccountRequest struct {
+	Role string `json:"role"`
+}
+
+// APIKeyRequest представляет структуру запроса на создание API-ключа.
+// Ключ создается для учетной записи вызывающего; привилегированный клиент
+// указывает учетную запись в AccountID.
+type APIKeyRequest struct {
+	Name      string `json:"name"`
+	AccountID string `json:"account_id"`
+}
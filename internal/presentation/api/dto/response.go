@@ -3,15 +3,659 @@
 package dto
 
 import (
+	"encoding/json"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
 	"github.com/shopspring/decimal"
 	"time"
 )
 
 // TransactionResponse представляет структуру ответа с информацией о транзакции.
 // Используется для сериализации данных о транзакции в API-ответах.
+// Amount списано в валюте Currency, TargetAmount зачислено в валюте TargetCurrency
+// по курсу Rate (уже за вычетом спреда Spread). Fee - комиссия в валюте Currency,
+// списанная с отправителя сверх Amount. У возврата RefundOf содержит идентификатор
+// исходной транзакции, у исходной транзакции RefundedAmount - уже возвращенная сумма.
+// BatchID - идентификатор пакета, в котором выполнен перевод.
 type TransactionResponse struct {
-	From      string          `json:"sender_address"`
-	To        string          `json:"receiver_address"`
-	Amount    decimal.Decimal `json:"amount"`
-	CreatedAt time.Time       `json:"date"`
+	ID             string          `json:"id"`
+	From           string          `json:"sender_address"`
+	To             string          `json:"receiver_address"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+	TargetAmount   decimal.Decimal `json:"target_amount"`
+	TargetCurrency string          `json:"target_currency"`
+	Rate           decimal.Decimal `json:"rate"`
+	Spread         decimal.Decimal `json:"spread"`
+	Fee            decimal.Decimal `json:"fee"`
+	RefundOf       *string         `json:"refund_of,omitempty"`
+	RefundedAmount decimal.Decimal `json:"refunded_amount"`
+	BatchID        *string         `json:"batch_id,omitempty"`
+	Nonce          *int64          `json:"nonce,omitempty"`
+	Signature      *string         `json:"signature,omitempty"`
+	CreatedAt      time.Time       `json:"date"`
+}
+
+// TransactionPage представляет страницу истории транзакций кошелька.
+// NextCursor передается в параметре cursor для получения следующей страницы
+// и отсутствует, если страница последняя.
+type TransactionPage struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// NewTransactionResponse преобразует модель транзакции в DTO ответа.
+//
+// Параметры:
+//   - transaction: модель транзакции
+//
+// Возвращает:
+//   - TransactionResponse: данные транзакции для API-ответа
+func NewTransactionResponse(transaction *models.Transaction) TransactionResponse {
+	return TransactionResponse{
+		ID:             transaction.PublicID,
+		From:           transaction.From,
+		To:             transaction.To,
+		Amount:         transaction.Amount,
+		Currency:       transaction.Currency,
+		TargetAmount:   transaction.TargetAmount,
+		TargetCurrency: transaction.TargetCurrency,
+		Rate:           transaction.Rate,
+		Spread:         transaction.Spread,
+		Fee:            transaction.Fee,
+		RefundOf:       transaction.RefundOf,
+		RefundedAmount: transaction.RefundedAmount,
+		BatchID:        transaction.BatchID,
+		Nonce:          transaction.Nonce,
+		Signature:      transaction.Signature,
+		CreatedAt:      transaction.CreatedAt,
+	}
+}
+
+// TransferQuote представляет предварительный расчет перевода (POST /api/send/quote).
+// TotalDebit - полная сумма списания с отправителя: Amount + Fee.
+type TransferQuote struct {
+	From           string          `json:"sender_address"`
+	To             string          `json:"receiver_address"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+	TargetAmount   decimal.Decimal `json:"target_amount"`
+	TargetCurrency string          `json:"target_currency"`
+	Rate           decimal.Decimal `json:"rate"`
+	Spread         decimal.Decimal `json:"spread"`
+	Fee            decimal.Decimal `json:"fee"`
+	TotalDebit     decimal.Decimal `json:"total_debit"`
+}
+
+// NewTransferQuote преобразует рассчитанную (не сохраненную) транзакцию в DTO расчета.
+//
+// Параметры:
+//   - transaction: подготовленная транзакция
+//
+// Возвращает:
+//   - *TransferQuote: расчет перевода для API-ответа
+func NewTransferQuote(transaction *models.Transaction) *TransferQuote {
+	return &TransferQuote{
+		From:           transaction.From,
+		To:             transaction.To,
+		Amount:         transaction.Amount,
+		Currency:       transaction.Currency,
+		TargetAmount:   transaction.TargetAmount,
+		TargetCurrency: transaction.TargetCurrency,
+		Rate:           transaction.Rate,
+		Spread:         transaction.Spread,
+		Fee:            transaction.Fee,
+		TotalDebit:     transaction.Debited(),
+	}
+}
+
+// BalanceResponse представляет структуру ответа с балансом кошелька.
+// Total (и совпадающий с ним Balance) - баланс по журналу проводок,
+// Available - часть баланса, не зарезервированная холдами.
+type BalanceResponse struct {
+	Balance   decimal.Decimal `json:"balance"`
+	Available decimal.Decimal `json:"available"`
+	Total     decimal.Decimal `json:"total"`
+	Currency  string          `json:"currency"`
+}
+
+// HoldResponse представляет структуру ответа с информацией о холде.
+// Transaction заполняется в ответе на списание холда.
+type HoldResponse struct {
+	ID             string               `json:"id"`
+	From           string               `json:"sender_address"`
+	To             string               `json:"receiver_address"`
+	Amount         decimal.Decimal      `json:"amount"`
+	Reserved       decimal.Decimal      `json:"reserved"`
+	CapturedAmount decimal.Decimal      `json:"captured_amount"`
+	Currency       string               `json:"currency"`
+	Status         string               `json:"status"`
+	ExpiresAt      time.Time            `json:"expires_at"`
+	CreatedAt      time.Time            `json:"created_at"`
+	TransactionID  *string              `json:"transaction_id,omitempty"`
+	Transaction    *TransactionResponse `json:"transaction,omitempty"`
+}
+
+// NewHoldResponse преобразует модель холда в DTO ответа.
+//
+// Параметры:
+//   - hold: модель холда
+//
+// Возвращает:
+//   - *HoldResponse: данные холда для API-ответа
+func NewHoldResponse(hold *models.Hold) *HoldResponse {
+	return &HoldResponse{
+		ID:             hold.PublicID,
+		From:           hold.From,
+		To:             hold.To,
+		Amount:         hold.Amount,
+		Reserved:       hold.Reserved,
+		CapturedAmount: hold.CapturedAmount,
+		Currency:       hold.Currency,
+		Status:         string(hold.Status),
+		ExpiresAt:      hold.ExpiresAt,
+		CreatedAt:      hold.CreatedAt,
+		TransactionID:  hold.TransactionID,
+	}
+}
+
+// WalletResponse представляет структуру ответа с полной информацией о кошельке.
+// Используется для сериализации данных о кошельке в API-ответах.
+// PublicKey и Nonce заданы только у кошелька с открытым ключом: Nonce - номер
+// последнего подписанного перевода, следующий перевод подписывается с большим номером.
+type WalletResponse struct {
+	Address   string          `json:"address"`
+	Balance   decimal.Decimal `json:"balance"`
+	Currency  string          `json:"currency"`
+	Status    string          `json:"status"`
+	PublicKey *string         `json:"public_key,omitempty"`
+	Nonce     *int64          `json:"nonce,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+	ClosedAt  *time.Time      `json:"closed_at,omitempty"`
+}
+
+// WalletStatusChangeResponse представляет структуру ответа с записью истории статусов кошелька.
+// AccountID и KeyID отсутствуют, если статус изменил администратор без API-ключа.
+type WalletStatusChangeResponse struct {
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"`
+	AccountID  *string   `json:"account_id,omitempty"`
+	KeyID      *string   `json:"key_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewWalletStatusChangeResponse преобразует запись истории статусов в DTO ответа.
+//
+// Параметры:
+//   - change: модель изменения статуса
+//
+// Возвращает:
+//   - WalletStatusChangeResponse: данные изменения для API-ответа
+func NewWalletStatusChangeResponse(change *models.WalletStatusChange) WalletStatusChangeResponse {
+	return WalletStatusChangeResponse{
+		FromStatus: string(change.FromStatus),
+		ToStatus:   string(change.ToStatus),
+		Reason:     change.Reason,
+		AccountID:  change.AccountID,
+		KeyID:      change.KeyID,
+		CreatedAt:  change.CreatedAt,
+	}
+}
+
+// LimitResponse представляет один лимит расходов кошелька.
+// Used - расход за текущий период, Remaining - сумма, которую еще можно перевести,
+// ResetsAt - окончание периода (отсутствует у лимита одного перевода).
+type LimitResponse struct {
+	Period    string          `json:"period"`
+	Limit     decimal.Decimal `json:"limit"`
+	Used      decimal.Decimal `json:"used"`
+	Remaining decimal.Decimal `json:"remaining"`
+	ResetsAt  *time.Time      `json:"resets_at,omitempty"`
+}
+
+// WalletLimitsResponse представляет структуру ответа с лимитами расходов кошелька.
+// Limits содержит только заданные лимиты (пустой список - расход не ограничен).
+type WalletLimitsResponse struct {
+	Address   string          `json:"address"`
+	Currency  string          `json:"currency"`
+	Tier      string          `json:"tier"`
+	Limits    []LimitResponse `json:"limits"`
+	CheckedAt time.Time       `json:"checked_at"`
+}
+
+// NewWalletLimitsResponse преобразует лимиты кошелька и их использование в DTO ответа.
+//
+// Параметры:
+//   - status: лимиты и расход кошелька
+//
+// Возвращает:
+//   - *WalletLimitsResponse: данные лимитов для API-ответа
+func NewWalletLimitsResponse(status *models.LimitStatus) *WalletLimitsResponse {
+	resp := &WalletLimitsResponse{
+		Address:   status.Wallet.Address,
+		Currency:  status.Wallet.Currency,
+		Tier:      status.Tier,
+		Limits:    []LimitResponse{},
+		CheckedAt: status.At,
+	}
+
+	for _, headroom := range status.Limits.Headroom(status.Usage, status.Windows) {
+		resp.Limits = append(resp.Limits, LimitResponse{
+			Period:    string(headroom.Period),
+			Limit:     headroom.Limit,
+			Used:      headroom.Used,
+			Remaining: headroom.Remaining,
+			ResetsAt:  headroom.ResetsAt,
+		})
+	}
+
+	return resp
+}
+
+// BalanceMismatch представляет расхождение баланса кошелька с журналом проводок.
+type BalanceMismatch struct {
+	Address       string          `json:"address"`
+	Balance       decimal.Decimal `json:"balance"`
+	LedgerBalance decimal.Decimal `json:"ledger_balance"`
+}
+
+// LedgerReport представляет результат проверки согласованности журнала двойной записи.
+type LedgerReport struct {
+	Consistent             bool              `json:"consistent"`
+	CheckedAt              time.Time         `json:"checked_at"`
+	BalanceMismatches      []BalanceMismatch `json:"balance_mismatches"`
+	UnbalancedTransactions []string          `json:"unbalanced_transactions"`
+}
+
+// StoredResponse представляет ответ на запрос перевода в готовом к отправке виде.
+// Используется для повторной выдачи сохраненного результата по ключу идемпотентности.
+type StoredResponse struct {
+	StatusCode int    // HTTP-код ответа
+	Body       []byte // Тело ответа в формате JSON
+	Replayed   bool   // Признак того, что ответ взят из сохраненного результата
+}
+
+// BatchTransferResponse представляет результат пакетного перевода.
+// Legs содержит квитанции о переводах в порядке их следования в запросе.
+type BatchTransferResponse struct {
+	BatchID string                `json:"batch_id"`
+	Legs    []TransactionResponse `json:"legs"`
+}
+
+// ScheduledTransferResponse представляет структуру ответа с информацией о запланированном переводе.
+// NextRunAt - время следующей попытки, Attempts - число неудачных попыток текущего выполнения.
+// Runs содержит последние попытки выполнения (только в ответе на запрос одного перевода).
+type ScheduledTransferResponse struct {
+	ID              string                         `json:"id"`
+	From            string                         `json:"from"`
+	To              string                         `json:"to"`
+	Amount          decimal.Decimal                `json:"amount"`
+	IntervalSeconds int64                          `json:"interval_seconds"`
+	EndAt           *time.Time                     `json:"end_at,omitempty"`
+	Status          string                         `json:"status"`
+	NextRunAt       time.Time                      `json:"next_run_at"`
+	Attempts        int                            `json:"attempts"`
+	CreatedAt       time.Time                      `json:"created_at"`
+	Runs            []ScheduledTransferRunResponse `json:"runs,omitempty"`
+}
+
+// ScheduledTransferRunResponse представляет попытку выполнения запланированного перевода.
+type ScheduledTransferRunResponse struct {
+	OccurrenceAt      time.Time `json:"occurrence_at"`
+	Attempt           int       `json:"attempt"`
+	Status            string    `json:"status"`
+	TransactionID     *string   `json:"transaction_id,omitempty"`
+	PendingTransferID *string   `json:"pending_transfer_id,omitempty"`
+	Error             *string   `json:"error,omitempty"`
+	CreatedAt         time.Time `json:"date"`
+}
+
+// NewScheduledTransferResponse преобразует модель запланированного перевода в DTO ответа.
+//
+// Параметры:
+//   - transfer: модель запланированного перевода
+//   - runs: попытки выполнения (может быть nil)
+//
+// Возвращает:
+//   - ScheduledTransferResponse: данные перевода для API-ответа
+func NewScheduledTransferResponse(transfer *models.ScheduledTransfer,
+	runs []models.ScheduledTransferRun) ScheduledTransferResponse {
+	resp := ScheduledTransferResponse{
+		ID:              transfer.PublicID,
+		From:            transfer.From,
+		To:              transfer.To,
+		Amount:          transfer.Amount,
+		IntervalSeconds: transfer.IntervalSeconds,
+		EndAt:           transfer.EndAt,
+		Status:          string(transfer.Status),
+		NextRunAt:       transfer.NextRunAt,
+		Attempts:        transfer.Attempts,
+		CreatedAt:       transfer.CreatedAt,
+	}
+
+	for i := range runs {
+		resp.Runs = append(resp.Runs, ScheduledTransferRunResponse{
+			OccurrenceAt:      runs[i].OccurrenceAt,
+			Attempt:           runs[i].Attempt,
+			Status:            string(runs[i].Status),
+			TransactionID:     runs[i].TransactionID,
+			PendingTransferID: runs[i].PendingTransferID,
+			Error:             runs[i].Error,
+			CreatedAt:         runs[i].CreatedAt,
+		})
+	}
+
+	return resp
+}
+
+// WebhookResponse представляет структуру ответа с информацией о подписке на вебхук.
+// Secret возвращается только при создании подписки.
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	Wallet    string    `json:"wallet"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewWebhookResponse преобразует модель подписки в DTO ответа (без секрета).
+//
+// Параметры:
+//   - subscription: модель подписки
+//
+// Возвращает:
+//   - WebhookResponse: данные подписки для API-ответа
+func NewWebhookResponse(subscription *models.WebhookSubscription) WebhookResponse {
+	return WebhookResponse{
+		ID:        subscription.PublicID,
+		Wallet:    subscription.Wallet,
+		URL:       subscription.URL,
+		Events:    subscription.EventTypes,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
+
+// WebhookDeliveryResponse представляет доставку вебхука и результат ее последней попытки.
+// NextAttemptAt заполнено только у ожидающей доставки.
+type WebhookDeliveryResponse struct {
+	ID             string     `json:"id"`
+	EventID        uint       `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// NewWebhookDeliveryResponse преобразует модель доставки вебхука в DTO ответа.
+//
+// Параметры:
+//   - delivery: модель доставки
+//
+// Возвращает:
+//   - WebhookDeliveryResponse: данные доставки для API-ответа
+func NewWebhookDeliveryResponse(delivery *models.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             delivery.PublicID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+
+	if delivery.Status == models.WebhookDeliveryPending {
+		resp.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return resp
+}
+
+// WebhookEvent представляет тело запроса, отправляемого подписчику вебхука.
+// ID - идентификатор доставки, одинаковый для всех ее попыток; по нему (или по EventID)
+// получатель отбрасывает повторные доставки. Data - данные события (для transfer.* -
+// перевод: transaction_id, from, to, amount, currency, target_amount, target_currency, fee).
+type WebhookEvent struct {
+	ID        string          `json:"id"`
+	EventID   uint            `json:"event_id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// AccountResponse представляет структуру ответа с информацией об учетной записи.
+// APIKey (первый ключ учетной записи) возвращается только при создании.
+type AccountResponse struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Role      string          `json:"role"`
+	CreatedAt time.Time       `json:"created_at"`
+	APIKey    *APIKeyResponse `json:"api_key,omitempty"`
+}
+
+// NewAccountResponse преобразует модель учетной записи в DTO ответа.
+//
+// Параметры:
+//   - account: модель учетной записи
+//   - role: действующая роль учетной записи (с учетом роли по умолчанию)
+//
+// Возвращает:
+//   - AccountResponse: данные учетной записи для API-ответа
+func NewAccountResponse(account *models.Account, role string) AccountResponse {
+	return AccountResponse{
+		ID:        account.PublicID,
+		Name:      account.Name,
+		Role:      role,
+		CreatedAt: account.CreatedAt,
+	}
+}
+
+// APIKeyResponse представляет структуру ответа с информацией об API-ключе.
+// Key (сам ключ) возвращается только при создании; Prefix - начало ключа.
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	AccountID  string     `json:"account_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKeyResponse преобразует модель API-ключа в DTO ответа (без ключа).
+//
+// Параметры:
+//   - key: модель API-ключа
+//   - account: учетная запись ключа
+//
+// Возвращает:
+//   - APIKeyResponse: данные ключа для API-ответа
+func NewAPIKeyResponse(key *models.APIKey, account *models.Account) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.PublicID,
+		AccountID:  account.PublicID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// AuditEventResponse представляет структуру ответа с записью журнала аудита.
+// AccountID и KeyID отсутствуют для анонимного запроса и администратора без API-ключа;
+// Path - шаблон маршрута, URI - фактический путь запроса; RemoteAddr - адрес клиента,
+// PeerAddr - адрес соединения (адрес доверенного прокси, если запрос пришел через него).
+type AuditEventResponse struct {
+	ID         uint      `json:"id"`
+	AccountID  *string   `json:"account_id,omitempty"`
+	KeyID      *string   `json:"key_id,omitempty"`
+	Role       string    `json:"role"`
+	Permission string    `json:"permission"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	URI        string    `json:"uri"`
+	RemoteAddr string    `json:"remote_addr"`
+	PeerAddr   string    `json:"peer_addr"`
+	Outcome    string    `json:"outcome"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewAuditEventResponse преобразует модель записи журнала аудита в DTO ответа.
+//
+// Параметры:
+//   - event: модель записи журнала
+//
+// Возвращает:
+//   - AuditEventResponse: данные записи для API-ответа
+func NewAuditEventResponse(event *models.AuditEvent) AuditEventResponse {
+	return AuditEventResponse{
+		ID:         event.ID,
+		AccountID:  event.AccountID,
+		KeyID:      event.KeyID,
+		Role:       event.Role,
+		Permission: string(event.Permission),
+		Method:     event.Method,
+		Path:       event.Path,
+		URI:        event.URI,
+		RemoteAddr: event.RemoteAddr,
+		PeerAddr:   event.PeerAddr,
+		Outcome:    string(event.Outcome),
+		Reason:     event.Reason,
+		CreatedAt:  event.CreatedAt,
+	}
+}
+
+// StreamEvent представляет событие потока транзакций (SSE и WebSocket).
+// ID - номер события в потоке: клиент передает последний полученный номер
+// (Last-Event-ID), чтобы продолжить поток без пропусков. Для события transfer
+// Data содержит зафиксированный перевод (transaction_id, from, to, amount, currency,
+// target_amount, target_currency, fee, created_at).
+type StreamEvent struct {
+	ID   int64           `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// PendingTransferResponse представляет перевод, отложенный до согласования (правилом проверки
+// или порогом согласования кошелька). Amount и TargetAmount - суммы из запроса перевода
+// (задана одна из них), TotalDebit - расчетная сумма списания с комиссией на момент запроса,
+// Reserved - сумма, зарезервированная на кошельке отправителя. Rule и Reason - сработавшее правило.
+// Approvals - число одобрений из RequiredApprovals, Approvers - согласующие (пустой список - любые),
+// Decisions - решения согласующих. У выполненного перевода заданы TransactionID и,
+// в ответе на последнее одобрение, квитанция Transaction.
+type PendingTransferResponse struct {
+	ID                string                            `json:"id"`
+	Status            string                            `json:"status"`
+	From              string                            `json:"sender_address"`
+	To                string                            `json:"receiver_address"`
+	Amount            decimal.Decimal                   `json:"amount"`
+	Currency          string                            `json:"currency"`
+	TargetAmount      decimal.Decimal                   `json:"target_amount"`
+	TargetCurrency    string                            `json:"target_currency"`
+	TotalDebit        decimal.Decimal                   `json:"total_debit"`
+	Reserved          decimal.Decimal                   `json:"reserved"`
+	Nonce             *int64                            `json:"nonce,omitempty"`
+	Rule              string                            `json:"rule"`
+	Reason            string                            `json:"reason"`
+	RequiredApprovals int                               `json:"required_approvals"`
+	Approvals         int                               `json:"approvals"`
+	Approvers         []string                          `json:"approvers"`
+	Decisions         []PendingTransferDecisionResponse `json:"decisions"`
+	CreatedAt         time.Time                         `json:"created_at"`
+	TransactionID     *string                           `json:"transaction_id,omitempty"`
+	Transaction       *TransactionResponse              `json:"transaction,omitempty"`
+}
+
+// PendingTransferDecisionResponse представляет решение согласующего по отложенному переводу.
+type PendingTransferDecisionResponse struct {
+	AccountID string    `json:"account_id"`
+	Approved  bool      `json:"approved"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewPendingTransferResponse преобразует модель отложенного перевода в DTO ответа.
+//
+// Параметры:
+//   - transfer: модель отложенного перевода
+//
+// Возвращает:
+//   - PendingTransferResponse: данные перевода для API-ответа
+func NewPendingTransferResponse(transfer *models.PendingTransfer) PendingTransferResponse {
+	resp := PendingTransferResponse{
+		ID:                transfer.PublicID,
+		Status:            string(transfer.Status),
+		From:              transfer.From,
+		To:                transfer.To,
+		Amount:            transfer.Amount,
+		Currency:          transfer.Currency,
+		TargetAmount:      transfer.TargetAmount,
+		TargetCurrency:    transfer.TargetCurrency,
+		TotalDebit:        transfer.Debit,
+		Reserved:          transfer.Reserved,
+		Nonce:             transfer.Nonce,
+		Rule:              transfer.Rule,
+		Reason:            transfer.Reason,
+		RequiredApprovals: transfer.RequiredApprovals,
+		Approvals:         transfer.Approvals(),
+		Approvers:         transfer.Approvers,
+		Decisions:         make([]PendingTransferDecisionResponse, 0, len(transfer.Decisions)),
+		CreatedAt:         transfer.CreatedAt,
+		TransactionID:     transfer.TransactionID,
+	}
+
+	if resp.Approvers == nil {
+		resp.Approvers = []string{}
+	}
+
+	for _, decision := range transfer.Decisions {
+		resp.Decisions = append(resp.Decisions, PendingTransferDecisionResponse{
+			AccountID: decision.AccountID,
+			Approved:  decision.Approved,
+			Comment:   decision.Comment,
+			CreatedAt: decision.CreatedAt,
+		})
+	}
+
+	return resp
+}
+
+// ApprovalPolicyResponse представляет политику согласования переводов с кошелька:
+// порог суммы списания (null - без порога), число одобрений и согласующих (пустой список - любые).
+type ApprovalPolicyResponse struct {
+	Address           string           `json:"address"`
+	Threshold         *decimal.Decimal `json:"threshold"`
+	RequiredApprovals int              `json:"required_approvals"`
+	Approvers         []string         `json:"approvers"`
+}
+
+// NewApprovalPolicyResponse преобразует политику согласования кошелька в DTO ответа.
+//
+// Параметры:
+//   - address: адрес кошелька
+//   - policy: политика согласования
+//
+// Возвращает:
+//   - *ApprovalPolicyResponse: политика для API-ответа
+func NewApprovalPolicyResponse(address string, policy *models.ApprovalPolicy) *ApprovalPolicyResponse {
+	resp := &ApprovalPolicyResponse{
+		Address:           address,
+		Threshold:         policy.Threshold,
+		RequiredApprovals: policy.RequiredApprovals,
+		Approvers:         policy.Approvers,
+	}
+	if resp.Approvers == nil {
+		resp.Approvers = []string{}
+	}
+	return resp
 }
@@ -0,0 +1,197 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/middleware"
+	"net/http"
+)
+
+// accountHandler реализует интерфейс AccountHandler.
+// Обрабатывает HTTP-запросы управления учетными записями и API-ключами.
+type accountHandler struct {
+	accountService service.AccountService
+}
+
+// NewAccountHandler создает новый экземпляр обработчика учетных записей.
+//
+// Параметры:
+//   - accountService: сервис учетных записей
+//
+// Возвращает:
+//   - interfaces.AccountHandler: реализацию интерфейса обработчика
+func NewAccountHandler(accountService service.AccountService) interfaces.AccountHandler {
+	return &accountHandler{accountService: accountService}
+}
+
+// CreateAccount обрабатывает запрос на создание учетной записи.
+// POST /accounts
+//
+// Требует разрешения accounts:manage.
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "name": "имя_учетной_записи",
+//	  "role": "роль (необязательно)"
+//	}
+//
+// Возможные ответы:
+//   - 201 Created: {"id": "...", "name": "...", "role": "...", "created_at": "...",
+//     "api_key": {"id": "...", "key": "sk_...", ...}} - учетная запись и ее первый API-ключ
+//     (key возвращается только здесь)
+//   - 400 Bad Request: {"invalid_value": "..."} - пустое или слишком длинное имя, неизвестная роль
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения accounts:manage
+//   - 500 Internal Server Error - ошибка сервера
+func (h *accountHandler) CreateAccount(c echo.Context) error {
+	var req dto.AccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	account, err := h.accountService.CreateAccount(c.Request().Context(), req)
+	if err != nil {
+		return accountError(c, err, "failed to create account")
+	}
+
+	return c.JSON(http.StatusCreated, account)
+}
+
+// UpdateAccount обрабатывает запрос на назначение роли учетной записи.
+// PATCH /accounts/{id}
+//
+// Требует разрешения accounts:manage. Роль действует со следующего запроса учетной записи.
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "role": "роль (пустая строка - роль по умолчанию)"
+//	}
+//
+// Возможные ответы:
+//   - 200 OK: {"id": "...", "name": "...", "role": "...", "created_at": "..."} - учетная запись
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор, неизвестная роль
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения accounts:manage
+//   - 404 Not Found: {"account_error": "..."} - учетная запись не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *accountHandler) UpdateAccount(c echo.Context) error {
+	var req dto.UpdateAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	account, err := h.accountService.UpdateAccount(c.Request().Context(), c.Param("id"), req)
+	if err != nil {
+		return accountError(c, err, "failed to update account")
+	}
+
+	return c.JSON(http.StatusOK, account)
+}
+
+// CreateKey обрабатывает запрос на создание API-ключа учетной записи вызывающего.
+// POST /keys
+//
+// Тело запроса (JSON, необязательно):
+//
+//	{
+//	  "name": "имя_ключа",
+//	  "account_id": "идентификатор_учетной_записи"
+//	}
+//
+// account_id обязателен для привилегированного клиента без API-ключа.
+//
+// Возможные ответы:
+//   - 201 Created: {"id": "...", "account_id": "...", "name": "...", "prefix": "sk_...", "key": "sk_...", ...} -
+//     созданный ключ (key возвращается только здесь)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидное имя или учетная запись
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - чужая учетная запись
+//   - 404 Not Found: {"account_error": "..."} - учетная запись не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *accountHandler) CreateKey(c echo.Context) error {
+	var req dto.APIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	key, err := h.accountService.CreateAPIKey(c.Request().Context(), req)
+	if err != nil {
+		return accountError(c, err, "failed to create api key")
+	}
+
+	return c.JSON(http.StatusCreated, key)
+}
+
+// ListKeys обрабатывает запрос на получение API-ключей учетной записи вызывающего.
+// GET /keys?account_id=...
+//
+// Параметр account_id обязателен для привилегированного клиента без API-ключа.
+//
+// Возможные ответы:
+//   - 200 OK: {"keys": [...]} - ключи учетной записи, в том числе отозванные (без самих ключей)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидная учетная запись
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - чужая учетная запись
+//   - 404 Not Found: {"account_error": "..."} - учетная запись не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *accountHandler) ListKeys(c echo.Context) error {
+	keys, err := h.accountService.APIKeys(c.Request().Context(), c.QueryParam("account_id"))
+	if err != nil {
+		return accountError(c, err, "failed to get api keys")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]dto.APIKeyResponse{"keys": keys})
+}
+
+// RevokeKey обрабатывает запрос на отзыв API-ключа. Отозванный ключ перестает приниматься сразу.
+// DELETE /keys/{id}
+//
+// Возможные ответы:
+//   - 204 No Content - ключ отозван
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 404 Not Found: {"account_error": "..."} - ключ не найден или принадлежит другой учетной записи
+//   - 500 Internal Server Error - ошибка сервера
+func (h *accountHandler) RevokeKey(c echo.Context) error {
+	if err := h.accountService.RevokeAPIKey(c.Request().Context(), c.Param("id")); err != nil {
+		return accountError(c, err, "failed to revoke api key")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// accountError преобразует ошибку сервиса учетных записей в HTTP-ответ.
+func accountError(c echo.Context, err error, message string) error {
+	if isAccessError(err) {
+		return accessError(c, err)
+	} else if errors.Is(err, er.ErrInvalidAccount) || errors.Is(err, er.ErrInvalidAPIKeyID) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+	} else if errors.Is(err, er.ErrAccountNotFound) || errors.Is(err, er.ErrAPIKeyNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"account_error": err.Error()})
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
+
+// isAccessError сообщает, вызвана ли ошибка отсутствием API-ключа, разрешения роли
+// или доступа к кошельку или учетной записи.
+func isAccessError(err error) bool {
+	return errors.Is(err, er.ErrUnauthenticated) ||
+		errors.Is(err, er.ErrPermissionDenied) ||
+		errors.Is(err, er.ErrWalletForbidden) ||
+		errors.Is(err, er.ErrAccountForbidden)
+}
+
+// accessError преобразует ошибку доступа в HTTP-ответ: 401 для запроса без API-ключа, иначе 403.
+func accessError(c echo.Context, err error) error {
+	if errors.Is(err, er.ErrUnauthenticated) {
+		return middleware.Unauthorized(c, err)
+	}
+	return c.JSON(http.StatusForbidden, map[string]string{"access_error": err.Error()})
+}
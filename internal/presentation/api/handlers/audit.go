@@ -0,0 +1,75 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"net/http"
+	"strconv"
+)
+
+// auditHandler реализует интерфейс AuditHandler.
+// Обрабатывает HTTP-запросы чтения журнала аудита.
+type auditHandler struct {
+	auditService service.AuditService
+}
+
+// NewAuditHandler создает новый экземпляр обработчика журнала аудита.
+//
+// Параметры:
+//   - auditService: сервис журнала аудита
+//
+// Возвращает:
+//   - interfaces.AuditHandler: реализацию интерфейса обработчика
+func NewAuditHandler(auditService service.AuditService) interfaces.AuditHandler {
+	return &auditHandler{auditService: auditService}
+}
+
+// Events обрабатывает запрос на получение записей журнала аудита.
+// GET /audit?count={n}&before={id}
+//
+// Требует разрешения audit:read.
+//
+// Параметры запроса:
+//   - count: количество записей (1-500, по умолчанию 50)
+//   - before: вернуть записи старше указанной (id последней записи предыдущей страницы)
+//
+// Возможные ответы:
+//   - 200 OK: {"events": [...]} - записи от новых к старым
+//   - 400 Bad Request: {"invalid_count": "..."} - невалидный параметр count или before
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения audit:read
+//   - 500 Internal Server Error - ошибка сервера
+func (h *auditHandler) Events(c echo.Context) error {
+	var count int
+	if value := c.QueryParam("count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_count": er.ErrInvalidCount.Error()})
+		}
+		count = n
+	}
+
+	var before uint64
+	if value := c.QueryParam("before"); value != "" {
+		n, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_count": er.ErrInvalidCursor.Error()})
+		}
+		before = n
+	}
+
+	events, err := h.auditService.Events(c.Request().Context(), uint(before), count)
+	if err != nil {
+		if errors.Is(err, er.ErrInvalidCount) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_count": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get audit events")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]dto.AuditEventResponse{"events": events})
+}
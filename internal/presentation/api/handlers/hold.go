@@ -0,0 +1,192 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"net/http"
+)
+
+// holdHandler реализует интерфейс HoldHandler.
+// Обрабатывает HTTP-запросы резервирования, списания и отмены холдов.
+type holdHandler struct {
+	holdService service.HoldService
+}
+
+// NewHoldHandler создает новый экземпляр обработчика холдов.
+//
+// Параметры:
+//   - holdService: сервис холдов
+//
+// Возвращает:
+//   - interfaces.HoldHandler: реализацию интерфейса обработчика
+func NewHoldHandler(holdService service.HoldService) interfaces.HoldHandler {
+	return &holdHandler{holdService: holdService}
+}
+
+// Create обрабатывает запрос на резервирование средств.
+// POST /holds
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "from": "адрес_отправителя",
+//	  "to": "адрес_получателя",
+//	  "amount": "сумма",
+//	  "ttl_seconds": 900
+//	}
+//
+// Возможные ответы:
+//   - 201 Created: данные холда (status: active, reserved - сумма с комиссией, expires_at)
+//   - 400 Bad Request: {"invalid_value": "..."} - ошибки валидации перевода или срока холда
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 400 Bad Request: {"signature_error": "..."} - кошелек отправителя имеет открытый ключ
+//   - 403 Forbidden: {"access_error": "..."} - кошелек отправителя принадлежит другой учетной записи
+//   - 403 Forbidden: {"risk_error": "..."} - перевод отклонен правилом проверки или требует ручной проверки
+//   - 422 Unprocessable Entity: {"invalid_amount": "..."} - недостаточно доступных средств
+//   - 423 Locked: {"wallet_error": "..."} - кошелек отправителя заморожен
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - конфликт с конкурентными
+//     переводами, изменения не зафиксированы и запрос можно повторить
+//   - 500 Internal Server Error - ошибка сервера
+func (h *holdHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.HoldRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	hold, err := h.holdService.CreateHold(ctx, req)
+	if err != nil {
+		if isAccessError(err) {
+			return accessError(c, err)
+		} else if status := signatureErrorStatus(err); status != 0 {
+			return c.JSON(status, map[string]string{"signature_error": err.Error()})
+		} else if isRiskError(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"risk_error": err.Error()})
+		} else if errors.Is(err, er.ErrInvalidHoldTTL) || isTransferValidationError(err) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrNotEnoughMoney) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"invalid_amount": err.Error()})
+		} else if isWalletFrozenError(err) {
+			return c.JSON(http.StatusLocked, map[string]string{"wallet_error": err.Error()})
+		} else if errors.Is(err, er.ErrConcurrentUpdate) {
+			return concurrentUpdateError(c, err)
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create hold")
+	}
+
+	return c.JSON(http.StatusCreated, hold)
+}
+
+// Get обрабатывает запрос на получение холда.
+// GET /holds/{id}
+//
+// Возможные ответы:
+//   - 200 OK: данные холда
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 404 Not Found: {"hold_error": "..."} - холд не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *holdHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	hold, err := h.holdService.Hold(ctx, c.Param("id"))
+	if err != nil {
+		return holdError(c, err, "failed to get hold")
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+// Capture обрабатывает запрос на списание холда.
+// POST /holds/{id}/capture
+//
+// Тело запроса (JSON, необязательно):
+//
+//	{
+//	  "amount": "сумма_списания"
+//	}
+//
+// Без суммы списывается вся сумма холда. Остаток резерва при частичном списании освобождается.
+//
+// Возможные ответы:
+//   - 200 OK: данные холда (status: captured) с квитанцией о переводе (transaction)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор или сумма
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - ни отправитель, ни получатель холда не принадлежат учетной записи
+//   - 404 Not Found: {"hold_error": "..."} - холд не найден
+//   - 409 Conflict: {"hold_error": "..."} - холд уже списан, отменен или истек
+//   - 422 Unprocessable Entity: {"invalid_amount": "..."} - недостаточно средств
+//   - 422 Unprocessable Entity: {"limit_error": "...", ...} - списание превышает лимит расходов отправителя
+//   - 423 Locked: {"wallet_error": "..."} - кошелек отправителя или получателя заморожен
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - конфликт с конкурентными
+//     переводами, изменения не зафиксированы и запрос можно повторить
+//   - 500 Internal Server Error - ошибка сервера
+func (h *holdHandler) Capture(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.CaptureHoldRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	hold, err := h.holdService.CaptureHold(ctx, c.Param("id"), req)
+	if err != nil {
+		if errors.Is(err, er.ErrCaptureExceedsHold) || isTransferValidationError(err) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrNotEnoughMoney) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"invalid_amount": err.Error()})
+		} else if errors.Is(err, er.ErrLimitExceeded) {
+			return c.JSON(http.StatusUnprocessableEntity, limitErrorBody(err))
+		} else if isWalletFrozenError(err) {
+			return c.JSON(http.StatusLocked, map[string]string{"wallet_error": err.Error()})
+		}
+		return holdError(c, err, "failed to capture hold")
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+// Void обрабатывает запрос на отмену холда.
+// POST /holds/{id}/void
+//
+// Возможные ответы:
+//   - 200 OK: данные холда (status: voided)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - ни отправитель, ни получатель холда не принадлежат учетной записи
+//   - 404 Not Found: {"hold_error": "..."} - холд не найден
+//   - 409 Conflict: {"hold_error": "..."} - холд уже списан, отменен или истек
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - конфликт с конкурентными
+//     переводами, изменения не зафиксированы и запрос можно повторить
+//   - 500 Internal Server Error - ошибка сервера
+func (h *holdHandler) Void(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	hold, err := h.holdService.VoidHold(ctx, c.Param("id"))
+	if err != nil {
+		return holdError(c, err, "failed to void hold")
+	}
+
+	return c.JSON(http.StatusOK, hold)
+}
+
+// holdError преобразует общие ошибки операций с холдом в HTTP-ответ.
+func holdError(c echo.Context, err error, message string) error {
+	if isAccessError(err) {
+		return accessError(c, err)
+	} else if errors.Is(err, er.ErrInvalidHoldID) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+	} else if errors.Is(err, er.ErrHoldNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"hold_error": err.Error()})
+	} else if errors.Is(err, er.ErrHoldNotActive) {
+		return c.JSON(http.StatusConflict, map[string]string{"hold_error": err.Error()})
+	} else if errors.Is(err, er.ErrConcurrentUpdate) {
+		return concurrentUpdateError(c, err)
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
@@ -0,0 +1,45 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"net/http"
+)
+
+// ledgerHandler реализует интерфейс LedgerHandler.
+// Обрабатывает HTTP-запросы, связанные с журналом двойной записи.
+type ledgerHandler struct {
+	ledgerService service.LedgerService
+}
+
+// NewLedgerHandler создает новый экземпляр обработчика журнала.
+//
+// Параметры:
+//   - ledgerService: сервис журнала двойной записи
+//
+// Возвращает:
+//   - interfaces.LedgerHandler: реализацию интерфейса обработчика
+func NewLedgerHandler(ledgerService service.LedgerService) interfaces.LedgerHandler {
+	return &ledgerHandler{ledgerService: ledgerService}
+}
+
+// Verify обрабатывает запрос на проверку согласованности журнала.
+// GET /ledger/verify
+//
+// Требует разрешения ledger:verify.
+//
+// Возможные ответы:
+//   - 200 OK: {"consistent": true, "balance_mismatches": [], ...} - отчет о проверке
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения ledger:verify
+//   - 500 Internal Server Error - ошибка сервера
+func (h *ledgerHandler) Verify(c echo.Context) error {
+	report, err := h.ledgerService.Verify(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to verify ledger")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
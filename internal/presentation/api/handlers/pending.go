@@ -0,0 +1,254 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"net/http"
+	"strconv"
+)
+
+// pendingTransferHandler реализует интерфейс PendingTransferHandler.
+// Обрабатывает HTTP-запросы к переводам, отложенным до согласования, и политикам согласования кошельков.
+type pendingTransferHandler struct {
+	pendingTransferService service.PendingTransferService
+}
+
+// NewPendingTransferHandler создает новый экземпляр обработчика отложенных переводов.
+//
+// Параметры:
+//   - pendingTransferService: сервис отложенных переводов
+//
+// Возвращает:
+//   - interfaces.PendingTransferHandler: реализацию интерфейса обработчика
+func NewPendingTransferHandler(pendingTransferService service.PendingTransferService) interfaces.PendingTransferHandler {
+	return &pendingTransferHandler{pendingTransferService: pendingTransferService}
+}
+
+// List обрабатывает запрос на получение очереди отложенных переводов.
+// GET /pending-transfers?status={status}&count={n}
+//
+// Требует разрешения transfers:approve.
+//
+// Параметры запроса:
+//   - status: статус переводов (pending, executed или rejected; по умолчанию pending)
+//   - count: количество переводов (1-500, по умолчанию 50)
+//
+// Возможные ответы:
+//   - 200 OK: {"pending_transfers": [...]} - переводы от старых к новым
+//   - 400 Bad Request: {"invalid_value": "..."} - неизвестный статус
+//   - 400 Bad Request: {"invalid_count": "..."} - невалидный параметр count
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения transfers:approve
+//   - 500 Internal Server Error - ошибка сервера
+func (h *pendingTransferHandler) List(c echo.Context) error {
+	var count int
+	if value := c.QueryParam("count"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_count": er.ErrInvalidCount.Error()})
+		}
+		count = n
+	}
+
+	transfers, err := h.pendingTransferService.PendingTransfers(c.Request().Context(), c.QueryParam("status"), count)
+	if err != nil {
+		if errors.Is(err, er.ErrInvalidCount) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_count": err.Error()})
+		}
+		return pendingTransferError(c, err, "failed to get pending transfers")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]dto.PendingTransferResponse{"pending_transfers": transfers})
+}
+
+// Get обрабатывает запрос на получение отложенного перевода.
+// GET /pending-transfers/{id}
+//
+// Перевод доступен владельцу кошелька отправителя или получателя и согласующим,
+// назначенным политикой согласования кошелька.
+//
+// Возможные ответы:
+//   - 200 OK: {"id": "...", "status": "pending", "sender_address": "...", ..., "rule": "...", "reason": "...",
+//     "required_approvals": 2, "approvals": 1, "approvers": [...], "decisions": [...]} - данные отложенного перевода
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - кошельки перевода принадлежат другой учетной записи
+//   - 404 Not Found: {"pending_transfer_error": "..."} - перевод не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *pendingTransferHandler) Get(c echo.Context) error {
+	transfer, err := h.pendingTransferService.PendingTransfer(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return pendingTransferError(c, err, "failed to get pending transfer")
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
+
+// Approve обрабатывает запрос на одобрение отложенного перевода.
+// POST /pending-transfers/{id}/approve
+//
+// Требует разрешения transfers:approve. Инициатор перевода не может его одобрить.
+// Последнее из требуемых одобрений выполняет перевод.
+//
+// Тело запроса (JSON, необязательно):
+//
+//	{
+//	  "comment": "комментарий согласующего"
+//	}
+//
+// Возможные ответы:
+//   - 200 OK: данные перевода (status: pending - ожидает других одобрений, executed - выполнен,
+//     с квитанцией о переводе transaction)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор или ошибка расчета перевода
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения, вызывающий - инициатор перевода
+//     или не входит в число согласующих
+//   - 404 Not Found: {"pending_transfer_error": "..."} - перевод не найден
+//   - 409 Conflict: {"pending_transfer_error": "..."} - перевод уже выполнен или отклонен,
+//     учетная запись уже приняла решение
+//   - 422 Unprocessable Entity: {"invalid_amount": "..."} - недостаточно средств
+//   - 422 Unprocessable Entity: {"limit_error": "...", ...} - перевод превышает лимит расходов отправителя
+//   - 423 Locked: {"wallet_error": "..."} - кошелек отправителя или получателя заморожен
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - конфликт с конкурентными
+//     переводами, изменения не зафиксированы и запрос можно повторить
+//   - 500 Internal Server Error - ошибка сервера
+func (h *pendingTransferHandler) Approve(c echo.Context) error {
+	var req dto.PendingTransferDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	transfer, err := h.pendingTransferService.ApprovePendingTransfer(c.Request().Context(), c.Param("id"), req)
+	if err != nil {
+		if isTransferValidationError(err) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrNotEnoughMoney) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"invalid_amount": err.Error()})
+		} else if errors.Is(err, er.ErrLimitExceeded) {
+			return c.JSON(http.StatusUnprocessableEntity, limitErrorBody(err))
+		} else if isWalletFrozenError(err) {
+			return c.JSON(http.StatusLocked, map[string]string{"wallet_error": err.Error()})
+		}
+		return pendingTransferError(c, err, "failed to approve pending transfer")
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
+
+// Reject обрабатывает запрос на отклонение отложенного перевода.
+// POST /pending-transfers/{id}/reject
+//
+// Требует разрешения transfers:approve. Перевод отклоняется первым отказом,
+// зарезервированные средства возвращаются в доступный баланс отправителя.
+//
+// Тело запроса (JSON, необязательно):
+//
+//	{
+//	  "comment": "причина отказа"
+//	}
+//
+// Возможные ответы:
+//   - 200 OK: данные перевода (status: rejected)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения или вызывающий не входит в число согласующих
+//   - 404 Not Found: {"pending_transfer_error": "..."} - перевод не найден
+//   - 409 Conflict: {"pending_transfer_error": "..."} - перевод уже выполнен или отклонен,
+//     учетная запись уже приняла решение
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - конфликт с конкурентными
+//     переводами, изменения не зафиксированы и запрос можно повторить
+//   - 500 Internal Server Error - ошибка сервера
+func (h *pendingTransferHandler) Reject(c echo.Context) error {
+	var req dto.PendingTransferDecisionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	transfer, err := h.pendingTransferService.RejectPendingTransfer(c.Request().Context(), c.Param("id"), req)
+	if err != nil {
+		return pendingTransferError(c, err, "failed to reject pending transfer")
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
+
+// Policy обрабатывает запрос на получение политики согласования кошелька.
+// GET /wallets/{address}/approval-policy
+//
+// Возможные ответы:
+//   - 200 OK: {"address": "...", "threshold": "...", "required_approvals": 2, "approvers": [...]}
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *pendingTransferHandler) Policy(c echo.Context) error {
+	policy, err := h.pendingTransferService.ApprovalPolicy(c.Request().Context(), c.Param("address"))
+	if err != nil {
+		if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get approval policy")
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+// SetPolicy обрабатывает запрос на назначение политики согласования кошелька (N из M).
+// PUT /wallets/{address}/approval-policy
+//
+// Требует разрешения approvals:manage. Запрос заменяет прежнюю политику целиком.
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "threshold": "порог суммы перевода (null - без порога)",
+//	  "required_approvals": 2,
+//	  "approvers": ["идентификатор учетной записи", ...]
+//	}
+//
+// Возможные ответы:
+//   - 200 OK: политика кошелька после изменения (как у Policy)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный порог, число одобрений или согласующий
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения approvals:manage
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден или закрыт
+//   - 500 Internal Server Error - ошибка сервера
+func (h *pendingTransferHandler) SetPolicy(c echo.Context) error {
+	var req dto.ApprovalPolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	policy, err := h.pendingTransferService.SetApprovalPolicy(c.Request().Context(), c.Param("address"), req)
+	if err != nil {
+		if errors.Is(err, er.ErrInvalidApprovalPolicy) || errors.Is(err, er.ErrAccountNotFound) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update approval policy")
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+// pendingTransferError преобразует ошибку сервиса отложенных переводов в HTTP-ответ.
+func pendingTransferError(c echo.Context, err error, message string) error {
+	if isAccessError(err) {
+		return accessError(c, err)
+	} else if errors.Is(err, er.ErrSelfApproval) || errors.Is(err, er.ErrNotApprover) {
+		return c.JSON(http.StatusForbidden, map[string]string{"access_error": err.Error()})
+	} else if errors.Is(err, er.ErrInvalidPendingTransferID) || errors.Is(err, er.ErrInvalidPendingTransferStatus) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+	} else if errors.Is(err, er.ErrPendingTransferNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"pending_transfer_error": err.Error()})
+	} else if errors.Is(err, er.ErrPendingTransferNotPending) || errors.Is(err, er.ErrAlreadyDecided) {
+		return c.JSON(http.StatusConflict, map[string]string{"pending_transfer_error": err.Error()})
+	} else if errors.Is(err, er.ErrConcurrentUpdate) {
+		return concurrentUpdateError(c, err)
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
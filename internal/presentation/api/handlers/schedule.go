@@ -0,0 +1,187 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"net/http"
+)
+
+// scheduledTransferHandler реализует интерфейс ScheduledTransferHandler.
+// Обрабатывает HTTP-запросы управления запланированными переводами.
+type scheduledTransferHandler struct {
+	scheduledTransferService service.ScheduledTransferService
+}
+
+// NewScheduledTransferHandler создает новый экземпляр обработчика запланированных переводов.
+//
+// Параметры:
+//   - scheduledTransferService: сервис запланированных переводов
+//
+// Возвращает:
+//   - interfaces.ScheduledTransferHandler: реализацию интерфейса обработчика
+func NewScheduledTransferHandler(scheduledTransferService service.ScheduledTransferService) interfaces.ScheduledTransferHandler {
+	return &scheduledTransferHandler{scheduledTransferService: scheduledTransferService}
+}
+
+// Create обрабатывает запрос на создание запланированного перевода.
+// POST /scheduled-transfers
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "from": "адрес_отправителя",
+//	  "to": "адрес_получателя",
+//	  "amount": "сумма_списания",
+//	  "start_at": "2025-07-07T09:00:00+03:00",
+//	  "interval_seconds": 604800,
+//	  "end_at": "2025-12-31T00:00:00+03:00"
+//	}
+//
+// start_at по умолчанию - сразу, interval_seconds = 0 - разовый перевод, end_at необязателен.
+//
+// Возможные ответы:
+//   - 201 Created: данные запланированного перевода (status: active, next_run_at)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидное расписание или ошибки валидации перевода
+//   - 400 Bad Request: {"signature_error": "..."} - кошелек отправителя имеет открытый ключ
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек отправителя принадлежит другой учетной записи
+//   - 500 Internal Server Error - ошибка сервера
+func (h *scheduledTransferHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.ScheduledTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	transfer, err := h.scheduledTransferService.CreateScheduledTransfer(ctx, req)
+	if err != nil {
+		if isAccessError(err) {
+			return accessError(c, err)
+		} else if status := signatureErrorStatus(err); status != 0 {
+			return c.JSON(status, map[string]string{"signature_error": err.Error()})
+		} else if errors.Is(err, er.ErrInvalidSchedule) || isTransferValidationError(err) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create scheduled transfer")
+	}
+
+	return c.JSON(http.StatusCreated, transfer)
+}
+
+// List обрабатывает запрос на получение запланированных переводов кошелька.
+// GET /wallet/{address}/scheduled-transfers
+//
+// Возможные ответы:
+//   - 200 OK: {"scheduled_transfers": [...]} - переводы, где кошелек - отправитель
+//   - 500 Internal Server Error - ошибка сервера
+func (h *scheduledTransferHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	transfers, err := h.scheduledTransferService.ScheduledTransfers(ctx, c.Param("address"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get scheduled transfers")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]dto.ScheduledTransferResponse{"scheduled_transfers": transfers})
+}
+
+// Get обрабатывает запрос на получение запланированного перевода.
+// GET /scheduled-transfers/{id}
+//
+// Возможные ответы:
+//   - 200 OK: данные перевода и последние попытки выполнения (runs)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 404 Not Found: {"scheduled_transfer_error": "..."} - перевод не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *scheduledTransferHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	transfer, err := h.scheduledTransferService.ScheduledTransfer(ctx, c.Param("id"))
+	if err != nil {
+		return scheduledTransferError(c, err, "failed to get scheduled transfer")
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
+
+// Update обрабатывает запрос на изменение запланированного перевода.
+// PATCH /scheduled-transfers/{id}
+//
+// Тело запроса (JSON, все поля необязательны):
+//
+//	{
+//	  "amount": "сумма_списания",
+//	  "interval_seconds": 86400,
+//	  "end_at": "2025-12-31T00:00:00+03:00",
+//	  "status": "paused"
+//	}
+//
+// status принимает значения active и paused.
+//
+// Возможные ответы:
+//   - 200 OK: данные измененного перевода
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор, расписание или сумма
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек отправителя принадлежит другой учетной записи
+//   - 404 Not Found: {"scheduled_transfer_error": "..."} - перевод не найден
+//   - 409 Conflict: {"scheduled_transfer_error": "..."} - перевод завершен, остановлен или отменен
+//   - 500 Internal Server Error - ошибка сервера
+func (h *scheduledTransferHandler) Update(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.UpdateScheduledTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	transfer, err := h.scheduledTransferService.UpdateScheduledTransfer(ctx, c.Param("id"), req)
+	if err != nil {
+		if errors.Is(err, er.ErrInvalidSchedule) || isTransferValidationError(err) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		}
+		return scheduledTransferError(c, err, "failed to update scheduled transfer")
+	}
+
+	return c.JSON(http.StatusOK, transfer)
+}
+
+// Cancel обрабатывает запрос на отмену запланированного перевода.
+// DELETE /scheduled-transfers/{id}
+//
+// Возможные ответы:
+//   - 204 No Content - перевод отменен
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек отправителя принадлежит другой учетной записи
+//   - 404 Not Found: {"scheduled_transfer_error": "..."} - перевод не найден
+//   - 409 Conflict: {"scheduled_transfer_error": "..."} - перевод уже завершен, остановлен или отменен
+//   - 500 Internal Server Error - ошибка сервера
+func (h *scheduledTransferHandler) Cancel(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := h.scheduledTransferService.CancelScheduledTransfer(ctx, c.Param("id")); err != nil {
+		return scheduledTransferError(c, err, "failed to cancel scheduled transfer")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// scheduledTransferError преобразует ошибку сервиса запланированных переводов в HTTP-ответ.
+func scheduledTransferError(c echo.Context, err error, message string) error {
+	if isAccessError(err) {
+		return accessError(c, err)
+	} else if errors.Is(err, er.ErrInvalidScheduledTransferID) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+	} else if errors.Is(err, er.ErrScheduledTransferNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"scheduled_transfer_error": err.Error()})
+	} else if errors.Is(err, er.ErrScheduledTransferFinished) {
+		return c.JSON(http.StatusConflict, map[string]string{"scheduled_transfer_error": err.Error()})
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
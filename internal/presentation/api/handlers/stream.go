@@ -0,0 +1,169 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"golang.org/x/net/websocket"
+	"io"
+	"net/http"
+	"time"
+)
+
+// streamHandler реализует интерфейс StreamHandler.
+// Передает клиентам зафиксированные переводы в реальном времени.
+type streamHandler struct {
+	stream    service.TransactionStream
+	heartbeat time.Duration
+}
+
+// NewStreamHandler создает новый экземпляр обработчика потока транзакций.
+//
+// Параметры:
+//   - stream: поток зафиксированных переводов
+//   - heartbeat: период служебных сообщений SSE, поддерживающих соединение через прокси
+//
+// Возвращает:
+//   - interfaces.StreamHandler: реализацию интерфейса обработчика
+func NewStreamHandler(stream service.TransactionStream, heartbeat time.Duration) interfaces.StreamHandler {
+	return &streamHandler{stream: stream, heartbeat: heartbeat}
+}
+
+// SSE обрабатывает подписку на поток переводов в формате Server-Sent Events.
+// GET /transactions/stream?wallet=адрес
+//
+// Каждый перевод передается событием:
+//
+//	id: 42
+//	event: transfer
+//	data: {"transaction_id": "...", "from": "...", "to": "...", "amount": "10", ...}
+//
+// Параметр wallet необязателен: только переводы, где кошелек - отправитель или получатель.
+// Заголовок Last-Event-ID (или параметр last_event_id) продолжает поток после указанного события
+// без пропусков; без него передаются только новые переводы.
+//
+// Возможные ответы:
+//   - 200 OK: поток событий (text/event-stream)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный Last-Event-ID
+//   - 503 Service Unavailable - приложение останавливается
+//   - 500 Internal Server Error - ошибка сервера
+func (h *streamHandler) SSE(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("last_event_id")
+	}
+
+	events, err := h.stream.Subscribe(ctx, c.QueryParam("wallet"), lastEventID)
+	if err != nil {
+		return streamError(c, err)
+	}
+
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	resp.Header().Set("X-Accel-Buffering", "no")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	var data bytes.Buffer
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			// Данные события должны занимать одну строку
+			data.Reset()
+			if err = json.Compact(&data, event.Data); err != nil {
+				return err
+			}
+
+			if _, err = fmt.Fprintf(resp, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data.Bytes()); err != nil {
+				return nil
+			}
+			resp.Flush()
+		case <-heartbeat.C:
+			if _, err = io.WriteString(resp, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			resp.Flush()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// WebSocket обрабатывает подписку на поток переводов по WebSocket.
+// GET /transactions/ws?wallet=адрес&last_event_id=42
+//
+// Каждый перевод передается текстовым сообщением:
+//
+//	{"id": 42, "type": "transfer", "data": {"transaction_id": "...", "from": "...", ...}}
+//
+// Параметры wallet и last_event_id имеют тот же смысл, что и в SSE. Сообщения клиента игнорируются.
+//
+// Возможные ответы:
+//   - 101 Switching Protocols: поток сообщений
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный last_event_id
+//   - 503 Service Unavailable - приложение останавливается
+//   - 500 Internal Server Error - ошибка сервера
+func (h *streamHandler) WebSocket(c echo.Context) error {
+	ctx, cancel := context.WithCancel(c.Request().Context())
+	defer cancel()
+
+	events, err := h.stream.Subscribe(ctx, c.QueryParam("wallet"), c.QueryParam("last_event_id"))
+	if err != nil {
+		return streamError(c, err)
+	}
+
+	// Проверка Origin отключена (Handshake не задан): поток доступен дашбордам с других доменов
+	websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		// Чтение обнаруживает закрытие соединения клиентом
+		go func() {
+			_, _ = io.Copy(io.Discard, ws)
+			cancel()
+		}()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if err := websocket.JSON.Send(ws, event); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}}.ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
+
+// streamError преобразует ошибку подписки на поток в HTTP-ответ.
+func streamError(c echo.Context, err error) error {
+	if errors.Is(err, er.ErrInvalidLastEventID) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+	} else if errors.Is(err, er.ErrStreamClosed) {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "failed to subscribe to transaction stream")
+}
@@ -60,3 +60,132 @@ func (h *transactionHandler) Last(c echo.Context) error {
 	// Успешный ответ
 	return c.JSON(http.StatusOK, map[string][]dto.TransactionResponse{"transactions": transactions})
 }
+
+// Get обрабатывает запрос на получение транзакции по идентификатору.
+// GET /transactions/{id}
+//
+// Параметры пути:
+//   - id: публичный идентификатор транзакции (UUID)
+//
+// Возможные ответы:
+//   - 200 OK: {"id": "...", "sender_address": "...", ...} - транзакция
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 404 Not Found: {"transaction_error": "..."} - транзакция не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *transactionHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	transaction, err := h.transactionService.Transaction(ctx, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, er.ErrInvalidTransactionID) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrTransactionIDNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"transaction_error": er.ErrTransactionIDNotFound.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get transaction")
+	}
+
+	return c.JSON(http.StatusOK, transaction)
+}
+
+// History обрабатывает запрос на получение истории транзакций кошелька.
+// GET /wallet/{address}/transactions
+//
+// Параметры пути:
+//   - address: адрес кошелька
+//
+// Параметры запроса (все необязательные):
+//   - direction: incoming, outgoing или both (по умолчанию both)
+//   - created_from, created_to: диапазон дат создания в формате RFC3339
+//   - min_amount, max_amount: диапазон сумм
+//   - limit: размер страницы (1-100, по умолчанию 20)
+//   - cursor: значение next_cursor из предыдущего ответа
+//
+// Возможные ответы:
+//   - 200 OK: {"transactions": [...], "next_cursor": "..."} - успешный запрос
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидные параметры или курсор
+//   - 500 Internal Server Error - ошибка сервера
+func (h *transactionHandler) History(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.TransactionHistoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": er.ErrInvalidHistoryFilter.Error()})
+	}
+
+	page, err := h.transactionService.WalletTransactions(ctx, c.Param("address"), req)
+	if err != nil {
+		if errors.Is(err, er.ErrInvalidHistoryFilter) || errors.Is(err, er.ErrInvalidCursor) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get wallet transactions")
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// Refund обрабатывает запрос на возврат транзакции.
+// POST /transactions/{id}/refund
+//
+// Параметры пути:
+//   - id: публичный идентификатор исходной транзакции (UUID)
+//
+// Тело запроса (JSON, необязательно):
+//
+//	{
+//	  "amount": "сумма_возврата"
+//	}
+//
+// Сумма задается в валюте отправителя исходной транзакции; если она не указана,
+// возвращается весь еще не возвращенный остаток. Возврат создается новой транзакцией
+// от получателя к отправителю, связанной с исходной полем refund_of.
+//
+// Возможные ответы:
+//   - 201 Created: {"id": "...", "sender_address": "...", ..., "refund_of": "..."} - квитанция о возврате
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор, сумма,
+//     возврат возврата или кошелек участника закрыт
+//   - 400 Bad Request: {"signature_error": "..."} - кошелек получателя исходной транзакции имеет открытый ключ
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек получателя исходной транзакции принадлежит другой учетной записи
+//   - 404 Not Found: {"transaction_error": "..."} - транзакция не найдена
+//   - 409 Conflict: {"refund_error": "..."} - сумма превышает невозвращенный остаток
+//   - 422 Unprocessable Entity: {"invalid_amount": "..."} - у получателя недостаточно средств
+//   - 423 Locked: {"wallet_error": "..."} - кошелек получателя или отправителя исходной транзакции заморожен
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - конфликт с конкурентными
+//     переводами, изменения не зафиксированы и запрос можно повторить
+//   - 500 Internal Server Error - ошибка сервера
+func (h *transactionHandler) Refund(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.RefundRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	refund, err := h.transactionService.Refund(ctx, c.Param("id"), req)
+	if err != nil {
+		switch {
+		case isAccessError(err):
+			return accessError(c, err)
+		case errors.Is(err, er.ErrSignatureRequired):
+			return c.JSON(http.StatusBadRequest, map[string]string{"signature_error": err.Error()})
+		case errors.Is(err, er.ErrTransactionIDNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{"transaction_error": er.ErrTransactionIDNotFound.Error()})
+		case errors.Is(err, er.ErrRefundExceedsOriginal):
+			return c.JSON(http.StatusConflict, map[string]string{"refund_error": err.Error()})
+		case errors.Is(err, er.ErrRefundNotEnoughMoney):
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"invalid_amount": err.Error()})
+		case isWalletFrozenError(err):
+			return c.JSON(http.StatusLocked, map[string]string{"wallet_error": err.Error()})
+		case errors.Is(err, er.ErrConcurrentUpdate):
+			return concurrentUpdateError(c, err)
+		case errors.Is(err, er.ErrInvalidTransactionID),
+			errors.Is(err, er.ErrRefundOfRefund),
+			isTransferValidationError(err):
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to refund transaction")
+	}
+
+	return c.JSON(http.StatusCreated, refund)
+}
@@ -5,72 +5,334 @@ import (
 	"errors"
 	"github.com/labstack/echo/v4"
 	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
 	"github.com/normalniydada/case_infotecs/internal/domain/service"
 	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
 	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
-	"github.com/shopspring/decimal"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/middleware"
 	"net/http"
 )
 
+const (
+	// idempotencyKeyHeader - заголовок, в котором клиент передает ключ идемпотентности перевода.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader - заголовок, помечающий ответ, взятый из сохраненного результата.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength - максимальная длина ключа идемпотентности.
+	maxIdempotencyKeyLength = 255
+	// concurrentUpdateRetryAfter - значение Retry-After (в секундах) ответа на конфликт с конкурентными переводами.
+	concurrentUpdateRetryAfter = "1"
+)
+
 // walletHandler реализует интерфейс WalletHandler.
 // Обрабатывает HTTP-запросы, связанные с операциями кошельков.
 type walletHandler struct {
 	walletService service.WalletService
+	authorizer    *middleware.Authorizer
 }
 
 // NewWalletHandler создает новый экземпляр обработчика кошельков.
 //
 // Параметры:
 //   - walletService: сервис для работы с кошельками
+//   - authorizer: проверка разрешений, зависящих от тела запроса
 //
 // Возвращает:
 //   - interfaces.WalletHandler: реализацию интерфейса обработчика
-func NewWalletHandler(walletService service.WalletService) interfaces.WalletHandler {
-	return &walletHandler{walletService: walletService}
+func NewWalletHandler(walletService service.WalletService, authorizer *middleware.Authorizer) interfaces.WalletHandler {
+	return &walletHandler{walletService: walletService, authorizer: authorizer}
 }
 
 // Send обрабатывает запрос на перевод средств между кошельками.
 // POST /wallets/send
 //
+// Заголовки:
+//   - Idempotency-Key: необязательный ключ идемпотентности. Повтор запроса
+//     с тем же ключом и телом возвращает сохраненный ответ первого запроса
+//     (с заголовком Idempotent-Replayed: true) без повторного перевода.
+//     Сохраняются только ответы 200 и 202 (перевод выполнен или отложен): запрос,
+//     завершившийся ошибкой, средств не списал и при повторе выполняется заново.
+//     Ключи действуют в пределах учетной записи вызывающего.
+//
 // Тело запроса (JSON):
 //
 //	{
 //	  "from": "адрес_отправителя",
 //	  "to": "адрес_получателя",
-//	  "amount": "сумма_перевода"
+//	  "amount": "сумма_списания",
+//	  "target_amount": "сумма_зачисления",
+//	  "nonce": 1,
+//	  "signature": "подпись_hex"
 //	}
 //
+// Перевод с кошелька, имеющего открытый ключ, подписывается закрытым ключом кошелька
+// (см. пакет walletsign): nonce должен быть больше номера последнего перевода с кошелька.
+// Для кошелька без ключа nonce и signature не передаются.
+//
+// Задается ровно одна из сумм. Для кошельков в разных валютах вторая сумма
+// рассчитывается по курсу обмена за вычетом спреда. Комиссия по тарифу валюты
+// отправителя списывается сверх суммы перевода.
+//
 // Возможные ответы:
-//   - 200 OK: {"message": "transaction succeeded"} - успешный перевод
+//   - 200 OK: {"id": "...", "sender_address": "...", "receiver_address": "...", "amount": "...", "currency": "...",
+//     "target_amount": "...", "target_currency": "...", "rate": "...", "spread": "...", "fee": "...", "date": "..."} -
+//     успешный перевод, в ответе квитанция о созданной транзакции
+//   - 202 Accepted: {"id": "...", "status": "pending", "sender_address": "...", ..., "rule": "...", "reason": "..."} -
+//     перевод отложен правилом проверки (решение review) и не выполнен
 //   - 400 Bad Request: {"invalid value": "..."} - ошибки валидации:
 //   - неверный формат JSON
 //   - недостаточно средств
 //   - невалидная сумма
 //   - перевод самому себе
 //   - кошелек не найден
+//   - заданы обе суммы или ни одной
+//   - сумма не представима в валюте кошелька
+//   - курс для пары валют неизвестен
+//   - невалидный Idempotency-Key
+//   - 400 Bad Request: {"signature_error": "..."} - перевод с кошелька с ключом не подписан
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек отправителя принадлежит другой учетной записи
+//   - 403 Forbidden: {"signature_error": "..."} - подпись неверна или у кошелька нет ключа
+//   - 403 Forbidden: {"risk_error": "..."} - перевод отклонен правилом проверки (решение deny)
+//   - 409 Conflict: {"signature_error": "..."} - номер перевода уже использован
+//   - 409 Conflict: {"idempotency_error": "..."} - ключ использован с другим телом запроса
+//   - 422 Unprocessable Entity: {"limit_error": "...", "period": "...", "limit": "...", "remaining": "..."} -
+//     перевод превышает лимит расходов отправителя (single, daily, weekly или monthly)
+//   - 423 Locked: {"wallet_error": "..."} - кошелек отправителя или получателя заморожен
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - перевод не выполнен
+//     из-за конфликта с конкурентными переводами (после всех повторов), запрос можно повторить
 //   - 500 Internal Server Error: {"transaction": "..."} - ошибка сервера
 func (h *walletHandler) Send(c echo.Context) error {
 	ctx := c.Request().Context()
 
+	idempotencyKey := c.Request().Header.Get(idempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": er.ErrInvalidIdempotencyKey.Error()})
+	}
+
 	var req dto.TransactionRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
 	}
 
-	err := h.walletService.TransferMoney(ctx, req.From, req.To, req.Amount)
+	resp, err := h.walletService.TransferMoney(ctx, req, idempotencyKey)
 	if err != nil {
-		if errors.Is(err, er.ErrSameWalletTransfer) ||
-			errors.Is(err, er.ErrInvalidAmount) ||
-			errors.Is(err, er.ErrWalletSenderNotFound) ||
-			errors.Is(err, er.ErrWalletReceiverNotFound) {
+		if isAccessError(err) {
+			return accessError(c, err)
+		} else if status := signatureErrorStatus(err); status != 0 {
+			return c.JSON(status, map[string]string{"signature_error": err.Error()})
+		} else if isRiskError(err) {
+			return c.JSON(http.StatusForbidden, map[string]string{"risk_error": err.Error()})
+		} else if errors.Is(err, er.ErrIdempotencyKeyReused) {
+			return c.JSON(http.StatusConflict, map[string]string{"idempotency_error": err.Error()})
+		} else if isTransferValidationError(err) {
 			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
 		} else if errors.Is(err, er.ErrNotEnoughMoney) {
 			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"invalid_amount": err.Error()})
+		} else if errors.Is(err, er.ErrLimitExceeded) {
+			return c.JSON(http.StatusUnprocessableEntity, limitErrorBody(err))
+		} else if isWalletFrozenError(err) {
+			return c.JSON(http.StatusLocked, map[string]string{"wallet_error": err.Error()})
+		} else if errors.Is(err, er.ErrConcurrentUpdate) {
+			return concurrentUpdateError(c, err)
 		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"transfer_error": "transaction failed and canceled"})
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{"message": "transaction succeeded"})
+	if resp.Replayed {
+		c.Response().Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	return c.JSONBlob(resp.StatusCode, resp.Body)
+}
+
+// SendBatch обрабатывает запрос на атомарный пакетный перевод.
+// POST /api/send/batch
+//
+// Заголовки:
+//   - Idempotency-Key: необязательный ключ идемпотентности (как у Send)
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "legs": [
+//	    {"from": "адрес_отправителя", "to": "адрес_получателя", "amount": "сумма_списания"},
+//	    ...
+//	  ]
+//	}
+//
+// Каждый перевод задается (и подписывается) так же, как в Send. Все переводы фиксируются вместе
+// или не фиксируется ни один; ошибка любого перевода отменяет весь пакет.
+//
+// Возможные ответы:
+//   - 200 OK: {"batch_id": "...", "legs": [{"id": "...", ..., "batch_id": "..."}, ...]} -
+//     квитанции о переводах в порядке следования в запросе
+//   - 400 Bad Request: {"invalid_value": "...", "leg": N} - пустой или слишком большой пакет
+//     либо ошибка валидации перевода N (те же ошибки, что и у Send)
+//   - 401 Unauthorized: {"auth_error": "...", "leg": 0} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "...", "leg": N} - кошелек отправителя перевода N
+//     принадлежит другой учетной записи
+//   - 400, 403, 409: {"signature_error": "...", "leg": N} - ошибка подписи перевода N (как у Send)
+//   - 403 Forbidden: {"risk_error": "...", "leg": N} - перевод N отклонен правилом проверки
+//     или требует ручной проверки (пакет не откладывается)
+//   - 409 Conflict: {"idempotency_error": "..."} - ключ использован с другим телом запроса
+//   - 422 Unprocessable Entity: {"invalid_amount": "...", "leg": N} - недостаточно средств для перевода N
+//   - 422 Unprocessable Entity: {"limit_error": "...", "period": "...", "limit": "...", "remaining": "...", "leg": N} -
+//     перевод N превышает лимит расходов отправителя (с учетом предыдущих переводов пакета)
+//   - 423 Locked: {"wallet_error": "...", "leg": N} - кошелек перевода N заморожен
+//   - 503 Service Unavailable: {"transfer_error": "..."} с заголовком Retry-After - конфликт
+//     с конкурентными переводами, пакет не выполнен и его можно повторить
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) SendBatch(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	idempotencyKey := c.Request().Header.Get(idempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": er.ErrInvalidIdempotencyKey.Error()})
+	}
+
+	var req dto.BatchTransferRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	resp, err := h.walletService.TransferBatch(ctx, req, idempotencyKey)
+	if err != nil {
+		if errors.Is(err, er.ErrIdempotencyKeyReused) {
+			return c.JSON(http.StatusConflict, map[string]string{"idempotency_error": err.Error()})
+		}
+		if errors.Is(err, er.ErrConcurrentUpdate) {
+			return concurrentUpdateError(c, err)
+		}
+
+		var status int
+		var key string
+		switch {
+		case errors.Is(err, er.ErrUnauthenticated):
+			c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+			status, key = http.StatusUnauthorized, "auth_error"
+		case isAccessError(err):
+			status, key = http.StatusForbidden, "access_error"
+		case signatureErrorStatus(err) != 0:
+			status, key = signatureErrorStatus(err), "signature_error"
+		case isRiskError(err):
+			status, key = http.StatusForbidden, "risk_error"
+		case errors.Is(err, er.ErrInvalidBatchSize), isTransferValidationError(err):
+			status, key = http.StatusBadRequest, "invalid_value"
+		case errors.Is(err, er.ErrNotEnoughMoney):
+			status, key = http.StatusUnprocessableEntity, "invalid_amount"
+		case errors.Is(err, er.ErrLimitExceeded):
+			status, key = http.StatusUnprocessableEntity, "limit_error"
+		case isWalletFrozenError(err):
+			status, key = http.StatusLocked, "wallet_error"
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"transfer_error": "batch failed and canceled"})
+		}
+
+		body := map[string]any{key: err.Error()}
+		if errors.Is(err, er.ErrLimitExceeded) {
+			body = limitErrorBody(err)
+		}
+		var legErr *er.BatchLegError
+		if errors.As(err, &legErr) {
+			body["leg"] = legErr.Leg
+		}
+		return c.JSON(status, body)
+	}
+
+	if resp.Replayed {
+		c.Response().Header().Set(idempotentReplayedHeader, "true")
+	}
+
+	return c.JSONBlob(resp.StatusCode, resp.Body)
+}
+
+// Quote обрабатывает запрос на предварительный расчет перевода без его выполнения.
+// POST /api/send/quote
+//
+// Тело запроса (JSON) - как у Send.
+//
+// Возможные ответы:
+//   - 200 OK: {"sender_address": "...", "receiver_address": "...", "amount": "...", "currency": "...",
+//     "target_amount": "...", "target_currency": "...", "rate": "...", "spread": "...", "fee": "...",
+//     "total_debit": "..."} - суммы списания и зачисления, курс и комиссия
+//   - 400 Bad Request: {"invalid_value": "..."} - те же ошибки валидации, что и у Send
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) Quote(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.TransactionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	quote, err := h.walletService.QuoteTransfer(ctx, req)
+	if err != nil {
+		if isTransferValidationError(err) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to quote transfer")
+	}
+
+	return c.JSON(http.StatusOK, quote)
+}
+
+// isTransferValidationError сообщает, вызвана ли ошибка перевода некорректными параметрами запроса.
+func isTransferValidationError(err error) bool {
+	return errors.Is(err, er.ErrSameWalletTransfer) ||
+		errors.Is(err, er.ErrInvalidAmount) ||
+		errors.Is(err, er.ErrWalletSenderNotFound) ||
+		errors.Is(err, er.ErrWalletReceiverNotFound) ||
+		errors.Is(err, er.ErrAmountAmbiguous) ||
+		errors.Is(err, er.ErrCurrencyMismatch) ||
+		errors.Is(err, er.ErrInvalidAmountPrecision) ||
+		errors.Is(err, er.ErrFXRateNotFound)
+}
+
+// isWalletFrozenError сообщает, заблокирован ли перевод статусом кошелька отправителя или получателя.
+func isWalletFrozenError(err error) bool {
+	return errors.Is(err, er.ErrWalletSenderFrozen) ||
+		errors.Is(err, er.ErrWalletReceiverFrozen) ||
+		errors.Is(err, er.ErrWalletFrozen)
+}
+
+// isRiskError сообщает, отклонен ли перевод правилом проверки.
+func isRiskError(err error) bool {
+	return errors.Is(err, er.ErrTransferDenied) || errors.Is(err, er.ErrTransferReviewRequired)
+}
+
+// limitErrorBody возвращает тело ответа на превышение лимита расходов:
+// описание ошибки, вид лимита, его значение и остаток в текущем периоде.
+func limitErrorBody(err error) map[string]any {
+	body := map[string]any{"limit_error": err.Error()}
+
+	var limitErr *er.LimitExceededError
+	if errors.As(err, &limitErr) {
+		body["period"] = limitErr.Period
+		body["limit"] = limitErr.Limit
+		body["remaining"] = limitErr.Remaining
+	}
+	return body
+}
+
+// concurrentUpdateError отвечает 503 с Retry-After на операцию, прерванную конфликтом блокировок
+// при всех повторах: изменения не зафиксированы, и запрос можно повторить (с тем же Idempotency-Key).
+func concurrentUpdateError(c echo.Context, err error) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, concurrentUpdateRetryAfter)
+	return c.JSON(http.StatusServiceUnavailable, map[string]string{"transfer_error": err.Error()})
+}
+
+// signatureErrorStatus возвращает HTTP-код ошибки подписи перевода (0 - ошибка не связана с подписью).
+func signatureErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, er.ErrSignatureRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, er.ErrInvalidSignature):
+		return http.StatusForbidden
+	case errors.Is(err, er.ErrNonceReused):
+		return http.StatusConflict
+	}
+	return 0
 }
 
 // Balance обрабатывает запрос на получение баланса кошелька.
@@ -80,7 +342,7 @@ func (h *walletHandler) Send(c echo.Context) error {
 //   - address: адрес кошелька
 //
 // Возможные ответы:
-//   - 200 OK: {"balance": "..."} - текущий баланс
+//   - 200 OK: {"balance": "...", "currency": "..."} - текущий баланс и валюта кошелька
 //   - 404 Not Found: {"wallet error": "..."} - кошелек не найден
 //   - 500 Internal Server Error - ошибка сервера
 func (h *walletHandler) Balance(c echo.Context) error {
@@ -95,5 +357,259 @@ func (h *walletHandler) Balance(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get balance")
 	}
 
-	return c.JSON(http.StatusOK, map[string]decimal.Decimal{"balance": balance})
+	return c.JSON(http.StatusOK, balance)
+}
+
+// Create обрабатывает запрос на создание кошелька.
+// POST /wallets
+//
+// Тело запроса (JSON, необязательно):
+//
+//	{
+//	  "balance": "начальный_баланс",
+//	  "currency": "код_валюты",
+//	  "public_key": "открытый_ключ_ed25519_hex"
+//	}
+//
+// Адрес кошелька - SHA-256 открытого ключа; переводы с кошелька подписываются закрытым ключом.
+// Ключ обязателен для учетной записи; привилегированный клиент может создать кошелек без ключа.
+// Требует разрешения wallets:create; начальный баланс (выпуск средств) - еще и wallets:mint.
+// Если валюта не указана, кошелек создается в RUB.
+// Владельцем кошелька становится учетная запись API-ключа. Привилегированный клиент может
+// указать владельца в поле owner (идентификатор учетной записи) или создать кошелек без владельца.
+//
+// Возможные ответы:
+//   - 201 Created: {"address": "...", "balance": "...", "currency": "...", "status": "active",
+//     "public_key": "...", "nonce": 0, "created_at": "..."}
+//   - 400 Bad Request: {"invalid_value": "..."} - неверный формат JSON, отрицательный баланс,
+//     неизвестная валюта, баланс не представим в валюте, невалидный или отсутствующий ключ
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - начальный баланс без разрешения wallets:mint
+//     или чужой владелец без привилегированного доступа
+//   - 404 Not Found: {"account_error": "..."} - учетная запись владельца не найдена
+//   - 409 Conflict: {"wallet_error": "..."} - кошелек с этим ключом уже существует
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.CreateWalletRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	if !req.Balance.IsZero() {
+		if err := h.authorizer.Check(c, models.PermissionWalletsMint); err != nil {
+			return accessError(c, err)
+		}
+	}
+
+	wallet, err := h.walletService.CreateWallet(ctx, req.Balance, req.Currency, req.Owner, req.PublicKey)
+	if err != nil {
+		if isAccessError(err) {
+			return accessError(c, err)
+		} else if errors.Is(err, er.ErrAccountNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"account_error": err.Error()})
+		} else if errors.Is(err, er.ErrWalletExists) {
+			return c.JSON(http.StatusConflict, map[string]string{"wallet_error": err.Error()})
+		} else if errors.Is(err, er.ErrInvalidAPIKeyID) ||
+			errors.Is(err, er.ErrInvalidPublicKey) ||
+			errors.Is(err, er.ErrInvalidInitialBalance) ||
+			errors.Is(err, er.ErrCurrencyNotFound) ||
+			errors.Is(err, er.ErrInvalidAmountPrecision) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create wallet")
+	}
+
+	return c.JSON(http.StatusCreated, wallet)
+}
+
+// Get обрабатывает запрос на получение полной информации о кошельке.
+// GET /wallets/{address}
+//
+// Параметры пути:
+//   - address: адрес кошелька
+//
+// Возможные ответы:
+//   - 200 OK: {"address": "...", "balance": "...", "currency": "...", "status": "...", "created_at": "..."}
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	wallet, err := h.walletService.Wallet(ctx, c.Param("address"))
+	if err != nil {
+		if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": er.ErrWalletNotFound.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get wallet")
+	}
+
+	return c.JSON(http.StatusOK, wallet)
+}
+
+// Close обрабатывает запрос на закрытие кошелька.
+// DELETE /wallets/{address}
+//
+// Параметры пути:
+//   - address: адрес кошелька
+//
+// Возможные ответы:
+//   - 204 No Content - кошелек закрыт
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек принадлежит другой учетной записи
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден или уже закрыт
+//   - 409 Conflict: {"wallet_error": "..."} - баланс кошелька не равен нулю
+//   - 423 Locked: {"wallet_error": "..."} - кошелек заморожен
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) Close(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	err := h.walletService.CloseWallet(ctx, c.Param("address"))
+	if err != nil {
+		if isAccessError(err) {
+			return accessError(c, err)
+		} else if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		} else if errors.Is(err, er.ErrWalletNotEmpty) {
+			return c.JSON(http.StatusConflict, map[string]string{"wallet_error": err.Error()})
+		} else if isWalletFrozenError(err) {
+			return c.JSON(http.StatusLocked, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to close wallet")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SetStatus обрабатывает запрос на заморозку или разморозку кошелька.
+// PUT /wallets/{address}/status
+//
+// Требует разрешения wallets:freeze. Изменение сохраняется в истории статусов.
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "status": "active | frozen_in | frozen_out | frozen",
+//	  "reason": "причина изменения"
+//	}
+//
+// frozen_in блокирует зачисления на кошелек, frozen_out - списания (переводы, холды, возвраты
+// с кошелька), frozen - оба направления. Замороженный кошелек нельзя закрыть.
+//
+// Возможные ответы:
+//   - 200 OK: данные кошелька с новым статусом
+//   - 400 Bad Request: {"invalid_value": "..."} - неизвестный статус или пустая причина
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения wallets:freeze
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден или закрыт
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) SetStatus(c echo.Context) error {
+	var req dto.WalletStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	wallet, err := h.walletService.SetWalletStatus(c.Request().Context(), c.Param("address"), req)
+	if err != nil {
+		if isAccessError(err) {
+			return accessError(c, err)
+		} else if errors.Is(err, er.ErrInvalidWalletStatus) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update wallet status")
+	}
+
+	return c.JSON(http.StatusOK, wallet)
+}
+
+// StatusHistory обрабатывает запрос на получение истории статусов кошелька.
+// GET /wallets/{address}/status-history
+//
+// Требует разрешения audit:read.
+//
+// Возможные ответы:
+//   - 200 OK: {"changes": [{"from_status": "...", "to_status": "...", "reason": "...", ...}]} -
+//     изменения от новых к старым
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения audit:read
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) StatusHistory(c echo.Context) error {
+	changes, err := h.walletService.WalletStatusHistory(c.Request().Context(), c.Param("address"))
+	if err != nil {
+		if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get wallet status history")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]dto.WalletStatusChangeResponse{"changes": changes})
+}
+
+// Limits обрабатывает запрос на получение лимитов расходов кошелька.
+// GET /wallet/{address}/limits
+//
+// Лимиты кошелька - лимиты его уровня (limits.tiers в конфигурации) в валюте кошелька,
+// замененные индивидуальными значениями (PUT /wallets/{address}/limits). Расход считается
+// по исходящим переводам без комиссий и возвратов за календарные периоды в UTC.
+//
+// Возможные ответы:
+//   - 200 OK: {"address": "...", "currency": "...", "tier": "...", "limits": [{"period": "daily",
+//     "limit": "...", "used": "...", "remaining": "...", "resets_at": "..."}, ...], "checked_at": "..."}
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) Limits(c echo.Context) error {
+	limits, err := h.walletService.WalletLimits(c.Request().Context(), c.Param("address"))
+	if err != nil {
+		if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get wallet limits")
+	}
+
+	return c.JSON(http.StatusOK, limits)
+}
+
+// SetLimits обрабатывает запрос на назначение уровня и индивидуальных лимитов расходов кошелька.
+// PUT /wallets/{address}/limits
+//
+// Требует разрешения limits:manage. Запрос заменяет прежние настройки кошелька целиком.
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "tier": "уровень (пустая строка - уровень по умолчанию)",
+//	  "max_single": "максимальная сумма перевода (null - лимит уровня)",
+//	  "daily": "лимит за сутки",
+//	  "weekly": "лимит за неделю",
+//	  "monthly": "лимит за месяц"
+//	}
+//
+// Возможные ответы:
+//   - 200 OK: лимиты кошелька после изменения (как у Limits)
+//   - 400 Bad Request: {"invalid_value": "..."} - неизвестный уровень или отрицательный лимит
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа
+//   - 403 Forbidden: {"access_error": "..."} - нет разрешения limits:manage
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден или закрыт
+//   - 500 Internal Server Error - ошибка сервера
+func (h *walletHandler) SetLimits(c echo.Context) error {
+	var req dto.WalletLimitsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	limits, err := h.walletService.SetWalletLimits(c.Request().Context(), c.Param("address"), req)
+	if err != nil {
+		if errors.Is(err, er.ErrInvalidLimit) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to update wallet limits")
+	}
+
+	return c.JSON(http.StatusOK, limits)
 }
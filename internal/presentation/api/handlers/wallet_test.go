@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// failingWalletService завершает переводы ошибкой err. Неиспользуемые методы не реализованы.
+type failingWalletService struct {
+	service.WalletService
+	err error
+}
+
+func (s *failingWalletService) TransferMoney(context.Context, dto.TransactionRequest,
+	string) (*dto.StoredResponse, error) {
+	return nil, s.err
+}
+
+func (s *failingWalletService) TransferBatch(context.Context, dto.BatchTransferRequest,
+	string) (*dto.StoredResponse, error) {
+	return nil, s.err
+}
+
+// TestSendConcurrentUpdate проверяет, что взаимоблокировка или ошибка сериализации,
+// не разрешившаяся повторами в репозитории (ErrConcurrentUpdate), отдается клиенту как
+// повторяемый ответ 503 с Retry-After, а не как 500.
+func TestSendConcurrentUpdate(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"deadlock", fmt.Errorf("%w: %w", er.ErrConcurrentUpdate, &pgconn.PgError{Code: "40P01"}),
+			http.StatusServiceUnavailable},
+		{"serialization failure", fmt.Errorf("%w: %w", er.ErrConcurrentUpdate, &pgconn.PgError{Code: "40001"}),
+			http.StatusServiceUnavailable},
+		{"other database error", errors.New("connection reset"), http.StatusInternalServerError},
+	}
+
+	routes := []struct {
+		path string
+		body string
+		send func(h *walletHandler, c echo.Context) error
+	}{
+		{"/api/send", `{"from": "a", "to": "b", "amount": "1"}`, (*walletHandler).Send},
+		{"/api/send/batch", `{"legs": [{"from": "a", "to": "b", "amount": "1"}]}`, (*walletHandler).SendBatch},
+	}
+
+	for _, route := range routes {
+		for _, tt := range tests {
+			t.Run(route.path+"/"+tt.name, func(t *testing.T) {
+				h := &walletHandler{walletService: &failingWalletService{err: tt.err}}
+
+				req := httptest.NewRequest(http.MethodPost, route.path, strings.NewReader(route.body))
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				rec := httptest.NewRecorder()
+				if err := route.send(h, echo.New().NewContext(req, rec)); err != nil {
+					t.Fatalf("handler error = %v", err)
+				}
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+				}
+				if retryAfter := rec.Header().Get(echo.HeaderRetryAfter); (tt.wantStatus == http.StatusServiceUnavailable) !=
+					(retryAfter != "") {
+					t.Fatalf("Retry-After = %q for status %d", retryAfter, rec.Code)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package handlers предоставляет HTTP-обработчики для API сервиса кошельков.
+package handlers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/dto"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"net/http"
+)
+
+// webhookHandler реализует интерфейс WebhookHandler.
+// Обрабатывает HTTP-запросы управления подписками на вебхуки и их доставками.
+type webhookHandler struct {
+	webhookService service.WebhookService
+}
+
+// NewWebhookHandler создает новый экземпляр обработчика вебхуков.
+//
+// Параметры:
+//   - webhookService: сервис вебхуков
+//
+// Возвращает:
+//   - interfaces.WebhookHandler: реализацию интерфейса обработчика
+func NewWebhookHandler(webhookService service.WebhookService) interfaces.WebhookHandler {
+	return &webhookHandler{webhookService: webhookService}
+}
+
+// Create обрабатывает запрос на подписку на события кошелька.
+// POST /webhooks
+//
+// Тело запроса (JSON):
+//
+//	{
+//	  "wallet": "адрес_кошелька",
+//	  "url": "https://example.com/hooks/payments",
+//	  "events": ["transfer.incoming", "transfer.outgoing"]
+//	}
+//
+// Возможные ответы:
+//   - 201 Created: данные подписки вместе с секретом подписи (secret возвращается только здесь)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный URL или типы событий
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек принадлежит другой учетной записи
+//   - 404 Not Found: {"wallet_error": "..."} - кошелек не найден
+//   - 500 Internal Server Error - ошибка сервера
+func (h *webhookHandler) Create(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req dto.WebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_json": "json is formatted incorrectly"})
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(ctx, req)
+	if err != nil {
+		if isAccessError(err) {
+			return accessError(c, err)
+		} else if errors.Is(err, er.ErrInvalidWebhook) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+		} else if errors.Is(err, er.ErrWalletNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"wallet_error": err.Error()})
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to create webhook")
+	}
+
+	return c.JSON(http.StatusCreated, webhook)
+}
+
+// List обрабатывает запрос на получение подписок кошелька.
+// GET /wallet/{address}/webhooks
+//
+// Возможные ответы:
+//   - 200 OK: {"webhooks": [...]} - подписки кошелька (без секретов)
+//   - 500 Internal Server Error - ошибка сервера
+func (h *webhookHandler) List(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhooks, err := h.webhookService.Webhooks(ctx, c.Param("address"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get webhooks")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]dto.WebhookResponse{"webhooks": webhooks})
+}
+
+// Get обрабатывает запрос на получение подписки.
+// GET /webhooks/{id}
+//
+// Возможные ответы:
+//   - 200 OK: данные подписки (без секрета)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 404 Not Found: {"webhook_error": "..."} - подписка не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *webhookHandler) Get(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	webhook, err := h.webhookService.Webhook(ctx, c.Param("id"))
+	if err != nil {
+		return webhookError(c, err, "failed to get webhook")
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+// Delete обрабатывает запрос на удаление подписки. Неотправленные доставки отменяются.
+// DELETE /webhooks/{id}
+//
+// Возможные ответы:
+//   - 204 No Content - подписка удалена
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек подписки принадлежит другой учетной записи
+//   - 404 Not Found: {"webhook_error": "..."} - подписка не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *webhookHandler) Delete(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	if err := h.webhookService.DeleteWebhook(ctx, c.Param("id")); err != nil {
+		return webhookError(c, err, "failed to delete webhook")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Deliveries обрабатывает запрос на получение журнала доставок подписки.
+// GET /webhooks/{id}/deliveries?status=failed
+//
+// Параметр status необязателен: pending, succeeded, failed или cancelled.
+//
+// Возможные ответы:
+//   - 200 OK: {"deliveries": [...]} - последние доставки (от новых к старым)
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор или статус
+//   - 404 Not Found: {"webhook_error": "..."} - подписка не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *webhookHandler) Deliveries(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	deliveries, err := h.webhookService.Deliveries(ctx, c.Param("id"), c.QueryParam("status"))
+	if err != nil {
+		return webhookError(c, err, "failed to get webhook deliveries")
+	}
+
+	return c.JSON(http.StatusOK, map[string][]dto.WebhookDeliveryResponse{"deliveries": deliveries})
+}
+
+// Redeliver обрабатывает запрос на повторную доставку.
+// POST /webhooks/{id}/deliveries/{delivery_id}/redeliver
+//
+// Возможные ответы:
+//   - 202 Accepted: данные доставки (status: pending) - доставка будет отправлена в ближайшее время
+//   - 400 Bad Request: {"invalid_value": "..."} - невалидный идентификатор
+//   - 401 Unauthorized: {"auth_error": "..."} - запрос без API-ключа или с недействительным ключом
+//   - 403 Forbidden: {"access_error": "..."} - кошелек подписки принадлежит другой учетной записи
+//   - 404 Not Found: {"webhook_error": "..."} - подписка или доставка не найдена
+//   - 500 Internal Server Error - ошибка сервера
+func (h *webhookHandler) Redeliver(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	delivery, err := h.webhookService.Redeliver(ctx, c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		return webhookError(c, err, "failed to redeliver webhook")
+	}
+
+	return c.JSON(http.StatusAccepted, delivery)
+}
+
+// webhookError преобразует ошибку сервиса вебхуков в HTTP-ответ.
+func webhookError(c echo.Context, err error, message string) error {
+	if isAccessError(err) {
+		return accessError(c, err)
+	} else if errors.Is(err, er.ErrInvalidWebhookID) || errors.Is(err, er.ErrInvalidWebhook) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"invalid_value": err.Error()})
+	} else if errors.Is(err, er.ErrWebhookNotFound) || errors.Is(err, er.ErrWebhookDeliveryNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"webhook_error": err.Error()})
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, message)
+}
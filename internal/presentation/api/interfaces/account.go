@@ -0,0 +1,15 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// AccountHandler определяет контракт для обработчика учетных записей и API-ключей.
+type AccountHandler interface {
+	CreateAccount(c echo.Context) error
+	UpdateAccount(c echo.Context) error
+	CreateKey(c echo.Context) error
+	ListKeys(c echo.Context) error
+	RevokeKey(c echo.Context) error
+}
@@ -0,0 +1,11 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// AuditHandler определяет контракт для обработчика журнала аудита.
+type AuditHandler interface {
+	Events(c echo.Context) error
+}
@@ -0,0 +1,14 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// HoldHandler определяет контракт для обработчика холдов (резервирования средств).
+type HoldHandler interface {
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	Capture(c echo.Context) error
+	Void(c echo.Context) error
+}
@@ -0,0 +1,11 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// LedgerHandler определяет контракт для обработчика операций с журналом двойной записи.
+type LedgerHandler interface {
+	Verify(c echo.Context) error
+}
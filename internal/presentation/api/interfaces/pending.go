@@ -0,0 +1,17 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// PendingTransferHandler определяет контракт для обработчика отложенных переводов
+// и политик согласования кошельков.
+type PendingTransferHandler interface {
+	List(c echo.Context) error
+	Get(c echo.Context) error
+	Approve(c echo.Context) error
+	Reject(c echo.Context) error
+	Policy(c echo.Context) error
+	SetPolicy(c echo.Context) error
+}
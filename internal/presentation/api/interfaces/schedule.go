@@ -0,0 +1,15 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// ScheduledTransferHandler определяет контракт для обработчика запланированных переводов.
+type ScheduledTransferHandler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	Get(c echo.Context) error
+	Update(c echo.Context) error
+	Cancel(c echo.Context) error
+}
@@ -0,0 +1,12 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// StreamHandler определяет контракт для обработчика потока транзакций.
+type StreamHandler interface {
+	SSE(c echo.Context) error
+	WebSocket(c echo.Context) error
+}
@@ -10,4 +10,7 @@ import (
 // Реализации этого интерфейса должны обрабатывать запросы, связанные с историей транзакций.
 type TransactionHandler interface {
 	Last(c echo.Context) error
+	Get(c echo.Context) error
+	History(c echo.Context) error
+	Refund(c echo.Context) error
 }
@@ -9,5 +9,14 @@ import (
 // Описывает методы API для работы с кошельками и переводами средств.
 type WalletHandler interface {
 	Send(c echo.Context) error
+	Quote(c echo.Context) error
+	SendBatch(c echo.Context) error
 	Balance(c echo.Context) error
+	Create(c echo.Context) error
+	Get(c echo.Context) error
+	Close(c echo.Context) error
+	SetStatus(c echo.Context) error
+	StatusHistory(c echo.Context) error
+	Limits(c echo.Context) error
+	SetLimits(c echo.Context) error
 }
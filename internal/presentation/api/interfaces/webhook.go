@@ -0,0 +1,16 @@
+// Package interfaces определяет контракты для HTTP-обработчиков API.
+package interfaces
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// WebhookHandler определяет контракт для обработчика подписок на вебхуки.
+type WebhookHandler interface {
+	Create(c echo.Context) error
+	List(c echo.Context) error
+	Get(c echo.Context) error
+	Delete(c echo.Context) error
+	Deliveries(c echo.Context) error
+	Redeliver(c echo.Context) error
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"net/http"
+	"strings"
+)
+
+const (
+	// APIKeyHeader - заголовок, в котором клиент может передать API-ключ вместо Authorization.
+	APIKeyHeader = "X-API-Key"
+	// bearerPrefix - схема заголовка Authorization с API-ключом.
+	bearerPrefix = "Bearer "
+)
+
+// Authenticate проверяет API-ключ запроса (Authorization: Bearer <ключ> или X-API-Key)
+// и помещает субъект операции (models.Principal) в контекст запроса. Запрос с неизвестным
+// или отозванным ключом отклоняется с 401. Запросы без ключа не отклоняются:
+// операции, требующие владельца кошелька, проверяют субъект в сервисном слое.
+// Привилегированный клиент (см. Privileged) получает привилегированный субъект,
+// поэтому Authenticate должен выполняться после Privileged.
+//
+// Параметры:
+//   - accountService: сервис учетных записей, проверяющий ключи
+//
+// Возвращает:
+//   - echo.MiddlewareFunc: промежуточный обработчик
+func Authenticate(accountService service.AccountService) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			var principal *models.Principal
+			if key := apiKey(c.Request()); key != "" {
+				p, err := accountService.Authenticate(ctx, key)
+				if err != nil {
+					if errors.Is(err, er.ErrInvalidAPIKey) {
+						return Unauthorized(c, err)
+					}
+					return echo.NewHTTPError(http.StatusInternalServerError, "failed to authenticate")
+				}
+				principal = p
+			}
+
+			if IsPrivileged(c) {
+				privileged := models.Principal{}
+				if principal != nil {
+					privileged = *principal
+				}
+				privileged.Privileged = true
+				principal = &privileged
+			}
+
+			if principal != nil {
+				c.SetRequest(c.Request().WithContext(models.WithPrincipal(ctx, principal)))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Unauthorized отвечает 401 с указанием схемы аутентификации.
+//
+// Параметры:
+//   - c: контекст запроса Echo
+//   - err: причина отказа
+//
+// Возвращает:
+//   - error: ошибка записи ответа
+func Unauthorized(c echo.Context, err error) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	return c.JSON(http.StatusUnauthorized, map[string]string{"auth_error": err.Error()})
+}
+
+// apiKey возвращает API-ключ из заголовков запроса (пустая строка - ключа нет).
+func apiKey(r *http.Request) string {
+	if auth := r.Header.Get(echo.HeaderAuthorization); len(auth) > len(bearerPrefix) &&
+		strings.EqualFold(auth[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(auth[len(bearerPrefix):])
+	}
+	return strings.TrimSpace(r.Header.Get(APIKeyHeader))
+}
@@ -0,0 +1,47 @@
+// Package middleware содержит промежуточные обработчики HTTP-запросов API.
+package middleware
+
+import (
+	"crypto/subtle"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// AdminTokenHeader - заголовок, в котором привилегированный клиент передает токен администратора.
+	AdminTokenHeader = "X-Admin-Token"
+	// privilegedKey - ключ контекста Echo с признаком привилегированного клиента.
+	privilegedKey = "privileged"
+)
+
+// Privileged помечает запросы с корректным токеном администратора как привилегированные.
+// Запросы без токена не отклоняются: привилегированный клиент получает роль администратора
+// (rbac.admin_token_role), а разрешения маршрутов проверяет Authorizer.
+//
+// Параметры:
+//   - token: токен администратора из конфигурации (пустой токен отключает привилегированный доступ)
+//
+// Возвращает:
+//   - echo.MiddlewareFunc: промежуточный обработчик
+func Privileged(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			provided := c.Request().Header.Get(AdminTokenHeader)
+			if token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+				c.Set(privilegedKey, true)
+			}
+			return next(c)
+		}
+	}
+}
+
+// IsPrivileged сообщает, был ли запрос выполнен привилегированным клиентом.
+//
+// Параметры:
+//   - c: контекст запроса Echo
+//
+// Возвращает:
+//   - bool: true, если запрос содержит корректный токен администратора
+func IsPrivileged(c echo.Context) bool {
+	privileged, _ := c.Get(privilegedKey).(bool)
+	return privileged
+}
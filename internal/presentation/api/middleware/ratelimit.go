@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// Заголовки ограничения частоты (draft-ietf-httpapi-ratelimit-headers)
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+	// rateLimitKey - ключ контекста Echo с самым строгим решением ограничения по запросу.
+	rateLimitKey = "rate_limit"
+	// maxTransferBodyBytes - максимальный размер тела запроса перевода, читаемого PerWallet.
+	maxTransferBodyBytes = 1 << 20
+)
+
+// RateLimiter ограничивает частоту запросов корзинами токенов: по адресу клиента
+// (до аутентификации, в том числе запросы с неверным ключом), по API-ключу и по кошельку отправителя перевода.
+// Ответы содержат заголовки RateLimit-* самого строгого из примененных ограничений,
+// отказ - 429 Too Many Requests с заголовком Retry-After.
+// Если хранилище корзин недоступно, запрос пропускается: ограничение не должно останавливать переводы.
+type RateLimiter struct {
+	store   repository.RateLimitStore
+	address models.RateLimit
+	client  models.RateLimit
+	wallet  models.RateLimit
+}
+
+// NewRateLimiter создает ограничение частоты запросов.
+//
+// Параметры:
+//   - store: хранилище корзин токенов
+//   - address: ограничение по адресу клиента (пустое - без ограничения)
+//   - client: ограничение по API-ключу (пустое - без ограничения)
+//   - wallet: ограничение по кошельку отправителя (пустое - без ограничения)
+//
+// Возвращает:
+//   - *RateLimiter: ограничение частоты запросов
+func NewRateLimiter(store repository.RateLimitStore, address, client, wallet models.RateLimit) *RateLimiter {
+	return &RateLimiter{store: store, address: address, client: client, wallet: wallet}
+}
+
+// PerAddress возвращает промежуточный обработчик, ограничивающий все запросы с одного адреса клиента.
+// Обработчик выполняется до Authenticate, поэтому ограничивает и перебор API-ключей:
+// запросы с неизвестным ключом отклоняются аутентификацией, но расходуют корзину адреса.
+//
+// Возвращает:
+//   - echo.MiddlewareFunc: промежуточный обработчик
+func (l *RateLimiter) PerAddress() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !l.address.Enabled() {
+			return next
+		}
+		return func(c echo.Context) error {
+			if !l.allow(c, "address:"+c.RealIP(), l.address) {
+				return tooManyRequests(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// PerKey возвращает промежуточный обработчик, ограничивающий запросы с одним API-ключом.
+// Ключ определяется по субъекту запроса, поэтому обработчик должен выполняться после Authenticate;
+// запросы без ключа ограничиваются только по адресу клиента (PerAddress).
+//
+// Возвращает:
+//   - echo.MiddlewareFunc: промежуточный обработчик
+func (l *RateLimiter) PerKey() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !l.client.Enabled() {
+			return next
+		}
+		return func(c echo.Context) error {
+			principal, ok := models.PrincipalFromContext(c.Request().Context())
+			if !ok || principal.KeyID == "" {
+				return next(c)
+			}
+
+			if !l.allow(c, "key:"+principal.KeyID, l.client) {
+				return tooManyRequests(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+// PerWallet возвращает промежуточный обработчик маршрута перевода, ограничивающий
+// запросы по кошельку отправителя (поле from тела запроса или from каждого перевода пакета legs).
+// Владелец кошелька на этом этапе еще не проверен, поэтому корзина принадлежит паре
+// «вызывающий - кошелек»: запросы с чужим адресом отправителя расходуют только корзину
+// вызывающего и не блокируют переводы владельца. Запрос с невалидным телом пропускается:
+// его отклонит обработчик маршрута.
+//
+// Возвращает:
+//   - echo.MiddlewareFunc: промежуточный обработчик
+func (l *RateLimiter) PerWallet() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if !l.wallet.Enabled() {
+			return next
+		}
+		return func(c echo.Context) error {
+			senders, err := senderWallets(c)
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "request body is too large")
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, "failed to read request body")
+			}
+
+			caller := callerKey(c)
+			for _, sender := range senders {
+				if !l.allow(c, "wallet:"+sender+":"+caller, l.wallet) {
+					return tooManyRequests(c)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// allow забирает токен из корзины ключа и записывает заголовки RateLimit-*.
+func (l *RateLimiter) allow(c echo.Context, key string, limit models.RateLimit) bool {
+	decision, err := l.store.Take(c.Request().Context(), key, limit)
+	if err != nil {
+		log.Printf("[ERROR] Error checking rate limit %s, request is allowed: %v", key, err)
+		return true
+	}
+
+	// Заголовки описывают самое строгое ограничение: отказ или меньший остаток
+	if previous, ok := c.Get(rateLimitKey).(models.RateLimitDecision); !ok ||
+		!decision.Allowed || decision.Remaining <= previous.Remaining {
+		c.Set(rateLimitKey, decision)
+
+		header := c.Response().Header()
+		header.Set(RateLimitLimitHeader, strconv.Itoa(decision.Limit))
+		header.Set(RateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+		header.Set(RateLimitResetHeader, strconv.Itoa(seconds(decision.Reset)))
+		header.Set(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%d;burst=%d", limit.Requests,
+			seconds(limit.Period), limit.Burst))
+		if !decision.Allowed {
+			header.Set(echo.HeaderRetryAfter, strconv.Itoa(max(seconds(decision.RetryAfter), 1)))
+		}
+	}
+
+	return decision.Allowed
+}
+
+// tooManyRequests отвечает 429 на запрос, исчерпавший лимит.
+func tooManyRequests(c echo.Context) error {
+	return c.JSON(http.StatusTooManyRequests, map[string]string{"rate_limit_error": er.ErrRateLimited.Error()})
+}
+
+// callerKey возвращает ключ вызывающего для корзины кошелька: учетную запись субъекта,
+// для привилегированного клиента без учетной записи - признак администратора, иначе адрес клиента.
+func callerKey(c echo.Context) string {
+	principal, ok := models.PrincipalFromContext(c.Request().Context())
+	switch {
+	case ok && principal.AccountPublicID != "":
+		return "account:" + principal.AccountPublicID
+	case ok && principal.Privileged:
+		return "admin"
+	default:
+		return "ip:" + c.RealIP()
+	}
+}
+
+// senderWallets возвращает кошельки отправителей из тела запроса перевода без повторов.
+// Читается не более maxTransferBodyBytes; тело восстанавливается, чтобы его мог прочитать
+// обработчик маршрута.
+func senderWallets(c echo.Context) ([]string, error) {
+	r := c.Request()
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Response(), r.Body, maxTransferBodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var transfer struct {
+		From string `json:"from"`
+		Legs []struct {
+			From string `json:"from"`
+		} `json:"legs"`
+	}
+	if json.Unmarshal(body, &transfer) != nil {
+		return nil, nil
+	}
+
+	senders := make([]string, 0, 1+len(transfer.Legs))
+	seen := make(map[string]bool, cap(senders))
+	add := func(address string) {
+		if address != "" && !seen[address] {
+			seen[address] = true
+			senders = append(senders, address)
+		}
+	}
+
+	add(transfer.From)
+	for _, leg := range transfer.Legs {
+		add(leg.From)
+	}
+
+	return senders, nil
+}
+
+// seconds округляет длительность вверх до целых секунд.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/infrastructure/ratelimit"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// newLimitedEcho создает сервер с ограничением по адресу клиента на burst запросов.
+func newLimitedEcho(t *testing.T, trustedProxies []string, burst int) *echo.Echo {
+	t.Helper()
+
+	extractor, err := NewIPExtractor(trustedProxies)
+	if err != nil {
+		t.Fatalf("NewIPExtractor() error = %v", err)
+	}
+
+	e := echo.New()
+	e.IPExtractor = extractor
+	limiter := NewRateLimiter(ratelimit.NewMemoryStore(),
+		models.RateLimit{Requests: 1, Period: time.Hour, Burst: burst}, models.RateLimit{}, models.RateLimit{})
+	e.Use(limiter.PerAddress())
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, c.RealIP())
+	})
+	return e
+}
+
+// request выполняет запрос с адреса соединения remoteAddr и заголовком X-Forwarded-For.
+func request(e *echo.Echo, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		req.Header.Set(echo.HeaderXRealIP, forwardedFor)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// TestPerAddressIgnoresForwardedFor проверяет, что подмена X-Forwarded-For и X-Real-IP
+// не дает клиенту новой корзины: все запросы с одного адреса соединения расходуют одну корзину.
+func TestPerAddressIgnoresForwardedFor(t *testing.T) {
+	e := newLimitedEcho(t, nil, 3)
+
+	for i := 0; i < 3; i++ {
+		rec := request(e, "203.0.113.7:40000", "198.51.100."+strconv.Itoa(i))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i+1, rec.Code, http.StatusOK)
+		}
+		if rec.Body.String() != "203.0.113.7" {
+			t.Fatalf("request %d: client address = %q, want the connection address", i+1, rec.Body.String())
+		}
+	}
+
+	rec := request(e, "203.0.113.7:40001", "198.51.100.99")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if rec.Header().Get(echo.HeaderRetryAfter) == "" {
+		t.Fatal("429 response has no Retry-After header")
+	}
+
+	if rec = request(e, "203.0.113.8:40000", ""); rec.Code != http.StatusOK {
+		t.Fatalf("another client: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+// TestPerAddressTrustedProxy проверяет, что за доверенным прокси клиенты различаются
+// по X-Forwarded-For, а подставленные клиентом записи слева от адреса, добавленного
+// прокси, не учитываются.
+func TestPerAddressTrustedProxy(t *testing.T) {
+	e := newLimitedEcho(t, []string{"10.0.0.0/24"}, 1)
+
+	if rec := request(e, "10.0.0.1:40000", "198.51.100.1"); rec.Code != http.StatusOK ||
+		rec.Body.String() != "198.51.100.1" {
+		t.Fatalf("status = %d, client address = %q, want 200 from 198.51.100.1", rec.Code, rec.Body.String())
+	}
+
+	for i := 0; i < 3; i++ {
+		rec := request(e, "10.0.0.1:40000", "192.0.2."+strconv.Itoa(i)+", 198.51.100.1")
+		if rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("forged request %d: status = %d, want %d", i+1, rec.Code, http.StatusTooManyRequests)
+		}
+	}
+
+	if rec := request(e, "10.0.0.1:40000", "198.51.100.2"); rec.Code != http.StatusOK {
+		t.Fatalf("another client: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+// TestNewIPExtractorInvalidRange проверяет отказ при невалидном диапазоне прокси.
+func TestNewIPExtractorInvalidRange(t *testing.T) {
+	if _, err := NewIPExtractor([]string{"10.0.0.1"}); err == nil {
+		t.Fatal("NewIPExtractor() error = nil, want invalid range error")
+	}
+}
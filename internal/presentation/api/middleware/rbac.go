@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	er "github.com/normalniydada/case_infotecs/internal/domain/errors"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/repository"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"log"
+	"net/http"
+)
+
+// Authorizer проверяет разрешения роли субъекта запроса по политике доступа
+// и записывает отказы в журнал аудита. Должен выполняться после Authenticate.
+type Authorizer struct {
+	policy repository.AccessPolicy
+	audit  service.AuditService
+}
+
+// NewAuthorizer создает проверку разрешений.
+//
+// Параметры:
+//   - policy: политика доступа (роли и их разрешения)
+//   - audit: журнал аудита, в который записываются отказы
+//
+// Возвращает:
+//   - *Authorizer: проверка разрешений
+func NewAuthorizer(policy repository.AccessPolicy, audit service.AuditService) *Authorizer {
+	return &Authorizer{policy: policy, audit: audit}
+}
+
+// Require возвращает промежуточный обработчик маршрута, пропускающий запрос,
+// только если роль субъекта имеет разрешение. Анонимный запрос без разрешения
+// отклоняется с 401, запрос с API-ключом - с 403 {"access_error": "..."}.
+//
+// Параметры:
+//   - permission: разрешение, которое требует маршрут
+//
+// Возвращает:
+//   - echo.MiddlewareFunc: промежуточный обработчик
+func (a *Authorizer) Require(permission models.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := a.Check(c, permission); err != nil {
+				if errors.Is(err, er.ErrUnauthenticated) {
+					return Unauthorized(c, err)
+				}
+				return c.JSON(http.StatusForbidden, map[string]string{"access_error": err.Error()})
+			}
+			return next(c)
+		}
+	}
+}
+
+// Check проверяет разрешение внутри обработчика, когда оно зависит от тела запроса
+// (например, начальный баланс кошелька требует wallets:mint). Отказ записывается в журнал аудита.
+//
+// Параметры:
+//   - c: контекст запроса Echo
+//   - permission: проверяемое разрешение
+//
+// Возвращает:
+//   - error: nil при наличии разрешения, ErrUnauthenticated для анонимного запроса,
+//     иначе ErrPermissionDenied
+func (a *Authorizer) Check(c echo.Context, permission models.Permission) error {
+	principal, _ := models.PrincipalFromContext(c.Request().Context())
+	role := a.policy.Role(principal)
+	if a.policy.Allows(role, permission) {
+		return nil
+	}
+
+	var err error
+	if principal == nil {
+		err = er.ErrUnauthenticated
+	} else {
+		err = fmt.Errorf("%w: role %q lacks %s", er.ErrPermissionDenied, role, permission)
+	}
+
+	a.recordDenied(c, principal, role, permission, err)
+	return err
+}
+
+// recordDenied записывает отказ в журнал аудита. Ошибка записи не меняет ответ клиенту.
+func (a *Authorizer) recordDenied(c echo.Context, principal *models.Principal, role string,
+	permission models.Permission, reason error) {
+	event := &models.AuditEvent{
+		Role:       role,
+		Permission: permission,
+		Method:     c.Request().Method,
+		Path:       c.Path(),
+		URI:        c.Request().URL.RequestURI(),
+		RemoteAddr: c.RealIP(),
+		PeerAddr:   c.Request().RemoteAddr,
+		Outcome:    models.AuditOutcomeDenied,
+		Reason:     reason.Error(),
+	}
+	if principal != nil && principal.AccountPublicID != "" {
+		event.AccountID = &principal.AccountPublicID
+		event.KeyID = &principal.KeyID
+	}
+
+	// Отказ записывается, даже если клиент уже закрыл соединение
+	if err := a.audit.Record(context.WithoutCancel(c.Request().Context()), event); err != nil {
+		log.Printf("[ERROR] Error recording denied %s %s: %v", event.Method, event.URI, err)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
+	"github.com/normalniydada/case_infotecs/internal/domain/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// denyAllPolicy - политика доступа без разрешений.
+type denyAllPolicy struct{}
+
+func (denyAllPolicy) Role(*models.Principal) string         { return "viewer" }
+func (denyAllPolicy) Allows(string, models.Permission) bool { return false }
+func (denyAllPolicy) HasRole(string) bool                   { return true }
+
+// recordingAudit запоминает записанные события журнала аудита.
+type recordingAudit struct {
+	service.AuditService
+	events []*models.AuditEvent
+}
+
+func (a *recordingAudit) Record(_ context.Context, event *models.AuditEvent) error {
+	a.events = append(a.events, event)
+	return nil
+}
+
+// TestRecordDeniedAddresses проверяет, что подделанный X-Forwarded-For не попадает
+// в журнал аудита: записываются адрес клиента по соединению и сам адрес соединения.
+func TestRecordDeniedAddresses(t *testing.T) {
+	extractor, err := NewIPExtractor(nil)
+	if err != nil {
+		t.Fatalf("NewIPExtractor() error = %v", err)
+	}
+
+	audit := &recordingAudit{}
+	e := echo.New()
+	e.IPExtractor = extractor
+	e.GET("/api/audit", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, NewAuthorizer(denyAllPolicy{}, audit).Require(models.PermissionAuditRead))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/audit", nil)
+	req.RemoteAddr = "203.0.113.7:40000"
+	req.Header.Set(echo.HeaderXForwardedFor, "198.51.100.1")
+	req.Header.Set(echo.HeaderXRealIP, "198.51.100.1")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if len(audit.events) != 1 {
+		t.Fatalf("recorded %d audit events, want 1", len(audit.events))
+	}
+	if event := audit.events[0]; event.RemoteAddr != "203.0.113.7" || event.PeerAddr != "203.0.113.7:40000" {
+		t.Fatalf("audit addresses = %q, %q, want the connection address", event.RemoteAddr, event.PeerAddr)
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net"
+)
+
+// NewIPExtractor возвращает способ определения адреса клиента для c.RealIP().
+// Без доверенных прокси адресом клиента считается адрес соединения, а заголовки
+// X-Forwarded-For и X-Real-IP игнорируются: их задает клиент, и подмена заголовка
+// давала бы новую корзину ограничения частоты и ложный адрес в журнале аудита.
+// С доверенными прокси адрес берется из X-Forwarded-For, но только за цепочкой
+// адресов из перечисленных диапазонов (loopback и частные сети доверенными не считаются).
+//
+// Параметры:
+//   - trustedProxies: диапазоны адресов доверенных обратных прокси в нотации CIDR
+//
+// Возвращает:
+//   - echo.IPExtractor: способ определения адреса клиента
+//   - error: ошибка разбора диапазона
+func NewIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %q: %w", cidr, err)
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
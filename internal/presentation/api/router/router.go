@@ -4,30 +4,162 @@ package router
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/normalniydada/case_infotecs/internal/domain/models"
 	interfaces2 "github.com/normalniydada/case_infotecs/internal/presentation/api/interfaces"
+	"github.com/normalniydada/case_infotecs/internal/presentation/api/middleware"
 )
 
-// NewRouter инициализирует маршруты API и связывает их с обработчиками.
+// senderLimited - пути маршрутов POST, запросы к которым дополнительно ограничиваются
+// по кошельку отправителя: переводы блокируют строку кошелька.
+var senderLimited = map[string]bool{
+	"/send":       true,
+	"/send/batch": true,
+	"/holds":      true,
+}
+
+// route описывает маршрут API и разрешение, которое он требует.
+type route struct {
+	method     string
+	path       string
+	handler    echo.HandlerFunc
+	permission models.Permission
+}
+
+// NewRouter инициализирует маршруты API, связывает их с обработчиками
+// и назначает каждому маршруту разрешение, проверяемое authorizer. Переводы
+// (POST /api/send, /api/send/batch, /api/holds) после проверки разрешения
+// ограничиваются по частоте для пары «вызывающий - кошелек отправителя».
 //
 // Параметры:
 //   - e: экземпляр Echo для настройки маршрутов
+//   - authorizer: проверка разрешений роли вызывающего
+//   - rateLimiter: ограничение частоты запросов по кошельку отправителя
+//   - accountHandler: обработчик учетных записей и API-ключей
 //   - walletHandler: обработчик операций с кошельками
 //   - transactionHandler: обработчик операций с транзакциями
+//   - ledgerHandler: обработчик операций с журналом двойной записи
+//   - holdHandler: обработчик холдов (резервирования средств)
+//   - scheduledTransferHandler: обработчик запланированных переводов
+//   - webhookHandler: обработчик подписок на вебхуки
+//   - streamHandler: обработчик потока транзакций в реальном времени
+//   - auditHandler: обработчик журнала аудита
+//   - pendingTransferHandler: обработчик отложенных переводов и политик согласования
 //
-// Определяемые маршруты:
+// Определяемые маршруты (с требуемым разрешением):
 //
-//	GET    /api/wallet/:address/balance - Получение баланса кошелька
-//	GET    /api/transactions           - Получение последних транзакций
-//	POST   /api/send                   - Перевод средств между кошельками
+//	POST   /api/accounts               - Создание учетной записи с первым API-ключом (accounts:manage)
+//	PATCH  /api/accounts/:id           - Назначение роли учетной записи (accounts:manage)
+//	POST   /api/keys                   - Создание API-ключа (keys:manage)
+//	GET    /api/keys                   - API-ключи учетной записи (keys:manage)
+//	DELETE /api/keys/:id               - Отзыв API-ключа (keys:manage)
+//	GET    /api/wallet/:address/balance - Получение баланса кошелька (wallets:read)
+//	GET    /api/wallet/:address/transactions - История транзакций кошелька (wallets:read)
+//	GET    /api/wallet/:address/limits - Лимиты расходов кошелька и остатки (wallets:read)
+//	GET    /api/transactions           - Получение последних транзакций (wallets:read)
+//	GET    /api/transactions/stream    - Поток зафиксированных переводов, Server-Sent Events (wallets:read)
+//	GET    /api/transactions/ws        - Поток зафиксированных переводов, WebSocket (wallets:read)
+//	GET    /api/transactions/:id       - Получение транзакции по идентификатору (wallets:read)
+//	POST   /api/transactions/:id/refund - Полный или частичный возврат транзакции (transfers:create)
+//	POST   /api/send                   - Перевод средств между кошельками (transfers:create)
+//	POST   /api/send/quote             - Расчет перевода (курс, комиссия) без выполнения (wallets:read)
+//	POST   /api/send/batch             - Атомарный пакетный перевод (transfers:create)
+//	GET    /api/pending-transfers      - Очередь переводов, ожидающих согласования (transfers:approve)
+//	GET    /api/pending-transfers/:id  - Получение отложенного перевода (wallets:read)
+//	POST   /api/pending-transfers/:id/approve - Одобрение отложенного перевода (transfers:approve)
+//	POST   /api/pending-transfers/:id/reject - Отклонение отложенного перевода (transfers:approve)
+//	POST   /api/wallets                - Создание кошелька (wallets:create; начальный баланс - wallets:mint)
+//	GET    /api/wallets/:address       - Получение информации о кошельке (wallets:read)
+//	DELETE /api/wallets/:address       - Закрытие кошелька (wallets:close)
+//	PUT    /api/wallets/:address/status - Заморозка и разморозка кошелька (wallets:freeze)
+//	GET    /api/wallets/:address/status-history - История статусов кошелька (audit:read)
+//	PUT    /api/wallets/:address/limits - Назначение уровня и лимитов расходов кошелька (limits:manage)
+//	GET    /api/wallets/:address/approval-policy - Политика согласования переводов кошелька (wallets:read)
+//	PUT    /api/wallets/:address/approval-policy - Назначение политики согласования, N из M (approvals:manage)
+//	GET    /api/ledger/verify          - Проверка согласованности журнала проводок (ledger:verify)
+//	POST   /api/holds                  - Резервирование средств, холд (transfers:create)
+//	GET    /api/holds/:id              - Получение холда (wallets:read)
+//	POST   /api/holds/:id/capture      - Списание холда, полное или частичное (transfers:create)
+//	POST   /api/holds/:id/void         - Отмена холда (transfers:create)
+//	POST   /api/scheduled-transfers    - Создание запланированного перевода (transfers:create)
+//	GET    /api/wallet/:address/scheduled-transfers - Запланированные переводы кошелька (wallets:read)
+//	GET    /api/scheduled-transfers/:id - Получение перевода и попыток его выполнения (wallets:read)
+//	PATCH  /api/scheduled-transfers/:id - Изменение, приостановка и возобновление перевода (transfers:create)
+//	DELETE /api/scheduled-transfers/:id - Отмена запланированного перевода (transfers:create)
+//	POST   /api/webhooks               - Подписка на события кошелька (webhooks:manage)
+//	GET    /api/wallet/:address/webhooks - Подписки кошелька (wallets:read)
+//	GET    /api/webhooks/:id           - Получение подписки (wallets:read)
+//	DELETE /api/webhooks/:id           - Удаление подписки (webhooks:manage)
+//	GET    /api/webhooks/:id/deliveries - Журнал доставок подписки (wallets:read)
+//	POST   /api/webhooks/:id/deliveries/:delivery_id/redeliver - Повторная доставка (webhooks:manage)
+//	GET    /api/audit                  - Журнал аудита (audit:read)
 //
 // Группировка:
 //
 //	Все маршруты префиксируются /api для версионирования и разделения API.
-func NewRouter(e *echo.Echo, walletHandler interfaces2.WalletHandler, transactionHandler interfaces2.TransactionHandler) {
+func NewRouter(e *echo.Echo, authorizer *middleware.Authorizer, rateLimiter *middleware.RateLimiter,
+	accountHandler interfaces2.AccountHandler,
+	walletHandler interfaces2.WalletHandler, transactionHandler interfaces2.TransactionHandler,
+	ledgerHandler interfaces2.LedgerHandler, holdHandler interfaces2.HoldHandler,
+	scheduledTransferHandler interfaces2.ScheduledTransferHandler, webhookHandler interfaces2.WebhookHandler,
+	streamHandler interfaces2.StreamHandler, auditHandler interfaces2.AuditHandler,
+	pendingTransferHandler interfaces2.PendingTransferHandler) {
+	routes := []route{
+		{echo.POST, "/accounts", accountHandler.CreateAccount, models.PermissionAccountsManage},
+		{echo.PATCH, "/accounts/:id", accountHandler.UpdateAccount, models.PermissionAccountsManage},
+		{echo.POST, "/keys", accountHandler.CreateKey, models.PermissionKeysManage},
+		{echo.GET, "/keys", accountHandler.ListKeys, models.PermissionKeysManage},
+		{echo.DELETE, "/keys/:id", accountHandler.RevokeKey, models.PermissionKeysManage},
+		{echo.GET, "/wallet/:address/balance", walletHandler.Balance, models.PermissionWalletsRead},
+		{echo.GET, "/wallet/:address/transactions", transactionHandler.History, models.PermissionWalletsRead},
+		{echo.GET, "/wallet/:address/limits", walletHandler.Limits, models.PermissionWalletsRead},
+		{echo.GET, "/transactions", transactionHandler.Last, models.PermissionWalletsRead},
+		{echo.GET, "/transactions/stream", streamHandler.SSE, models.PermissionWalletsRead},
+		{echo.GET, "/transactions/ws", streamHandler.WebSocket, models.PermissionWalletsRead},
+		{echo.GET, "/transactions/:id", transactionHandler.Get, models.PermissionWalletsRead},
+		{echo.POST, "/transactions/:id/refund", transactionHandler.Refund, models.PermissionTransfersCreate},
+		{echo.POST, "/send", walletHandler.Send, models.PermissionTransfersCreate},
+		{echo.POST, "/send/quote", walletHandler.Quote, models.PermissionWalletsRead},
+		{echo.POST, "/send/batch", walletHandler.SendBatch, models.PermissionTransfersCreate},
+		{echo.GET, "/pending-transfers", pendingTransferHandler.List, models.PermissionTransfersApprove},
+		{echo.GET, "/pending-transfers/:id", pendingTransferHandler.Get, models.PermissionWalletsRead},
+		{echo.POST, "/pending-transfers/:id/approve", pendingTransferHandler.Approve, models.PermissionTransfersApprove},
+		{echo.POST, "/pending-transfers/:id/reject", pendingTransferHandler.Reject, models.PermissionTransfersApprove},
+		{echo.POST, "/wallets", walletHandler.Create, models.PermissionWalletsCreate},
+		{echo.GET, "/wallets/:address", walletHandler.Get, models.PermissionWalletsRead},
+		{echo.DELETE, "/wallets/:address", walletHandler.Close, models.PermissionWalletsClose},
+		{echo.PUT, "/wallets/:address/status", walletHandler.SetStatus, models.PermissionWalletsFreeze},
+		{echo.GET, "/wallets/:address/status-history", walletHandler.StatusHistory, models.PermissionAuditRead},
+		{echo.PUT, "/wallets/:address/limits", walletHandler.SetLimits, models.PermissionLimitsManage},
+		{echo.GET, "/wallets/:address/approval-policy", pendingTransferHandler.Policy, models.PermissionWalletsRead},
+		{echo.PUT, "/wallets/:address/approval-policy", pendingTransferHandler.SetPolicy, models.PermissionApprovalsManage},
+		{echo.GET, "/ledger/verify", ledgerHandler.Verify, models.PermissionLedgerVerify},
+		{echo.POST, "/holds", holdHandler.Create, models.PermissionTransfersCreate},
+		{echo.GET, "/holds/:id", holdHandler.Get, models.PermissionWalletsRead},
+		{echo.POST, "/holds/:id/capture", holdHandler.Capture, models.PermissionTransfersCreate},
+		{echo.POST, "/holds/:id/void", holdHandler.Void, models.PermissionTransfersCreate},
+		{echo.POST, "/scheduled-transfers", scheduledTransferHandler.Create, models.PermissionTransfersCreate},
+		{echo.GET, "/wallet/:address/scheduled-transfers", scheduledTransferHandler.List, models.PermissionWalletsRead},
+		{echo.GET, "/scheduled-transfers/:id", scheduledTransferHandler.Get, models.PermissionWalletsRead},
+		{echo.PATCH, "/scheduled-transfers/:id", scheduledTransferHandler.Update, models.PermissionTransfersCreate},
+		{echo.DELETE, "/scheduled-transfers/:id", scheduledTransferHandler.Cancel, models.PermissionTransfersCreate},
+		{echo.POST, "/webhooks", webhookHandler.Create, models.PermissionWebhooksManage},
+		{echo.GET, "/wallet/:address/webhooks", webhookHandler.List, models.PermissionWalletsRead},
+		{echo.GET, "/webhooks/:id", webhookHandler.Get, models.PermissionWalletsRead},
+		{echo.DELETE, "/webhooks/:id", webhookHandler.Delete, models.PermissionWebhooksManage},
+		{echo.GET, "/webhooks/:id/deliveries", webhookHandler.Deliveries, models.PermissionWalletsRead},
+		{echo.POST, "/webhooks/:id/deliveries/:delivery_id/redeliver", webhookHandler.Redeliver,
+			models.PermissionWebhooksManage},
+		{echo.GET, "/audit", auditHandler.Events, models.PermissionAuditRead},
+	}
+
+	perWallet := rateLimiter.PerWallet()
+
 	api := e.Group("/api")
-	{
-		api.GET("/wallet/:address/balance", walletHandler.Balance)
-		api.GET("/transactions", transactionHandler.Last)
-		api.POST("/send", walletHandler.Send)
+	for _, r := range routes {
+		middlewares := []echo.MiddlewareFunc{authorizer.Require(r.permission)}
+		if r.method == echo.POST && senderLimited[r.path] {
+			middlewares = append(middlewares, perWallet)
+		}
+		api.Add(r.method, r.path, r.handler, middlewares...)
 	}
 }
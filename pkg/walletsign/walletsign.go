@@ -0,0 +1,116 @@
+// Package walletsign предоставляет клиентскую часть подписанных переводов:
+// генерацию ключей кошелька Ed25519, вычисление адреса кошелька по открытому ключу
+// и подпись перевода. Сервер проверяет подписи этим же пакетом, поэтому
+// каноническое представление перевода определено в одном месте.
+//
+// Пример:
+//
+//	pub, priv, _ := walletsign.GenerateKey()
+//	// POST /api/wallets {"public_key": walletsign.EncodePublicKey(pub)}
+//	transfer := walletsign.Transfer{From: walletsign.Address(pub), To: to, Amount: amount, Nonce: 1}
+//	signature := walletsign.Sign(priv, transfer)
+//	// POST /api/send {"from": ..., "to": ..., "amount": ..., "nonce": 1, "signature": signature}
+package walletsign
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/shopspring/decimal"
+	"strconv"
+	"strings"
+)
+
+// messageHeader - первая строка канонического представления перевода.
+// Отделяет подписи переводов от подписей других данных тем же ключом и задает версию формата.
+const messageHeader = "case_infotecs transfer v1"
+
+// ErrInvalidPublicKey возвращается при открытом ключе неверного формата или длины.
+var ErrInvalidPublicKey = errors.New("public key must be a hex-encoded 32-byte Ed25519 key")
+
+// Transfer описывает подписываемые параметры перевода.
+// Задается ровно одна из сумм Amount и TargetAmount (вторая - ноль), как в запросе POST /api/send.
+// Nonce - номер перевода кошелька: должен быть больше номера последнего выполненного
+// перевода с этого кошелька (поле nonce кошелька), поэтому подписанный запрос нельзя повторить.
+type Transfer struct {
+	From         string
+	To           string
+	Amount       decimal.Decimal
+	TargetAmount decimal.Decimal
+	Nonce        int64
+}
+
+// GenerateKey генерирует пару ключей кошелька Ed25519.
+//
+// Возвращает:
+//   - ed25519.PublicKey: открытый ключ (передается серверу при создании кошелька)
+//   - ed25519.PrivateKey: закрытый ключ (хранится только у клиента)
+//   - error: ошибка генератора случайных чисел
+func GenerateKey() (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	return ed25519.GenerateKey(rand.Reader)
+}
+
+// EncodePublicKey возвращает открытый ключ в hex-формате, в котором его принимает API.
+func EncodePublicKey(publicKey ed25519.PublicKey) string {
+	return hex.EncodeToString(publicKey)
+}
+
+// ParsePublicKey разбирает открытый ключ в hex-формате.
+//
+// Возвращает:
+//   - ed25519.PublicKey: открытый ключ
+//   - error: ErrInvalidPublicKey при неверном формате или длине
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return b, nil
+}
+
+// Address возвращает адрес кошелька с открытым ключом: SHA-256 ключа в hex-формате.
+func Address(publicKey ed25519.PublicKey) string {
+	hash := sha256.Sum256(publicKey)
+	return hex.EncodeToString(hash[:])
+}
+
+// Message возвращает каноническое представление перевода, которое подписывается.
+// Строки разделены символом перевода строки:
+//
+//	case_infotecs transfer v1
+//	from:<адрес отправителя>
+//	to:<адрес получателя>
+//	amount:<сумма списания>
+//	target_amount:<сумма зачисления>
+//	nonce:<номер перевода>
+//
+// Суммы записываются без экспоненты и незначащих нулей ("10.5", а не "10.50"; "0" для незаданной суммы).
+func Message(transfer Transfer) []byte {
+	return []byte(strings.Join([]string{
+		messageHeader,
+		"from:" + transfer.From,
+		"to:" + transfer.To,
+		"amount:" + transfer.Amount.String(),
+		"target_amount:" + transfer.TargetAmount.String(),
+		"nonce:" + strconv.FormatInt(transfer.Nonce, 10),
+	}, "\n"))
+}
+
+// Sign подписывает перевод закрытым ключом кошелька отправителя.
+//
+// Возвращает:
+//   - string: подпись в hex-формате (поле signature запроса)
+func Sign(privateKey ed25519.PrivateKey, transfer Transfer) string {
+	return hex.EncodeToString(ed25519.Sign(privateKey, Message(transfer)))
+}
+
+// Verify проверяет подпись перевода в hex-формате открытым ключом кошелька отправителя.
+func Verify(publicKey ed25519.PublicKey, transfer Transfer, signature string) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize || len(publicKey) != ed25519.PublicKeySize {
+		return false
+	}
+	return ed25519.Verify(publicKey, Message(transfer), sig)
+}